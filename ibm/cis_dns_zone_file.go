@@ -0,0 +1,550 @@
+package ibm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	cisDNSRecordTypePTR = "PTR"
+	cisDNSRecordTypeSOA = "SOA"
+
+	// cisDNSZoneFileAutomaticTTL is the CIS TTL of 1 second, which stands for
+	// an automatic TTL. It is used for records that have no TTL in the zone
+	// file, and for proxied records.
+	cisDNSZoneFileAutomaticTTL = 1
+
+	cisDNSZoneFileProxiedTag = "cf-proxied:true"
+
+	// TXT character strings can't be longer than 255 characters.
+	cisDNSZoneFileMaxStringLength = 255
+)
+
+// cisDNSZoneFileTypes are the record types that can be written in a zone file
+// managed by ibm_cis_dns_zone_file. Live records of the other types are left
+// alone.
+var cisDNSZoneFileTypes = map[string]bool{
+	cisDNSRecordTypeA:     true,
+	cisDNSRecordTypeAAAA:  true,
+	cisDNSRecordTypeCAA:   true,
+	cisDNSRecordTypeCNAME: true,
+	cisDNSRecordTypeMX:    true,
+	cisDNSRecordTypeNS:    true,
+	cisDNSRecordTypePTR:   true,
+	cisDNSRecordTypeSPF:   true,
+	cisDNSRecordTypeSRV:   true,
+	cisDNSRecordTypeTXT:   true,
+}
+
+// cisDNSZoneRecord is a DNS record in the form the CIS DNS records API takes
+// it. Name is the fully qualified, lower case name of the record without the
+// trailing dot. Priority is only used by MX records and Data only by SRV and
+// CAA records.
+type cisDNSZoneRecord struct {
+	ID       string
+	Name     string
+	Type     string
+	TTL      int64
+	Content  string
+	Priority int64
+	Proxied  bool
+	Data     map[string]interface{}
+}
+
+// String renders the record as a line of a BIND zone file. Two records are
+// the same record when their lines are the same.
+func (r cisDNSZoneRecord) String() string {
+	line := fmt.Sprintf("%s.\t%d\tIN\t%s\t%s", r.Name, r.TTL, r.Type, r.rdata())
+	if r.Proxied {
+		line += "\t; cf_tags=" + cisDNSZoneFileProxiedTag
+	}
+	return line
+}
+
+func (r cisDNSZoneRecord) rdata() string {
+	switch r.Type {
+	case cisDNSRecordTypeCNAME, cisDNSRecordTypeNS, cisDNSRecordTypePTR:
+		return r.Content + "."
+	case cisDNSRecordTypeMX:
+		return fmt.Sprintf("%d %s.", r.Priority, r.Content)
+	case cisDNSRecordTypeTXT, cisDNSRecordTypeSPF:
+		return quoteCISDNSZoneFileText(r.Content)
+	case cisDNSRecordTypeSRV:
+		return fmt.Sprintf("%d %d %d %s.", cisDNSZoneDataInt(r.Data["priority"]), cisDNSZoneDataInt(r.Data["weight"]),
+			cisDNSZoneDataInt(r.Data["port"]), cisDNSZoneDataString(r.Data["target"]))
+	case cisDNSRecordTypeCAA:
+		return fmt.Sprintf("%d %s %s", cisDNSZoneDataInt(r.Data["flags"]), cisDNSZoneDataString(r.Data["tag"]),
+			quoteCISDNSZoneFileText(cisDNSZoneDataString(r.Data["value"])))
+	}
+	return r.Content
+}
+
+// quoteCISDNSZoneFileText quotes s as one or more character strings of at
+// most 255 characters each.
+func quoteCISDNSZoneFileText(s string) string {
+	chunks := []string{}
+	for {
+		chunk := s
+		if len(chunk) > cisDNSZoneFileMaxStringLength {
+			chunk = chunk[:cisDNSZoneFileMaxStringLength]
+		}
+		s = s[len(chunk):]
+		chunk = strings.Replace(chunk, `\`, `\\`, -1)
+		chunk = strings.Replace(chunk, `"`, `\"`, -1)
+		chunks = append(chunks, `"`+chunk+`"`)
+		if s == "" {
+			return strings.Join(chunks, " ")
+		}
+	}
+}
+
+// cisDNSZoneDataInt returns the number v of the data of a record, which is
+// a float64 when the data was decoded from JSON.
+func cisDNSZoneDataInt(v interface{}) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	case string:
+		i, _ := strconv.ParseInt(n, 10, 64)
+		return i
+	}
+	return 0
+}
+
+func cisDNSZoneDataString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// renderCISDNSZoneFile renders records as a BIND zone file for the zone
+// origin. Records are sorted by name, type and data so that the same records
+// always render the same text.
+func renderCISDNSZoneFile(origin string, records []cisDNSZoneRecord) string {
+	lines := make([]string, 0, len(records))
+	for _, record := range records {
+		lines = append(lines, record.String())
+	}
+	sort.Strings(lines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", strings.ToLower(strings.TrimSuffix(origin, ".")))
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// cisDNSZoneFileToken is a word of a zone file entry. Quoted words may
+// contain white space and are never directives, names or numbers.
+type cisDNSZoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+// cisDNSZoneFileEntry is a directive or a resource record, which may span
+// several lines when it is enclosed in parentheses.
+type cisDNSZoneFileEntry struct {
+	line      int
+	tokens    []cisDNSZoneFileToken
+	blankName bool
+	comment   string
+}
+
+// readCISDNSZoneFileEntries splits a zone file into entries, dropping
+// comments other than the comment of the last line of each entry.
+func readCISDNSZoneFileEntries(reader io.Reader) ([]cisDNSZoneFileEntry, error) {
+	entries := []cisDNSZoneFileEntry{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var entry *cisDNSZoneFileEntry
+	depth := 0
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if entry == nil {
+			entry = &cisDNSZoneFileEntry{
+				line:      lineNumber,
+				blankName: line != "" && unicode.IsSpace(rune(line[0])),
+			}
+		}
+		entry.comment = ""
+
+		for i := 0; i < len(line); {
+			c := line[i]
+			switch {
+			case c == ';':
+				entry.comment = strings.TrimSpace(line[i+1:])
+				i = len(line)
+			case c == '(':
+				depth++
+				i++
+			case c == ')':
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+				}
+				depth--
+				i++
+			case c == '"':
+				var text strings.Builder
+				i++
+				closed := false
+				for i < len(line) {
+					if line[i] == '\\' && i+1 < len(line) {
+						text.WriteByte(line[i+1])
+						i += 2
+						continue
+					}
+					if line[i] == '"' {
+						closed = true
+						i++
+						break
+					}
+					text.WriteByte(line[i])
+					i++
+				}
+				if !closed {
+					return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+				}
+				entry.tokens = append(entry.tokens, cisDNSZoneFileToken{text: text.String(), quoted: true})
+			case unicode.IsSpace(rune(c)):
+				i++
+			default:
+				start := i
+				for i < len(line) && !unicode.IsSpace(rune(line[i])) && !strings.ContainsRune(`;()"`, rune(line[i])) {
+					i++
+				}
+				entry.tokens = append(entry.tokens, cisDNSZoneFileToken{text: line[start:i]})
+			}
+		}
+
+		if depth == 0 {
+			if len(entry.tokens) > 0 {
+				entries = append(entries, *entry)
+			}
+			entry = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", entry.line)
+	}
+	return entries, nil
+}
+
+// parseCISDNSZoneFileTTL parses a TTL in seconds or in the BIND notation
+// with units, e.g. 1h30m.
+func parseCISDNSZoneFileTTL(s string) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if ttl, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ttl, ttl >= 0
+	}
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, n int64
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int64(c-'0')
+			digits = true
+		case units[byte(unicode.ToLower(rune(c)))] != 0 && digits:
+			ttl += n * units[byte(unicode.ToLower(rune(c)))]
+			n = 0
+			digits = false
+		default:
+			return 0, false
+		}
+	}
+	if digits {
+		return 0, false
+	}
+	return ttl, true
+}
+
+// absoluteCISDNSZoneFileName resolves the name of a zone file against the
+// origin. The result is lower case and has no trailing dot.
+func absoluteCISDNSZoneFileName(name, origin string) string {
+	switch {
+	case name == "@":
+		name = origin
+	case strings.HasSuffix(name, "."):
+		name = strings.TrimSuffix(name, ".")
+	case origin != "":
+		name = name + "." + origin
+	}
+	return strings.ToLower(name)
+}
+
+// parseCISDNSZoneFile parses a BIND zone file for the zone origin into the
+// records it holds. The $ORIGIN and $TTL directives, comments, parentheses,
+// relative names and the @ shorthand are supported. SOA records and the NS
+// records of the zone apex are skipped because CIS manages them. The comment
+// cf_tags=cf-proxied:true, as written by a CIS export, marks a record as
+// proxied.
+func parseCISDNSZoneFile(origin string, reader io.Reader) ([]cisDNSZoneRecord, error) {
+	entries, err := readCISDNSZoneFileEntries(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := strings.ToLower(strings.TrimSuffix(origin, "."))
+	origin = zone
+	defaultTTL := int64(-1)
+	lastName := ""
+	lastTTL := int64(-1)
+	records := []cisDNSZoneRecord{}
+
+	for _, entry := range entries {
+		tokens := entry.tokens
+		if !tokens[0].quoted && strings.HasPrefix(tokens[0].text, "$") {
+			directive := strings.ToUpper(tokens[0].text)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN takes a domain name", entry.line)
+				}
+				origin = absoluteCISDNSZoneFileName(tokens[1].text, origin)
+			case "$TTL":
+				ttl, ok := int64(0), len(tokens) == 2
+				if ok {
+					ttl, ok = parseCISDNSZoneFileTTL(tokens[1].text)
+				}
+				if !ok {
+					return nil, fmt.Errorf("line %d: $TTL takes a TTL", entry.line)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("line %d: directive %s is not supported", entry.line, tokens[0].text)
+			}
+			continue
+		}
+
+		name := lastName
+		if !entry.blankName {
+			name = absoluteCISDNSZoneFileName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: record has no name", entry.line)
+		}
+		lastName = name
+
+		ttl := int64(-1)
+		for len(tokens) > 0 && !tokens[0].quoted {
+			if class := strings.ToUpper(tokens[0].text); class == "IN" {
+				tokens = tokens[1:]
+				continue
+			} else if class == "CH" || class == "HS" || class == "CS" {
+				return nil, fmt.Errorf("line %d: class %s is not supported", entry.line, tokens[0].text)
+			}
+			if v, ok := parseCISDNSZoneFileTTL(tokens[0].text); ok && ttl < 0 {
+				ttl = v
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: record has no type", entry.line)
+		}
+		recordType := strings.ToUpper(tokens[0].text)
+		rdata := tokens[1:]
+
+		if recordType == cisDNSRecordTypeSOA {
+			if ttl >= 0 {
+				lastTTL = ttl
+			}
+			continue
+		}
+		if recordType == cisDNSRecordTypeNS && name == zone {
+			continue
+		}
+		if !cisDNSZoneFileTypes[recordType] {
+			return nil, fmt.Errorf("line %d: record type %s is not supported", entry.line, tokens[0].text)
+		}
+
+		switch {
+		case ttl >= 0:
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		case lastTTL >= 0:
+			ttl = lastTTL
+		default:
+			ttl = cisDNSZoneFileAutomaticTTL
+		}
+		lastTTL = ttl
+
+		record := cisDNSZoneRecord{
+			Name: name,
+			Type: recordType,
+			TTL:  ttl,
+		}
+		if strings.Contains(entry.comment, "cf_tags=") && strings.Contains(entry.comment, cisDNSZoneFileProxiedTag) {
+			record.Proxied = true
+			record.TTL = cisDNSZoneFileAutomaticTTL
+		}
+		if err := parseCISDNSZoneFileRData(&record, rdata, origin); err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func parseCISDNSZoneFileRData(record *cisDNSZoneRecord, rdata []cisDNSZoneFileToken, origin string) error {
+	want := map[string]int{
+		cisDNSRecordTypeA:     1,
+		cisDNSRecordTypeAAAA:  1,
+		cisDNSRecordTypeCNAME: 1,
+		cisDNSRecordTypeNS:    1,
+		cisDNSRecordTypePTR:   1,
+		cisDNSRecordTypeMX:    2,
+		cisDNSRecordTypeCAA:   3,
+		cisDNSRecordTypeSRV:   4,
+	}
+	if n, ok := want[record.Type]; ok && len(rdata) != n {
+		return fmt.Errorf("%s record takes %d values, got %d", record.Type, n, len(rdata))
+	}
+	if len(rdata) == 0 {
+		return fmt.Errorf("%s record has no data", record.Type)
+	}
+
+	numbers := func(tokens []cisDNSZoneFileToken, names ...string) (map[string]interface{}, error) {
+		values := map[string]interface{}{}
+		for i, name := range names {
+			n, err := strconv.Atoi(tokens[i].text)
+			if err != nil || n < 0 || n > 65535 {
+				return nil, fmt.Errorf("invalid %s %q in %s record", name, tokens[i].text, record.Type)
+			}
+			values[name] = n
+		}
+		return values, nil
+	}
+
+	switch record.Type {
+	case cisDNSRecordTypeA, cisDNSRecordTypeAAAA:
+		ip := net.ParseIP(rdata[0].text)
+		if ip == nil || (ip.To4() != nil) != (record.Type == cisDNSRecordTypeA) {
+			return fmt.Errorf("invalid %s record address %q", record.Type, rdata[0].text)
+		}
+		record.Content = ip.String()
+	case cisDNSRecordTypeCNAME, cisDNSRecordTypeNS, cisDNSRecordTypePTR:
+		record.Content = absoluteCISDNSZoneFileName(rdata[0].text, origin)
+	case cisDNSRecordTypeMX:
+		values, err := numbers(rdata, "priority")
+		if err != nil {
+			return err
+		}
+		record.Priority = int64(values["priority"].(int))
+		record.Content = absoluteCISDNSZoneFileName(rdata[1].text, origin)
+	case cisDNSRecordTypeTXT, cisDNSRecordTypeSPF:
+		var text strings.Builder
+		for _, token := range rdata {
+			text.WriteString(token.text)
+		}
+		record.Content = text.String()
+	case cisDNSRecordTypeSRV:
+		labels := strings.SplitN(record.Name, ".", 3)
+		if len(labels) != 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return fmt.Errorf("SRV record name %q is not of the form _service._proto.name", record.Name)
+		}
+		values, err := numbers(rdata, "priority", "weight", "port")
+		if err != nil {
+			return err
+		}
+		values["service"] = labels[0]
+		values["proto"] = labels[1]
+		values["name"] = labels[2]
+		values["target"] = absoluteCISDNSZoneFileName(rdata[3].text, origin)
+		record.Data = values
+	case cisDNSRecordTypeCAA:
+		values, err := numbers(rdata, "flags")
+		if err != nil {
+			return err
+		}
+		values["tag"] = strings.ToLower(rdata[1].text)
+		values["value"] = rdata[2].text
+		record.Data = values
+	}
+	return nil
+}
+
+// diffCISDNSZoneRecords compares the records of a zone file with the live
+// records of the zone. Live records that are the same as a record of the zone
+// file are kept. The remaining live records are updated in place with the
+// remaining records of the same name and type, and the ones left over are
+// created or removed. Updated records carry the id of the live record.
+func diffCISDNSZoneRecords(desired, live []cisDNSZoneRecord) (create, update, remove []cisDNSZoneRecord) {
+	kept := map[string]int{}
+	for _, record := range live {
+		kept[record.String()]++
+	}
+	pending := []cisDNSZoneRecord{}
+	for _, record := range desired {
+		key := record.String()
+		if kept[key] > 0 {
+			kept[key]--
+			continue
+		}
+		pending = append(pending, record)
+	}
+
+	matched := map[string]int{}
+	for _, record := range desired {
+		matched[record.String()]++
+	}
+	stale := map[string][]cisDNSZoneRecord{}
+	for _, record := range live {
+		key := record.String()
+		if matched[key] > 0 {
+			matched[key]--
+			continue
+		}
+		nameType := record.Name + " " + record.Type
+		stale[nameType] = append(stale[nameType], record)
+	}
+
+	for _, record := range pending {
+		nameType := record.Name + " " + record.Type
+		if len(stale[nameType]) > 0 {
+			record.ID = stale[nameType][0].ID
+			stale[nameType] = stale[nameType][1:]
+			update = append(update, record)
+			continue
+		}
+		create = append(create, record)
+	}
+	for _, records := range stale {
+		remove = append(remove, records...)
+	}
+
+	for _, records := range [][]cisDNSZoneRecord{create, update, remove} {
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].String() < records[j].String()
+		})
+	}
+	return create, update, remove
+}
@@ -0,0 +1,183 @@
+package ibm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	cisedgefunctionv1 "github.com/IBM/networking-go-sdk/edgefunctionsapiv1"
+)
+
+// Uploading a script with bindings and listing the names of the scripts are
+// not part of networking-go-sdk yet. These calls go through the BaseService of
+// the CIS edge functions client, see cisRequest.
+
+const (
+	cisEdgeFunctionsActionsPath = "/v1/{crn}/workers/scripts"
+	cisEdgeFunctionsActionPath  = "/v1/{crn}/workers/scripts/{script_name}"
+
+	cisEdgeFunctionsScriptExt = ".js"
+)
+
+// cisEdgeFunctionsBinding makes a variable available to the script
+type cisEdgeFunctionsBinding struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// cisEdgeFunctionsScriptSHA256 returns the hex encoded SHA-256 of a script
+// with \n line endings.
+func cisEdgeFunctionsScriptSHA256(script string) string {
+	sum := sha256.Sum256([]byte(normalizeCISEdgeFunctionsScript(script)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeCISEdgeFunctionsScript uses \n line endings, so that the same
+// script checked out on different systems has the same hash.
+func normalizeCISEdgeFunctionsScript(script string) string {
+	return strings.Replace(script, "\r\n", "\n", -1)
+}
+
+func readCISEdgeFunctionsScriptFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Error reading script file %s: %s", path, err)
+	}
+	return normalizeCISEdgeFunctionsScript(string(content)), nil
+}
+
+// bundleCISEdgeFunctionsScriptDir concatenates the .js files of dir and its
+// subdirectories in the order of their paths, each one preceded by a comment
+// with its path. Hidden files and directories and node_modules are skipped.
+// The files share the global scope of the script.
+func bundleCISEdgeFunctionsScriptDir(dir string) (string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(name, ".") && filepath.Ext(name) == cisEdgeFunctionsScriptExt {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error reading script directory %s: %s", dir, err)
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("Script directory %s has no %s files", dir, cisEdgeFunctionsScriptExt)
+	}
+	sort.Strings(files)
+
+	var bundle strings.Builder
+	for i, file := range files {
+		content, err := readCISEdgeFunctionsScriptFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			bundle.WriteString("\n")
+		}
+		bundle.WriteString("// " + file + "\n")
+		bundle.WriteString(content)
+		if !strings.HasSuffix(content, "\n") {
+			bundle.WriteString("\n")
+		}
+	}
+	return bundle.String(), nil
+}
+
+// uploadCISEdgeFunctionsAction creates or replaces a script. The script and
+// its bindings are sent as a multipart form, as the SDK only sends the script.
+func uploadCISEdgeFunctionsAction(sess *cisedgefunctionv1.EdgeFunctionsApiV1, scriptName, script string, bindings []cisEdgeFunctionsBinding) (*core.DetailedResponse, error) {
+	if len(bindings) == 0 {
+		opt := sess.NewUpdateEdgeFunctionsActionOptions(scriptName)
+		opt.SetEdgeFunctionsAction(ioutil.NopCloser(strings.NewReader(script)))
+		_, response, err := sess.UpdateEdgeFunctionsAction(opt)
+		return response, err
+	}
+
+	builder := core.NewRequestBuilder(core.PUT)
+	pathParamsMap := map[string]string{
+		"crn":         *sess.Crn,
+		"script_name": scriptName,
+	}
+	_, err := builder.ResolveRequestURL(sess.Service.Options.URL, cisEdgeFunctionsActionPath, pathParamsMap)
+	if err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	metadata := map[string]interface{}{
+		"body_part": "script",
+		"bindings":  bindings,
+	}
+	builder.AddFormData("metadata", "", "application/json", metadata)
+	builder.AddFormData("script", scriptName+cisEdgeFunctionsScriptExt, "application/javascript", script)
+
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return sess.Service.Request(request, &cisResponse{})
+}
+
+func getCISEdgeFunctionsActionScript(sess *cisedgefunctionv1.EdgeFunctionsApiV1, scriptName string) (string, *core.DetailedResponse, error) {
+	opt := sess.NewGetEdgeFunctionsActionOptions(scriptName)
+	result, response, err := sess.GetEdgeFunctionsAction(opt)
+	if err != nil {
+		return "", response, err
+	}
+	defer result.Close()
+	content, err := ioutil.ReadAll(result)
+	if err != nil {
+		return "", response, err
+	}
+	return string(content), response, nil
+}
+
+// listCISEdgeFunctionsActions returns the scripts of the instance by name.
+// The SDK drops the names from the list.
+func listCISEdgeFunctionsActions(sess *cisedgefunctionv1.EdgeFunctionsApiV1) ([]string, []cisedgefunctionv1.EdgeFunctionsActionResp, *core.DetailedResponse, error) {
+	items := []map[string]json.RawMessage{}
+	pathParamsMap := map[string]string{
+		"crn": *sess.Crn,
+	}
+	response, err := cisRequest(sess.Service, core.GET, cisEdgeFunctionsActionsPath, pathParamsMap, nil, nil, &items)
+	if err != nil {
+		return nil, nil, response, err
+	}
+	names := make([]string, 0, len(items))
+	scripts := make([]cisedgefunctionv1.EdgeFunctionsActionResp, 0, len(items))
+	for _, item := range items {
+		var name string
+		if err := json.Unmarshal(item["id"], &name); err != nil {
+			return nil, nil, response, fmt.Errorf("Error decoding the name of an edge functions action: %s", err)
+		}
+		var script *cisedgefunctionv1.EdgeFunctionsActionResp
+		if err := cisedgefunctionv1.UnmarshalEdgeFunctionsActionResp(item, &script); err != nil {
+			return nil, nil, response, err
+		}
+		names = append(names, name)
+		scripts = append(scripts, *script)
+	}
+	return names, scripts, response, nil
+}
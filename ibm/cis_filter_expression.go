@@ -0,0 +1,457 @@
+package ibm
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter expressions are written in the wire-filter language, e.g.
+//
+//	http.request.uri.path contains "/admin" and not ip.src in {10.0.0.0/8 192.168.0.1}
+//
+// validateCISFilterExpression checks the syntax of an expression, the names
+// of its fields and the types of the values they are compared with, so that
+// mistakes are reported at plan time instead of by the API. The language has
+// more fields than cisFilterFields, so other dotted fields such as
+// http.request.headers["x-api-key"] are accepted with any type of value.
+
+type cisFilterFieldType int
+
+const (
+	cisFilterString cisFilterFieldType = iota
+	cisFilterInt
+	cisFilterIP
+	cisFilterBool
+	// cisFilterAny is the type of a field missing from cisFilterFields
+	cisFilterAny
+)
+
+func (t cisFilterFieldType) String() string {
+	switch t {
+	case cisFilterInt:
+		return "a number"
+	case cisFilterIP:
+		return "an IP address"
+	case cisFilterBool:
+		return "a boolean"
+	case cisFilterAny:
+		return "a value"
+	}
+	return "a string"
+}
+
+// cisFilterFields are the fields that filter expressions can use.
+var cisFilterFields = map[string]cisFilterFieldType{
+	"http.cookie":                     cisFilterString,
+	"http.host":                       cisFilterString,
+	"http.referer":                    cisFilterString,
+	"http.request.full_uri":           cisFilterString,
+	"http.request.method":             cisFilterString,
+	"http.request.uri":                cisFilterString,
+	"http.request.uri.path":           cisFilterString,
+	"http.request.uri.query":          cisFilterString,
+	"http.request.version":            cisFilterString,
+	"http.user_agent":                 cisFilterString,
+	"http.x_forwarded_for":            cisFilterString,
+	"raw.http.request.full_uri":       cisFilterString,
+	"raw.http.request.uri":            cisFilterString,
+	"raw.http.request.uri.path":       cisFilterString,
+	"raw.http.request.uri.query":      cisFilterString,
+	"ip.geoip.continent":              cisFilterString,
+	"ip.geoip.country":                cisFilterString,
+	"ip.geoip.subdivision_1_iso_code": cisFilterString,
+	"ip.geoip.subdivision_2_iso_code": cisFilterString,
+	"ip.geoip.asnum":                  cisFilterInt,
+	"ip.src.asnum":                    cisFilterInt,
+	"cf.threat_score":                 cisFilterInt,
+	"cf.bot_management.score":         cisFilterInt,
+	"cf.edge.server_port":             cisFilterInt,
+	"http.request.timestamp.sec":      cisFilterInt,
+	"ip.src":                          cisFilterIP,
+	"cf.edge.server_ip":               cisFilterIP,
+	"ssl":                             cisFilterBool,
+	"cf.client.bot":                   cisFilterBool,
+	"ip.geoip.is_in_european_union":   cisFilterBool,
+}
+
+// cisFilterFunctions are the functions that can transform a string field,
+// with the type of their result.
+var cisFilterFunctions = map[string]cisFilterFieldType{
+	"lower":      cisFilterString,
+	"upper":      cisFilterString,
+	"url_decode": cisFilterString,
+	"len":        cisFilterInt,
+}
+
+// cisFilterOperators maps the operators of the language, in both their
+// English and C-like notation, to their English notation.
+var cisFilterOperators = map[string]string{
+	"eq": "eq", "==": "eq",
+	"ne": "ne", "!=": "ne",
+	"lt": "lt", "<": "lt",
+	"le": "le", "<=": "le",
+	"gt": "gt", ">": "gt",
+	"ge": "ge", ">=": "ge",
+	"contains": "contains",
+	"matches":  "matches", "~": "matches",
+	"in": "in",
+}
+
+const (
+	cisFilterTokenEOF = iota
+	cisFilterTokenWord
+	cisFilterTokenString
+	cisFilterTokenPunct
+)
+
+type cisFilterToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+func (t cisFilterToken) String() string {
+	switch t.kind {
+	case cisFilterTokenEOF:
+		return "end of expression"
+	case cisFilterTokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func isCISFilterWordRune(r rune, first bool) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == ':' {
+		return true
+	}
+	return !first && (r == '.' || r == '/')
+}
+
+func lexCISFilterExpression(expression string) ([]cisFilterToken, error) {
+	tokens := []cisFilterToken{}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			start := i
+			var text strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					text.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				text.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			tokens = append(tokens, cisFilterToken{kind: cisFilterTokenString, text: text.String(), pos: start})
+		case isCISFilterWordRune(r, true):
+			start := i
+			for i < len(runes) && isCISFilterWordRune(runes[i], false) {
+				i++
+			}
+			tokens = append(tokens, cisFilterToken{kind: cisFilterTokenWord, text: string(runes[start:i]), pos: start})
+		default:
+			punct := ""
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<=", ">=", "&&", "||", "^^":
+					punct = two
+				}
+			}
+			if punct == "" && strings.ContainsRune("<>~!(){}[]*", r) {
+				punct = string(r)
+			}
+			if punct == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+			}
+			tokens = append(tokens, cisFilterToken{kind: cisFilterTokenPunct, text: punct, pos: i})
+			i += len(punct)
+		}
+	}
+	tokens = append(tokens, cisFilterToken{kind: cisFilterTokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+type cisFilterParser struct {
+	tokens []cisFilterToken
+	next   int
+	// unknown are the fields missing from cisFilterFields, in order of
+	// appearance
+	unknown []string
+}
+
+func (p *cisFilterParser) peek() cisFilterToken {
+	return p.tokens[p.next]
+}
+
+func (p *cisFilterParser) advance() cisFilterToken {
+	t := p.tokens[p.next]
+	if t.kind != cisFilterTokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *cisFilterParser) errorf(t cisFilterToken, format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), t.pos+1)
+}
+
+// is reports whether t is one of the given keywords or punctuation marks.
+func (t cisFilterToken) is(texts ...string) bool {
+	if t.kind != cisFilterTokenWord && t.kind != cisFilterTokenPunct {
+		return false
+	}
+	for _, text := range texts {
+		if t.text == text {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *cisFilterParser) expect(text string) error {
+	if t := p.advance(); !t.is(text) {
+		return p.errorf(t, "expected %q, found %s", text, t)
+	}
+	return nil
+}
+
+func (p *cisFilterParser) parseBinary(operators []string, operand func() error) error {
+	if err := operand(); err != nil {
+		return err
+	}
+	for p.peek().is(operators...) {
+		p.advance()
+		if err := operand(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *cisFilterParser) parseOr() error {
+	return p.parseBinary([]string{"or", "||"}, p.parseXor)
+}
+
+func (p *cisFilterParser) parseXor() error {
+	return p.parseBinary([]string{"xor", "^^"}, p.parseAnd)
+}
+
+func (p *cisFilterParser) parseAnd() error {
+	return p.parseBinary([]string{"and", "&&"}, p.parseNot)
+}
+
+func (p *cisFilterParser) parseNot() error {
+	if p.peek().is("not", "!") {
+		p.advance()
+		return p.parseNot()
+	}
+	if p.peek().is("(") {
+		p.advance()
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		return p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+// parseField parses a field, or a function applied to a string field, and
+// returns its name and type.
+func (p *cisFilterParser) parseField() (string, cisFilterFieldType, error) {
+	t := p.advance()
+	if t.kind != cisFilterTokenWord {
+		return "", 0, p.errorf(t, "expected a field, found %s", t)
+	}
+	if resultType, ok := cisFilterFunctions[t.text]; ok && p.peek().is("(") {
+		p.advance()
+		name, fieldType, err := p.parseField()
+		if err != nil {
+			return "", 0, err
+		}
+		if fieldType != cisFilterString && fieldType != cisFilterAny {
+			return "", 0, p.errorf(t, "%s() takes a string, but %s is %s", t.text, name, fieldType)
+		}
+		if err := p.expect(")"); err != nil {
+			return "", 0, err
+		}
+		return fmt.Sprintf("%s(%s)", t.text, name), resultType, nil
+	}
+	if fieldType, ok := cisFilterFields[t.text]; ok {
+		return t.text, fieldType, nil
+	}
+	if !strings.Contains(t.text, ".") {
+		return "", 0, p.errorf(t, "unknown field %q", t.text)
+	}
+	p.unknown = append(p.unknown, t.text)
+	// Map and array fields are indexed, e.g. http.request.uri.args["id"][0]
+	// or http.request.headers.names[*]
+	for p.peek().is("[") {
+		p.advance()
+		index := p.advance()
+		_, err := strconv.ParseUint(index.text, 10, 64)
+		if index.kind != cisFilterTokenString && !(index.kind == cisFilterTokenWord && err == nil) && !index.is("*") {
+			return "", 0, p.errorf(index, "expected an index of %s, found %s", t.text, index)
+		}
+		if err := p.expect("]"); err != nil {
+			return "", 0, err
+		}
+	}
+	return t.text, cisFilterAny, nil
+}
+
+func (p *cisFilterParser) parseComparison() error {
+	start := p.peek()
+	name, fieldType, err := p.parseField()
+	if err != nil {
+		return err
+	}
+	opToken := p.peek()
+	op, isOp := "", false
+	if opToken.kind == cisFilterTokenWord || opToken.kind == cisFilterTokenPunct {
+		op, isOp = cisFilterOperators[opToken.text]
+	}
+	if !isOp {
+		if fieldType != cisFilterBool && fieldType != cisFilterAny {
+			return p.errorf(start, "%s is %s and needs a comparison", name, fieldType)
+		}
+		return nil
+	}
+	p.advance()
+
+	switch {
+	case fieldType == cisFilterBool:
+		return p.errorf(opToken, "%s is a boolean and can't be compared", name)
+	case (op == "contains" || op == "matches") && fieldType != cisFilterString && fieldType != cisFilterAny:
+		return p.errorf(opToken, "%s only applies to strings, but %s is %s", op, name, fieldType)
+	case (op == "lt" || op == "le" || op == "gt" || op == "ge") && fieldType == cisFilterIP:
+		return p.errorf(opToken, "%s doesn't apply to IP addresses", op)
+	}
+
+	if op != "in" {
+		return p.parseValue(name, fieldType, op)
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	if p.peek().is("}") {
+		return p.errorf(p.peek(), "the set of values of %s is empty", name)
+	}
+	for !p.peek().is("}") {
+		if p.peek().kind == cisFilterTokenEOF {
+			return p.errorf(p.peek(), "expected \"}\", found %s", p.peek())
+		}
+		if err := p.parseValue(name, fieldType, op); err != nil {
+			return err
+		}
+	}
+	p.advance()
+	return nil
+}
+
+// parseValue parses a value that the field name of type fieldType is compared
+// with by the operator op. The values of a set may also be IP ranges and
+// number ranges.
+func (p *cisFilterParser) parseValue(name string, fieldType cisFilterFieldType, op string) error {
+	t := p.advance()
+	switch fieldType {
+	case cisFilterString:
+		if t.kind != cisFilterTokenString {
+			return p.errorf(t, "%s is a string and must be compared with a quoted string, found %s", name, t)
+		}
+		if op == "matches" {
+			if _, err := regexp.Compile(t.text); err != nil {
+				return p.errorf(t, "invalid regular expression %s: %s", t, err)
+			}
+		}
+		return nil
+	case cisFilterInt:
+		if t.kind == cisFilterTokenWord {
+			bounds := strings.SplitN(t.text, "..", 2)
+			valid := true
+			for _, bound := range bounds {
+				if _, err := strconv.ParseUint(bound, 10, 64); err != nil {
+					valid = false
+				}
+			}
+			if valid && (len(bounds) == 1 || op == "in") {
+				return nil
+			}
+		}
+		return p.errorf(t, "%s is a number and can't be compared with %s", name, t)
+	case cisFilterIP:
+		if t.kind == cisFilterTokenWord {
+			if net.ParseIP(t.text) != nil {
+				return nil
+			}
+			if _, _, err := net.ParseCIDR(t.text); err == nil && op == "in" {
+				return nil
+			}
+		}
+		return p.errorf(t, "%s is an IP address and can't be compared with %s", name, t)
+	case cisFilterAny:
+		// The type of the field is unknown, any string, number or IP address goes
+		if t.kind == cisFilterTokenString {
+			if op == "matches" {
+				if _, err := regexp.Compile(t.text); err != nil {
+					return p.errorf(t, "invalid regular expression %s: %s", t, err)
+				}
+			}
+			return nil
+		}
+		if t.kind != cisFilterTokenWord {
+			return p.errorf(t, "expected a value to compare %s with, found %s", name, t)
+		}
+	}
+	return nil
+}
+
+// validateCISFilterExpression checks that expression is a valid filter
+// expression, and returns the fields it uses that are missing from
+// cisFilterFields.
+func validateCISFilterExpression(expression string) ([]string, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	tokens, err := lexCISFilterExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &cisFilterParser{tokens: tokens}
+	if err := p.parseOr(); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != cisFilterTokenEOF {
+		return nil, p.errorf(t, "expected \"and\", \"or\" or \"xor\", found %s", t)
+	}
+	return p.unknown, nil
+}
+
+func validateCISFilterExpressionFunc(v interface{}, k string) (ws []string, errors []error) {
+	unknown, err := validateCISFilterExpression(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid filter expression: %s", k, err))
+	}
+	for _, field := range unknown {
+		ws = append(ws, fmt.Sprintf("%q uses the field %s, which the provider doesn't know: check its name, the values it is compared with aren't checked", k, field))
+	}
+	return
+}
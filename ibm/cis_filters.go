@@ -0,0 +1,208 @@
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	cislockdownv1 "github.com/IBM/networking-go-sdk/zonelockdownv1"
+)
+
+// The filters (/v1/{crn}/zones/{zone_identifier}/filters) and firewall rules
+// (/v1/{crn}/zones/{zone_identifier}/firewall/rules) APIs are not part of
+// networking-go-sdk yet. These calls go through the BaseService of the CIS
+// firewall lockdown client the same way the generated SDK methods do, and
+// should be replaced by the SDK methods once they are available.
+
+const (
+	cisFiltersPath       = "/v1/{crn}/zones/{zone_identifier}/filters"
+	cisFilterPath        = "/v1/{crn}/zones/{zone_identifier}/filters/{id}"
+	cisFirewallRulesPath = "/v1/{crn}/zones/{zone_identifier}/firewall/rules"
+	cisFirewallRulePath  = "/v1/{crn}/zones/{zone_identifier}/firewall/rules/{id}"
+
+	cisFiltersPerPage = 100
+)
+
+// cisFilter is an expression that matches requests, used by firewall rules
+type cisFilter struct {
+	ID          *string `json:"id,omitempty"`
+	Expression  *string `json:"expression,omitempty"`
+	Paused      *bool   `json:"paused,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// cisFirewallRule applies an action to the requests matched by a filter
+type cisFirewallRule struct {
+	ID          *string    `json:"id,omitempty"`
+	Filter      *cisFilter `json:"filter,omitempty"`
+	Action      *string    `json:"action,omitempty"`
+	Priority    *int64     `json:"priority,omitempty"`
+	Paused      *bool      `json:"paused,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Products    []string   `json:"products,omitempty"`
+}
+
+// cisResponse is the envelope of the responses of the CIS APIs
+type cisResponse struct {
+	Result     json.RawMessage `json:"result"`
+	ResultInfo *struct {
+		Page       int64 `json:"page"`
+		PerPage    int64 `json:"per_page"`
+		Count      int64 `json:"count"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"result_info,omitempty"`
+}
+
+// cisRequest sends a request to a CIS API that networking-go-sdk doesn't
+// cover yet. pathParamsMap resolves the parameters of path, and the result of
+// the response is decoded into result.
+func cisRequest(service *core.BaseService, method, path string, pathParamsMap map[string]string, query map[string]string, body, result interface{}) (*core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	_, err := builder.ResolveRequestURL(service.Options.URL, path, pathParamsMap)
+	if err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	for name, value := range query {
+		builder.AddQuery(name, value)
+	}
+	if body != nil {
+		builder.AddHeader("Content-Type", "application/json")
+		if _, err := builder.SetBodyContentJSON(body); err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	envelope := &cisResponse{}
+	response, err := service.Request(request, envelope)
+	if err != nil {
+		return response, err
+	}
+	if result != nil && len(envelope.Result) > 0 {
+		if err := json.Unmarshal(envelope.Result, result); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+func cisFiltersRequest(sess *cislockdownv1.ZoneLockdownV1, method, path, id string, query map[string]string, body, result interface{}) (*core.DetailedResponse, error) {
+	pathParamsMap := map[string]string{
+		"crn":             *sess.Crn,
+		"zone_identifier": *sess.ZoneIdentifier,
+	}
+	if id != "" {
+		pathParamsMap["id"] = id
+	}
+	return cisRequest(sess.Service, method, path, pathParamsMap, query, body, result)
+}
+
+func createCISFilter(sess *cislockdownv1.ZoneLockdownV1, filter cisFilter) (*cisFilter, *core.DetailedResponse, error) {
+	filters := []cisFilter{}
+	response, err := cisFiltersRequest(sess, core.POST, cisFiltersPath, "", nil, []cisFilter{filter}, &filters)
+	if err != nil {
+		return nil, response, err
+	}
+	if len(filters) != 1 {
+		return nil, response, fmt.Errorf("Expected one filter to be created, got %d", len(filters))
+	}
+	return &filters[0], response, nil
+}
+
+func getCISFilter(sess *cislockdownv1.ZoneLockdownV1, id string) (*cisFilter, *core.DetailedResponse, error) {
+	filter := &cisFilter{}
+	response, err := cisFiltersRequest(sess, core.GET, cisFilterPath, id, nil, nil, filter)
+	if err != nil {
+		return nil, response, err
+	}
+	return filter, response, nil
+}
+
+func updateCISFilter(sess *cislockdownv1.ZoneLockdownV1, filter cisFilter) (*cisFilter, *core.DetailedResponse, error) {
+	result := &cisFilter{}
+	response, err := cisFiltersRequest(sess, core.PUT, cisFilterPath, *filter.ID, nil, filter, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+func deleteCISFilter(sess *cislockdownv1.ZoneLockdownV1, id string) (*core.DetailedResponse, error) {
+	return cisFiltersRequest(sess, core.DELETE, cisFilterPath, id, nil, nil, nil)
+}
+
+func listCISFilters(sess *cislockdownv1.ZoneLockdownV1) ([]cisFilter, *core.DetailedResponse, error) {
+	allrecs := []cisFilter{}
+	for page := 1; ; page++ {
+		filters := []cisFilter{}
+		query := map[string]string{
+			"page":     fmt.Sprint(page),
+			"per_page": fmt.Sprint(cisFiltersPerPage),
+		}
+		response, err := cisFiltersRequest(sess, core.GET, cisFiltersPath, "", query, nil, &filters)
+		if err != nil {
+			return nil, response, err
+		}
+		allrecs = append(allrecs, filters...)
+		if len(filters) < cisFiltersPerPage {
+			return allrecs, response, nil
+		}
+	}
+}
+
+func createCISFirewallRule(sess *cislockdownv1.ZoneLockdownV1, rule cisFirewallRule) (*cisFirewallRule, *core.DetailedResponse, error) {
+	rules := []cisFirewallRule{}
+	response, err := cisFiltersRequest(sess, core.POST, cisFirewallRulesPath, "", nil, []cisFirewallRule{rule}, &rules)
+	if err != nil {
+		return nil, response, err
+	}
+	if len(rules) != 1 {
+		return nil, response, fmt.Errorf("Expected one firewall rule to be created, got %d", len(rules))
+	}
+	return &rules[0], response, nil
+}
+
+func getCISFirewallRule(sess *cislockdownv1.ZoneLockdownV1, id string) (*cisFirewallRule, *core.DetailedResponse, error) {
+	rule := &cisFirewallRule{}
+	response, err := cisFiltersRequest(sess, core.GET, cisFirewallRulePath, id, nil, nil, rule)
+	if err != nil {
+		return nil, response, err
+	}
+	return rule, response, nil
+}
+
+func updateCISFirewallRule(sess *cislockdownv1.ZoneLockdownV1, rule cisFirewallRule) (*cisFirewallRule, *core.DetailedResponse, error) {
+	result := &cisFirewallRule{}
+	response, err := cisFiltersRequest(sess, core.PUT, cisFirewallRulePath, *rule.ID, nil, rule, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+func deleteCISFirewallRule(sess *cislockdownv1.ZoneLockdownV1, id string) (*core.DetailedResponse, error) {
+	return cisFiltersRequest(sess, core.DELETE, cisFirewallRulePath, id, nil, nil, nil)
+}
+
+func listCISFirewallRules(sess *cislockdownv1.ZoneLockdownV1) ([]cisFirewallRule, *core.DetailedResponse, error) {
+	allrecs := []cisFirewallRule{}
+	for page := 1; ; page++ {
+		rules := []cisFirewallRule{}
+		query := map[string]string{
+			"page":     fmt.Sprint(page),
+			"per_page": fmt.Sprint(cisFiltersPerPage),
+		}
+		response, err := cisFiltersRequest(sess, core.GET, cisFirewallRulesPath, "", query, nil, &rules)
+		if err != nil {
+			return nil, response, err
+		}
+		allrecs = append(allrecs, rules...)
+		if len(rules) < cisFiltersPerPage {
+			return allrecs, response, nil
+		}
+	}
+}
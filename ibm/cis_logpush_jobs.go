@@ -0,0 +1,212 @@
+package ibm
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	ciszonesv1 "github.com/IBM/networking-go-sdk/zonesv1"
+)
+
+// The logpush jobs API (/v2/{crn}/zones/{zone_id}/logpush/jobs) is not part of
+// networking-go-sdk yet. These calls go through the BaseService of the CIS
+// zones client, see cisRequest.
+
+const (
+	cisLogpushJobsPath = "/v2/{crn}/zones/{zone_id}/logpush/jobs"
+	cisLogpushJobPath  = "/v2/{crn}/zones/{zone_id}/logpush/jobs/{job_id}"
+
+	cisLogpushDestinationCOS    = "cos"
+	cisLogpushDestinationLogDNA = "logdna"
+)
+
+var (
+	cisLogpushBucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	cisLogpushRegionRegexp     = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)?$`)
+	cisLogpushGUIDRegexp       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	cisLogpushHostnameRegexp   = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// cisLogpushJob pushes the logs of a dataset of a zone to a destination
+type cisLogpushJob struct {
+	ID              *int64  `json:"id,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	Enabled         *bool   `json:"enabled,omitempty"`
+	Dataset         *string `json:"dataset,omitempty"`
+	Frequency       *string `json:"frequency,omitempty"`
+	LogpullOptions  *string `json:"logpull_options,omitempty"`
+	DestinationConf *string `json:"destination_conf,omitempty"`
+	LastComplete    *string `json:"last_complete,omitempty"`
+	LastError       *string `json:"last_error,omitempty"`
+	ErrorMessage    *string `json:"error_message,omitempty"`
+}
+
+// cisLogpushJobRequest is the body of the create and update requests. The
+// destination is given either as cos or as logdna.
+type cisLogpushJobRequest struct {
+	Name               *string           `json:"name,omitempty"`
+	Enabled            *bool             `json:"enabled,omitempty"`
+	Dataset            *string           `json:"dataset,omitempty"`
+	Frequency          *string           `json:"frequency,omitempty"`
+	LogpullOptions     *string           `json:"logpull_options,omitempty"`
+	OwnershipChallenge *string           `json:"ownership_challenge,omitempty"`
+	Cos                map[string]string `json:"cos,omitempty"`
+	Logdna             map[string]string `json:"logdna,omitempty"`
+}
+
+// validateCISLogpushDestination checks the destination of a logpush job, so
+// that mistakes show up at plan time instead of when the job is created.
+func validateCISLogpushDestination(destination map[string]interface{}) error {
+	value := func(key string) string {
+		if v, ok := destination[key].(string); ok {
+			return strings.TrimSpace(v)
+		}
+		return ""
+	}
+	destinationType := value(cisLogpushJobDestinationType)
+
+	var required, notAllowed []string
+	switch destinationType {
+	case cisLogpushDestinationCOS:
+		required = []string{cisLogpushJobBucketName, cisLogpushJobRegion, cisLogpushJobInstanceID}
+		notAllowed = []string{cisLogpushJobHostname, cisLogpushJobIngressKey}
+	case cisLogpushDestinationLogDNA:
+		required = []string{cisLogpushJobHostname, cisLogpushJobIngressKey, cisLogpushJobRegion}
+		notAllowed = []string{cisLogpushJobBucketName, cisLogpushJobInstanceID}
+	default:
+		return fmt.Errorf("destination type must be %s or %s, got %q", cisLogpushDestinationCOS, cisLogpushDestinationLogDNA, destinationType)
+	}
+	for _, key := range required {
+		if value(key) == "" {
+			return fmt.Errorf("%s destination requires %s", destinationType, key)
+		}
+	}
+	for _, key := range notAllowed {
+		if value(key) != "" {
+			return fmt.Errorf("%s can't be set for a %s destination", key, destinationType)
+		}
+	}
+
+	if region := value(cisLogpushJobRegion); !cisLogpushRegionRegexp.MatchString(region) {
+		return fmt.Errorf("invalid region %q, expected a region such as us-south or a cross region such as us", region)
+	}
+	if destinationType == cisLogpushDestinationCOS {
+		bucket := value(cisLogpushJobBucketName)
+		if !cisLogpushBucketNameRegexp.MatchString(bucket) || strings.Contains(bucket, "..") ||
+			strings.Contains(bucket, ".-") || strings.Contains(bucket, "-.") || net.ParseIP(bucket) != nil {
+			return fmt.Errorf("invalid bucket_name %q, bucket names are 3 to 63 lowercase letters, digits, dots and dashes", bucket)
+		}
+		if instanceID := value(cisLogpushJobInstanceID); !cisLogpushGUIDRegexp.MatchString(instanceID) {
+			return fmt.Errorf("invalid instance_id %q, expected the GUID of the COS instance", instanceID)
+		}
+		return nil
+	}
+	hostname := value(cisLogpushJobHostname)
+	if len(hostname) > 253 || !cisLogpushHostnameRegexp.MatchString(hostname) {
+		return fmt.Errorf("invalid hostname %q", hostname)
+	}
+	return nil
+}
+
+// cisLogpullOptions builds the logpull_options of a job, which select the
+// fields of the dataset and the format of the timestamps.
+func cisLogpullOptions(fields []string, timestamps string) string {
+	return "fields=" + strings.Join(fields, ",") + "&timestamps=" + timestamps
+}
+
+// parseCISLogpullOptions returns the fields and the format of the timestamps
+// of logpull_options.
+func parseCISLogpullOptions(options string) ([]string, string) {
+	values, err := url.ParseQuery(strings.TrimPrefix(options, "?"))
+	if err != nil {
+		return nil, ""
+	}
+	fields := []string{}
+	if v := values.Get("fields"); v != "" {
+		fields = strings.Split(v, ",")
+	}
+	return fields, values.Get("timestamps")
+}
+
+// flattenCISLogpushDestination returns the destination block of the
+// destination_conf of a job, e.g.
+// cos://bucket?region=us-south&instance-id=<guid> or
+// logdna://hostname?region=us-south. The ingestion key isn't returned by the
+// API, so it is taken from ingressKey. It returns nil when destinationConf
+// can't be parsed.
+func flattenCISLogpushDestination(destinationConf, ingressKey string) map[string]interface{} {
+	conf, err := url.Parse(destinationConf)
+	if err != nil || conf.Host == "" {
+		return nil
+	}
+	query := conf.Query()
+	destination := map[string]interface{}{
+		cisLogpushJobDestinationType: conf.Scheme,
+		cisLogpushJobRegion:          query.Get("region"),
+		cisLogpushJobBucketName:      "",
+		cisLogpushJobInstanceID:      "",
+		cisLogpushJobHostname:        "",
+		cisLogpushJobIngressKey:      "",
+	}
+	switch conf.Scheme {
+	case cisLogpushDestinationCOS:
+		destination[cisLogpushJobBucketName] = conf.Host
+		for _, key := range []string{"instance-id", "instance_id", "id"} {
+			if v := query.Get(key); v != "" {
+				destination[cisLogpushJobInstanceID] = v
+				break
+			}
+		}
+	case cisLogpushDestinationLogDNA:
+		destination[cisLogpushJobHostname] = conf.Host
+		destination[cisLogpushJobIngressKey] = ingressKey
+	default:
+		return nil
+	}
+	return destination
+}
+
+func cisLogpushRequest(sess *ciszonesv1.ZonesV1, method, path, zoneID, jobID string, body, result interface{}) (*core.DetailedResponse, error) {
+	pathParamsMap := map[string]string{
+		"crn":     *sess.Crn,
+		"zone_id": zoneID,
+	}
+	if jobID != "" {
+		pathParamsMap["job_id"] = jobID
+	}
+	return cisRequest(sess.Service, method, path, pathParamsMap, nil, body, result)
+}
+
+func createCISLogpushJob(sess *ciszonesv1.ZonesV1, zoneID string, job cisLogpushJobRequest) (*cisLogpushJob, *core.DetailedResponse, error) {
+	result := &cisLogpushJob{}
+	response, err := cisLogpushRequest(sess, core.POST, cisLogpushJobsPath, zoneID, "", job, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+func getCISLogpushJob(sess *ciszonesv1.ZonesV1, zoneID, jobID string) (*cisLogpushJob, *core.DetailedResponse, error) {
+	result := &cisLogpushJob{}
+	response, err := cisLogpushRequest(sess, core.GET, cisLogpushJobPath, zoneID, jobID, nil, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+func updateCISLogpushJob(sess *ciszonesv1.ZonesV1, zoneID, jobID string, job cisLogpushJobRequest) (*cisLogpushJob, *core.DetailedResponse, error) {
+	result := &cisLogpushJob{}
+	response, err := cisLogpushRequest(sess, core.PUT, cisLogpushJobPath, zoneID, jobID, job, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+func deleteCISLogpushJob(sess *ciszonesv1.ZonesV1, zoneID, jobID string) (*core.DetailedResponse, error) {
+	return cisLogpushRequest(sess, core.DELETE, cisLogpushJobPath, zoneID, jobID, nil, nil)
+}
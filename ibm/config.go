@@ -1,13 +1,16 @@
 package ibm
 
 import (
-	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	gohttp "net/http"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	// Added code for the Power Colo Offering
@@ -47,6 +50,7 @@ import (
 	vpc "github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/apache/openwhisk-client-go/whisk"
 	jwt "github.com/dgrijalva/jwt-go"
+	httptransport "github.com/go-openapi/runtime/client"
 	slsession "github.com/softlayer/softlayer-go/session"
 	ns "github.ibm.com/ibmcloud/namespace-go-sdk/ibmcloudfunctionsnamespaceapiv1"
 
@@ -86,12 +90,19 @@ import (
 // RetryAPIDelay - retry api delay
 const RetryAPIDelay = 5 * time.Second
 
+// Default retry policy of the IBM Cloud go SDK clients, backoffs are in seconds
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryMinBackoff  = 1
+	defaultRetryMaxBackoff  = 30
+)
+
 //BluemixRegion ...
 var BluemixRegion string
 
 var (
 	errEmptySoftLayerCredentials = errors.New("iaas_classic_username and iaas_classic_api_key must be provided. Please see the documentation on how to configure them")
-	errEmptyBluemixCredentials   = errors.New("ibmcloud_api_key or bluemix_api_key or iam_token and iam_refresh_token must be provided. Please see the documentation on how to configure it")
+	errEmptyBluemixCredentials   = errors.New("ibmcloud_api_key or bluemix_api_key or iam_token and iam_refresh_token or iam_profile_id or iam_profile_name must be provided. Please see the documentation on how to configure it")
 )
 
 //UserConfig ...
@@ -150,11 +161,47 @@ type Config struct {
 	//IAM Refresh Token
 	IAMRefreshToken string
 
+	// IAMProfileID and IAMProfileName select the trusted profile to authenticate
+	// as with the compute resource token in CRTokenFile
+	IAMProfileID   string
+	IAMProfileName string
+	CRTokenFile    string
+
 	// PowerService Instance
 	PowerServiceInstance string
 
 	// Zone
 	Zone string
+
+	// Endpoints holds the service URL overrides from the provider endpoints block
+	// and endpoints_file_path, keyed by the names in serviceEndpointEnvs
+	Endpoints map[string]string
+
+	// Visibility selects public, private or public-and-private service endpoints
+	Visibility string
+
+	// CABundleFile is a PEM file with additional CAs trusted by the HTTP transport
+	CABundleFile string
+
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool
+
+	// HTTPTrace receives a dump of every request and response, with the
+	// credentials redacted, when set
+	HTTPTrace func(string)
+
+	// RetryMaxAttempts, RetryMinBackoff and RetryMaxBackoff make up the retry
+	// policy of the IBM Cloud go SDK clients on rate limiting and server errors
+	RetryMaxAttempts int
+	RetryMinBackoff  time.Duration
+	RetryMaxBackoff  time.Duration
+
+	// RateLimits caps the requests per second sent to a service, keyed by the
+	// names in serviceEndpointEnvs
+	RateLimits map[string]int
+
+	// DefaultTags are attached to every taggable resource
+	DefaultTags []string
 }
 
 //Session stores the information required for communication with the SoftLayer and Bluemix API
@@ -164,6 +211,21 @@ type Session struct {
 
 	// BluemixSession is the the Bluemix session used to connect to the Bluemix API
 	BluemixSession *bxsession.Session
+
+	// HTTPClient is shared by every IBM Cloud client so they reuse connections
+	HTTPClient *gohttp.Client
+
+	// RetryHTTPClient wraps the transport of HTTPClient with the provider retry
+	// policy. It is used by the go SDK clients, which do not retry on their own
+	RetryHTTPClient *gohttp.Client
+
+	// RateLimiters holds the token bucket shared by every client of a rate
+	// limited service
+	RateLimiters map[string]*tokenBucket
+
+	// IAMTokenSource issues the IAM tokens of a trusted profile, nil unless the
+	// provider authenticates with a compute resource token
+	IAMTokenSource *iamTokenSource
 }
 
 // ClientSession ...
@@ -172,6 +234,7 @@ type ClientSession interface {
 	BluemixAcccountAPI() (accountv2.AccountServiceAPI, error)
 	BluemixAcccountv1API() (accountv1.AccountServiceAPI, error)
 	BluemixUserDetails() (*UserConfig, error)
+	DefaultTags() []string
 	ContainerAPI() (containerv1.ContainerServiceAPI, error)
 	VpcContainerAPI() (containerv2.ContainerServiceAPI, error)
 	ContainerRegistryAPI() (registryv1.RegistryServiceAPI, error)
@@ -233,412 +296,1076 @@ type ClientSession interface {
 	IAMIdentityV1API() (*iamidentity.IamIdentityV1, error)
 }
 
+// clientSession builds each service client the first time its accessor is
+// called, so configuring the provider does no token exchange and creates no
+// client for services the plan never touches.
 type clientSession struct {
+	config  *Config
 	session *Session
 
-	apigatewayErr error
-	apigatewayAPI *apigateway.ApiGatewayControllerApiV1
+	// authOnce guards the IAM token exchange and the account user details
+	// shared by every client backed by the IBM Cloud session.
+	authOnce        sync.Once
+	authErr         error
+	bmxUserDetails  *UserConfig
+	bmxUserFetchErr error
+
+	// cfAuthOnce guards the UAA token exchange, which only Cloud Foundry and
+	// Functions need. configMu serialises it with copies of the session config.
+	cfAuthOnce sync.Once
+	cfAuthErr  error
+	configMu   sync.Mutex
 
+	apigatewayOnce sync.Once
+	apigatewayErr  error
+	apigatewayAPI  *apigateway.ApiGatewayControllerApiV1
+
+	accountOnce          sync.Once
 	accountConfigErr     error
 	bmxAccountServiceAPI accountv2.AccountServiceAPI
 
+	accountV1Once          sync.Once
 	accountV1ConfigErr     error
 	bmxAccountv1ServiceAPI accountv1.AccountServiceAPI
 
-	bmxUserDetails  *UserConfig
-	bmxUserFetchErr error
-
+	csOnce       sync.Once
 	csConfigErr  error
 	csServiceAPI containerv1.ContainerServiceAPI
 
+	csv2Once       sync.Once
 	csv2ConfigErr  error
 	csv2ServiceAPI containerv2.ContainerServiceAPI
 
+	crv1Once       sync.Once
 	crv1ConfigErr  error
 	crv1ServiceAPI registryv1.RegistryServiceAPI
 
+	stxOnce       sync.Once
 	stxConfigErr  error
 	stxServiceAPI schematics.SchematicsServiceAPI
 
-	certManagementErr error
-	certManagementAPI certificatemanager.CertificateManagerServiceAPI
+	certManagementOnce sync.Once
+	certManagementErr  error
+	certManagementAPI  certificatemanager.CertificateManagerServiceAPI
 
+	cfOnce       sync.Once
 	cfConfigErr  error
 	cfServiceAPI mccpv2.MccpServiceAPI
 
+	cisOnce       sync.Once
 	cisConfigErr  error
 	cisServiceAPI cisv1.CisServiceAPI
 
+	functionOnce      sync.Once
 	functionConfigErr error
 	functionClient    *whisk.Client
 
+	globalSearchOnce       sync.Once
 	globalSearchConfigErr  error
 	globalSearchServiceAPI globalsearchv2.GlobalSearchServiceAPI
 
+	globalTaggingOnce       sync.Once
 	globalTaggingConfigErr  error
 	globalTaggingServiceAPI globaltaggingv3.GlobalTaggingServiceAPI
 
+	iamPAPOnce       sync.Once
 	iamPAPConfigErr  error
 	iamPAPServiceAPI iampapv1.IAMPAPAPI
 
+	iamPAPOncev2       sync.Once
 	iamPAPConfigErrv2  error
 	iamPAPServiceAPIv2 iampapv2.IAMPAPAPIV2
 
+	iamUUMOnce       sync.Once
 	iamUUMConfigErr  error
 	iamUUMServiceAPI iamuumv1.IAMUUMServiceAPI
 
+	iamUUMOnceV2       sync.Once
 	iamUUMConfigErrV2  error
 	iamUUMServiceAPIV2 iamuumv2.IAMUUMServiceAPIv2
 
+	iamOnce       sync.Once
 	iamConfigErr  error
 	iamServiceAPI iamv1.IAMServiceAPI
 
-	userManagementErr error
-	userManagementAPI usermanagementv2.UserManagementAPI
+	userManagementOnce sync.Once
+	userManagementErr  error
+	userManagementAPI  usermanagementv2.UserManagementAPI
 
+	icdOnce       sync.Once
 	icdConfigErr  error
 	icdServiceAPI icdv4.ICDServiceAPI
 
+	resourceControllerOnce       sync.Once
 	resourceControllerConfigErr  error
 	resourceControllerServiceAPI controller.ResourceControllerAPI
 
+	resourceControllerOncev2       sync.Once
 	resourceControllerConfigErrv2  error
 	resourceControllerServiceAPIv2 controllerv2.ResourceControllerAPIV2
 
+	resourceManagementOnce       sync.Once
 	resourceManagementConfigErr  error
 	resourceManagementServiceAPI management.ResourceManagementAPI
 
+	resourceManagementOncev2       sync.Once
 	resourceManagementConfigErrv2  error
 	resourceManagementServiceAPIv2 managementv2.ResourceManagementAPIv2
 
+	resourceCatalogOnce       sync.Once
 	resourceCatalogConfigErr  error
 	resourceCatalogServiceAPI catalog.ResourceCatalogAPI
 
-	powerConfigErr error
+	ibmpiOnce      sync.Once
 	ibmpiConfigErr error
 	ibmpiSession   *ibmpisession.IBMPISession
 
-	kpErr error
-	kpAPI *kp.API
+	kpOnce sync.Once
+	kpErr  error
+	kpAPI  *kp.API
 
-	kmsErr error
-	kmsAPI *kp.API
+	kmsOnce sync.Once
+	kmsErr  error
+	kmsAPI  *kp.API
 
-	hpcsEndpointErr error
-	hpcsEndpointAPI hpcs.HPCSV2
+	hpcsEndpointOnce sync.Once
+	hpcsEndpointErr  error
+	hpcsEndpointAPI  hpcs.HPCSV2
 
+	pDNSOnce   sync.Once
 	pDNSClient *dns.DnsSvcsV1
 	pDNSErr    error
 
-	bluemixSessionErr error
+	vpcClassicOnce sync.Once
+	vpcClassicErr  error
+	vpcClassicAPI  *vpcclassic.VpcClassicV1
 
-	vpcClassicErr error
-	vpcClassicAPI *vpcclassic.VpcClassicV1
+	vpcOnce sync.Once
+	vpcErr  error
+	vpcAPI  *vpc.VpcV1
 
-	vpcErr error
-	vpcAPI *vpc.VpcV1
+	directlinkOnce sync.Once
+	directlinkAPI  *dl.DirectLinkV1
+	directlinkErr  error
+	dlProviderOnce sync.Once
+	dlProviderAPI  *dlProviderV2.DirectLinkProviderV2
+	dlProviderErr  error
 
-	directlinkAPI *dl.DirectLinkV1
-	directlinkErr error
-	dlProviderAPI *dlProviderV2.DirectLinkProviderV2
-	dlProviderErr error
+	cosConfigOnce sync.Once
+	cosConfigErr  error
+	cosConfigAPI  *cosconfig.ResourceConfigurationV1
 
-	cosConfigErr error
-	cosConfigAPI *cosconfig.ResourceConfigurationV1
+	transitgatewayOnce sync.Once
+	transitgatewayAPI  *tg.TransitGatewayApisV1
+	transitgatewayErr  error
 
-	transitgatewayAPI *tg.TransitGatewayApisV1
-	transitgatewayErr error
-
-	iamNamespaceAPI *ns.IbmCloudFunctionsNamespaceAPIV1
-	iamNamespaceErr error
+	iamNamespaceOnce sync.Once
+	iamNamespaceAPI  *ns.IbmCloudFunctionsNamespaceAPIV1
+	iamNamespaceErr  error
 
 	// CIS Zones
+	cisZonesOnce     sync.Once
 	cisZonesErr      error
 	cisZonesV1Client *ciszonesv1.ZonesV1
 
 	// CIS dns service options
+	cisDNSOnce          sync.Once
 	cisDNSErr           error
 	cisDNSRecordsClient *cisdnsrecordsv1.DnsRecordsV1
 
 	// CIS dns bulk service options
+	cisDNSBulkOnce         sync.Once
 	cisDNSBulkErr          error
 	cisDNSRecordBulkClient *cisdnsbulkv1.DnsRecordBulkV1
 
 	// CIS Global Load Balancer Pool service options
+	cisGLBPoolOnce   sync.Once
 	cisGLBPoolErr    error
 	cisGLBPoolClient *cisglbpoolv0.GlobalLoadBalancerPoolsV0
 
 	// CIS GLB service options
+	cisGLBOnce   sync.Once
 	cisGLBErr    error
 	cisGLBClient *cisglbv1.GlobalLoadBalancerV1
 
 	// CIS GLB health check service options
+	cisGLBHealthCheckOnce   sync.Once
 	cisGLBHealthCheckErr    error
 	cisGLBHealthCheckClient *cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1
 
 	// CIS IP service options
+	cisIPOnce   sync.Once
 	cisIPErr    error
 	cisIPClient *cisipv1.CisIpApiV1
 
 	// CIS Zone Rate Limits service options
+	cisRLOnce   sync.Once
 	cisRLErr    error
 	cisRLClient *cisratelimitv1.ZoneRateLimitsV1
 
 	// CIS Page Rules service options
+	cisPageRuleOnce   sync.Once
 	cisPageRuleErr    error
 	cisPageRuleClient *cispagerulev1.PageRuleApiV1
 
 	// CIS Edge Functions service options
+	cisEdgeFunctionOnce   sync.Once
 	cisEdgeFunctionErr    error
 	cisEdgeFunctionClient *cisedgefunctionv1.EdgeFunctionsApiV1
 
 	// CIS SSL certificate service options
+	cisSSLOnce   sync.Once
 	cisSSLErr    error
 	cisSSLClient *cissslv1.SslCertificateApiV1
 
 	// CIS WAF Package service options
+	cisWAFPackageOnce   sync.Once
 	cisWAFPackageErr    error
 	cisWAFPackageClient *ciswafpackagev1.WafRulePackagesApiV1
 
 	// CIS Zone Setting service options
+	cisDomainSettingsOnce   sync.Once
 	cisDomainSettingsErr    error
 	cisDomainSettingsClient *cisdomainsettingsv1.ZonesSettingsV1
 
 	// CIS Routing service options
+	cisRoutingOnce   sync.Once
 	cisRoutingErr    error
 	cisRoutingClient *cisroutingv1.RoutingV1
 
 	// CIS WAF Group service options
+	cisWAFGroupOnce   sync.Once
 	cisWAFGroupErr    error
 	cisWAFGroupClient *ciswafgroupv1.WafRuleGroupsApiV1
 
 	// CIS Caching service options
+	cisCacheOnce   sync.Once
 	cisCacheErr    error
 	cisCacheClient *ciscachev1.CachingApiV1
 
 	// CIS Custom Pages service options
+	cisCustomPageOnce   sync.Once
 	cisCustomPageErr    error
 	cisCustomPageClient *ciscustompagev1.CustomPagesV1
 
 	// CIS Firewall Access rule service option
+	cisAccessRuleOnce   sync.Once
 	cisAccessRuleErr    error
 	cisAccessRuleClient *cisaccessrulev1.ZoneFirewallAccessRulesV1
 
 	// CIS User Agent Blocking Rule service option
+	cisUARuleOnce   sync.Once
 	cisUARuleErr    error
 	cisUARuleClient *cisuarulev1.UserAgentBlockingRulesV1
 
 	// CIS Firewall Lockdwon Rule service option
+	cisLockdownOnce   sync.Once
 	cisLockdownErr    error
 	cisLockdownClient *cislockdownv1.ZoneLockdownV1
 
 	// CIS Range app service option
+	cisRangeAppOnce   sync.Once
 	cisRangeAppErr    error
 	cisRangeAppClient *cisrangeappv1.RangeApplicationsV1
 
 	// CIS WAF rule service options
+	cisWAFRuleOnce   sync.Once
 	cisWAFRuleErr    error
 	cisWAFRuleClient *ciswafrulev1.WafRulesApiV1
 	//IAM Identity Option
-	iamIdentityErr error
-	iamIdentityAPI *iamidentity.IamIdentityV1
+	iamIdentityOnce sync.Once
+	iamIdentityErr  error
+	iamIdentityAPI  *iamidentity.IamIdentityV1
+}
+
+// authenticate fetches the first IAM token the first time a client needs it
+// and fetches the account user details from the token.
+func (sess *clientSession) authenticate() error {
+	sess.authOnce.Do(func() {
+		bmxSess := sess.session.BluemixSession
+		var token string
+		token, sess.authErr = sess.session.IAMTokenSource.Token()
+		if sess.authErr == nil {
+			bmxSess.Config.IAMAccessToken = "Bearer " + token
+			bmxSess.Config.IAMRefreshToken = sess.session.IAMTokenSource.RefreshToken()
+		}
+		if sess.authErr != nil {
+			sess.bmxUserDetails = &UserConfig{}
+			sess.bmxUserFetchErr = fmt.Errorf("Error occured while fetching account user details: %q", sess.authErr)
+			return
+		}
+		userConfig, err := fetchUserDetails(bmxSess, sess.config.Generation)
+		if err != nil {
+			sess.bmxUserFetchErr = fmt.Errorf("Error occured while fetching account user details: %q", err)
+		}
+		sess.bmxUserDetails = userConfig
+
+		if sess.session.SoftLayerSession != nil && sess.session.SoftLayerSession.IAMToken != "" {
+			sess.session.SoftLayerSession.IAMToken = bmxSess.Config.IAMAccessToken
+			sess.session.SoftLayerSession.IAMRefreshToken = bmxSess.Config.IAMRefreshToken
+		}
+	})
+	return sess.authErr
+}
+
+// authenticateCF fetches the UAA tokens used by Cloud Foundry and Functions.
+// It is a no-op when the session was configured with an IAM token.
+func (sess *clientSession) authenticateCF() error {
+	sess.cfAuthOnce.Do(func() {
+		bmxSess := sess.session.BluemixSession
+		if bmxSess.Config.BluemixAPIKey == "" {
+			return
+		}
+		sess.configMu.Lock()
+		defer sess.configMu.Unlock()
+		sess.cfAuthErr = authenticateCF(bmxSess)
+	})
+	return sess.cfAuthErr
+}
+
+// bluemixClientErr reports why the client of service cannot be configured,
+// authenticating the IBM Cloud session on first use.
+func (sess *clientSession) bluemixClientErr(service string) error {
+	if sess.session.BluemixSession == nil {
+		return errEmptyBluemixCredentials
+	}
+	if err := sess.authenticate(); err != nil {
+		return err
+	}
+	return sess.config.privateEndpointErr(service)
+}
+
+// serviceSession returns the session used to build the bluemix-go client of
+// service.
+func (sess *clientSession) serviceSession(service string) *bxsession.Session {
+	sess.configMu.Lock()
+	defer sess.configMu.Unlock()
+	bmxSess := sess.config.bluemixServiceSession(sess.session.BluemixSession, service)
+	if limiter := sess.session.RateLimiters[service]; limiter != nil {
+		bmxSess = bmxSess.Copy()
+		bmxSess.Config.HTTPClient = &gohttp.Client{
+			Transport: &rateLimitTransport{next: sess.session.HTTPClient.Transport, limiter: limiter},
+			Timeout:   sess.session.HTTPClient.Timeout,
+		}
+	}
+	return bmxSess
+}
+
+// sdkHTTPClient returns the HTTP client of the go SDK clients of service,
+// which retries and, when configured, honours the rate limit of service.
+func (sess *clientSession) sdkHTTPClient(service string) *gohttp.Client {
+	limiter := sess.session.RateLimiters[service]
+	if limiter == nil {
+		return sess.session.RetryHTTPClient
+	}
+	retry := *sess.session.RetryHTTPClient.Transport.(*retryTransport)
+	retry.next = &rateLimitTransport{next: retry.next, limiter: limiter}
+	return &gohttp.Client{Transport: &retry}
+}
+
+// authenticator returns an authenticator for the IBM Cloud go SDK clients,
+// which fetches a fresh IAM token once the current one is about to expire.
+func (sess *clientSession) authenticator() core.Authenticator {
+	return &iamTokenAuthenticator{source: sess.session.IAMTokenSource}
+}
+
+// DefaultTags returns the provider default_tags, which are attached to every
+// taggable resource.
+func (sess *clientSession) DefaultTags() []string {
+	return sess.config.DefaultTags
 }
 
 // BluemixAcccountAPI ...
-func (sess clientSession) BluemixAcccountAPI() (accountv2.AccountServiceAPI, error) {
+func (sess *clientSession) BluemixAcccountAPI() (accountv2.AccountServiceAPI, error) {
+	sess.accountOnce.Do(func() {
+		if sess.accountConfigErr = sess.bluemixClientErr("account"); sess.accountConfigErr != nil {
+			return
+		}
+		accAPI, err := accountv2.New(sess.serviceSession("account"))
+		if err != nil {
+			sess.accountConfigErr = fmt.Errorf("Error occured while configuring  Account Service: %q", err)
+		}
+		sess.bmxAccountServiceAPI = accAPI
+	})
 	return sess.bmxAccountServiceAPI, sess.accountConfigErr
 }
 
 // BluemixAcccountAPI ...
-func (sess clientSession) BluemixAcccountv1API() (accountv1.AccountServiceAPI, error) {
+func (sess *clientSession) BluemixAcccountv1API() (accountv1.AccountServiceAPI, error) {
+	sess.accountV1Once.Do(func() {
+		if sess.accountV1ConfigErr = sess.bluemixClientErr("account"); sess.accountV1ConfigErr != nil {
+			return
+		}
+		accv1API, err := accountv1.New(sess.serviceSession("account"))
+		if err != nil {
+			sess.accountV1ConfigErr = fmt.Errorf("Error occured while configuring Bluemix Accountv1 Service: %q", err)
+		}
+		sess.bmxAccountv1ServiceAPI = accv1API
+	})
 	return sess.bmxAccountv1ServiceAPI, sess.accountV1ConfigErr
 }
 
 // BluemixSession to provide the Bluemix Session
-func (sess clientSession) BluemixSession() (*bxsession.Session, error) {
-	return sess.session.BluemixSession, sess.bluemixSessionErr
+func (sess *clientSession) BluemixSession() (*bxsession.Session, error) {
+	if sess.session.BluemixSession == nil {
+		return nil, errEmptyBluemixCredentials
+	}
+	if err := sess.authenticate(); err != nil {
+		return sess.session.BluemixSession, err
+	}
+	// Callers of the raw session read the UAA tokens too, e.g. the Functions
+	// resources and the ibm_iam_auth_token data source.
+	if err := sess.authenticateCF(); err != nil {
+		log.Printf("[WARN] Error occured while fetching UAA tokens: %s", err)
+	}
+	return sess.session.BluemixSession, nil
 }
 
 // BluemixUserDetails ...
-func (sess clientSession) BluemixUserDetails() (*UserConfig, error) {
+func (sess *clientSession) BluemixUserDetails() (*UserConfig, error) {
+	if sess.session.BluemixSession == nil {
+		return nil, errEmptyBluemixCredentials
+	}
+	sess.authenticate()
 	return sess.bmxUserDetails, sess.bmxUserFetchErr
 }
 
 // ContainerAPI provides Container Service APIs ...
-func (sess clientSession) ContainerAPI() (containerv1.ContainerServiceAPI, error) {
+func (sess *clientSession) ContainerAPI() (containerv1.ContainerServiceAPI, error) {
+	sess.csOnce.Do(func() {
+		if sess.csConfigErr = sess.bluemixClientErr("container"); sess.csConfigErr != nil {
+			return
+		}
+		clusterAPI, err := containerv1.New(sess.serviceSession("container"))
+		if err != nil {
+			sess.csConfigErr = fmt.Errorf("Error occured while configuring Container Service for K8s cluster: %q", err)
+		}
+		sess.csServiceAPI = clusterAPI
+	})
 	return sess.csServiceAPI, sess.csConfigErr
 }
 
 // VpcContainerAPI provides v2Container Service APIs ...
-func (sess clientSession) VpcContainerAPI() (containerv2.ContainerServiceAPI, error) {
+func (sess *clientSession) VpcContainerAPI() (containerv2.ContainerServiceAPI, error) {
+	sess.csv2Once.Do(func() {
+		if sess.csv2ConfigErr = sess.bluemixClientErr("container"); sess.csv2ConfigErr != nil {
+			return
+		}
+		v2clusterAPI, err := containerv2.New(sess.serviceSession("container"))
+		if err != nil {
+			sess.csv2ConfigErr = fmt.Errorf("Error occured while configuring vpc Container Service for K8s cluster: %q", err)
+		}
+		sess.csv2ServiceAPI = v2clusterAPI
+	})
 	return sess.csv2ServiceAPI, sess.csv2ConfigErr
 }
 
 // ContainerRegistryAPI provides v2Container Service APIs ...
-func (sess clientSession) ContainerRegistryAPI() (registryv1.RegistryServiceAPI, error) {
+func (sess *clientSession) ContainerRegistryAPI() (registryv1.RegistryServiceAPI, error) {
+	sess.crv1Once.Do(func() {
+		if sess.crv1ConfigErr = sess.bluemixClientErr("container_registry"); sess.crv1ConfigErr != nil {
+			return
+		}
+		v1registryAPI, err := registryv1.New(sess.serviceSession("container_registry"))
+		if err != nil {
+			sess.crv1ConfigErr = fmt.Errorf("Error occured while configuring Container Registry: %q", err)
+		}
+		sess.crv1ServiceAPI = v1registryAPI
+	})
 	return sess.crv1ServiceAPI, sess.crv1ConfigErr
 }
 
 // SchematicsAPI provides schematics Service APIs ...
-func (sess clientSession) SchematicsAPI() (schematics.SchematicsServiceAPI, error) {
+func (sess *clientSession) SchematicsAPI() (schematics.SchematicsServiceAPI, error) {
+	sess.stxOnce.Do(func() {
+		if sess.stxConfigErr = sess.bluemixClientErr("schematics"); sess.stxConfigErr != nil {
+			return
+		}
+		schematicService, err := schematics.New(sess.serviceSession("schematics"))
+		if err != nil {
+			sess.stxConfigErr = fmt.Errorf("Error occured while fetching schematics Configuration: %q", err)
+		}
+		sess.stxServiceAPI = schematicService
+	})
 	return sess.stxServiceAPI, sess.stxConfigErr
 }
 
 // CisAPI provides Cloud Internet Services APIs ...
-func (sess clientSession) CisAPI() (cisv1.CisServiceAPI, error) {
+func (sess *clientSession) CisAPI() (cisv1.CisServiceAPI, error) {
+	sess.cisOnce.Do(func() {
+		if sess.cisConfigErr = sess.bluemixClientErr("cis"); sess.cisConfigErr != nil {
+			return
+		}
+		cisAPI, err := cisv1.New(sess.serviceSession("cis"))
+		if err != nil {
+			sess.cisConfigErr = fmt.Errorf("Error occured while configuring Cloud Internet Services: %q", err)
+		}
+		sess.cisServiceAPI = cisAPI
+	})
 	return sess.cisServiceAPI, sess.cisConfigErr
 }
 
 // FunctionClient ...
-func (sess clientSession) FunctionClient() (*whisk.Client, error) {
+func (sess *clientSession) FunctionClient() (*whisk.Client, error) {
+	sess.functionOnce.Do(func() {
+		if sess.functionConfigErr = sess.bluemixClientErr("functions"); sess.functionConfigErr != nil {
+			sess.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", sess.functionConfigErr)
+			return
+		}
+		if err := sess.authenticateCF(); err != nil {
+			sess.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
+			return
+		}
+		sess.functionClient, sess.functionConfigErr = FunctionClient(sess.session.BluemixSession.Config)
+	})
 	return sess.functionClient, sess.functionConfigErr
 }
 
 // GlobalSearchAPI provides Global Search  APIs ...
-func (sess clientSession) GlobalSearchAPI() (globalsearchv2.GlobalSearchServiceAPI, error) {
+func (sess *clientSession) GlobalSearchAPI() (globalsearchv2.GlobalSearchServiceAPI, error) {
+	sess.globalSearchOnce.Do(func() {
+		if sess.globalSearchConfigErr = sess.bluemixClientErr("global_search"); sess.globalSearchConfigErr != nil {
+			return
+		}
+		globalSearchAPI, err := globalsearchv2.New(sess.serviceSession("global_search"))
+		if err != nil {
+			sess.globalSearchConfigErr = fmt.Errorf("Error occured while configuring Global Search: %q", err)
+		}
+		sess.globalSearchServiceAPI = globalSearchAPI
+	})
 	return sess.globalSearchServiceAPI, sess.globalSearchConfigErr
 }
 
 // GlobalTaggingAPI provides Global Search  APIs ...
-func (sess clientSession) GlobalTaggingAPI() (globaltaggingv3.GlobalTaggingServiceAPI, error) {
+func (sess *clientSession) GlobalTaggingAPI() (globaltaggingv3.GlobalTaggingServiceAPI, error) {
+	sess.globalTaggingOnce.Do(func() {
+		if sess.globalTaggingConfigErr = sess.bluemixClientErr("global_tagging"); sess.globalTaggingConfigErr != nil {
+			return
+		}
+		globalTaggingAPI, err := globaltaggingv3.New(sess.serviceSession("global_tagging"))
+		if err != nil {
+			sess.globalTaggingConfigErr = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
+		}
+		sess.globalTaggingServiceAPI = globalTaggingAPI
+	})
 	return sess.globalTaggingServiceAPI, sess.globalTaggingConfigErr
 }
 
 // HpcsEndpointAPI provides Hpcs Endpoint generator APIs ...
-func (sess clientSession) HpcsEndpointAPI() (hpcs.HPCSV2, error) {
+func (sess *clientSession) HpcsEndpointAPI() (hpcs.HPCSV2, error) {
+	sess.hpcsEndpointOnce.Do(func() {
+		if sess.hpcsEndpointErr = sess.bluemixClientErr("hpcs"); sess.hpcsEndpointErr != nil {
+			return
+		}
+		hpcsAPI, err := hpcs.New(sess.serviceSession("hpcs"))
+		if err != nil {
+			sess.hpcsEndpointErr = fmt.Errorf("Error occured while configuring hpcs Endpoint: %q", err)
+		}
+		sess.hpcsEndpointAPI = hpcsAPI
+	})
 	return sess.hpcsEndpointAPI, sess.hpcsEndpointErr
 }
 
 // IAMAPI provides IAM PAP APIs ...
-func (sess clientSession) IAMAPI() (iamv1.IAMServiceAPI, error) {
+func (sess *clientSession) IAMAPI() (iamv1.IAMServiceAPI, error) {
+	sess.iamOnce.Do(func() {
+		if sess.iamConfigErr = sess.bluemixClientErr("iam"); sess.iamConfigErr != nil {
+			return
+		}
+		iam, err := iamv1.New(sess.serviceSession("iam"))
+		if err != nil {
+			sess.iamConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAM Service: %q", err)
+		}
+		sess.iamServiceAPI = iam
+	})
 	return sess.iamServiceAPI, sess.iamConfigErr
 }
 
 // UserManagementAPI provides User management APIs ...
-func (sess clientSession) UserManagementAPI() (usermanagementv2.UserManagementAPI, error) {
+func (sess *clientSession) UserManagementAPI() (usermanagementv2.UserManagementAPI, error) {
+	sess.userManagementOnce.Do(func() {
+		if sess.userManagementErr = sess.bluemixClientErr("user_management"); sess.userManagementErr != nil {
+			return
+		}
+		userManagementAPI, err := usermanagementv2.New(sess.serviceSession("user_management"))
+		if err != nil {
+			sess.userManagementErr = fmt.Errorf("Error occured while configuring user management service: %q", err)
+		}
+		sess.userManagementAPI = userManagementAPI
+	})
 	return sess.userManagementAPI, sess.userManagementErr
 }
 
 // IAMPAPAPI provides IAM PAP APIs ...
-func (sess clientSession) IAMPAPAPI() (iampapv1.IAMPAPAPI, error) {
+func (sess *clientSession) IAMPAPAPI() (iampapv1.IAMPAPAPI, error) {
+	sess.iamPAPOnce.Do(func() {
+		if sess.iamPAPConfigErr = sess.bluemixClientErr("iam"); sess.iamPAPConfigErr != nil {
+			return
+		}
+		iampap, err := iampapv1.New(sess.serviceSession("iam"))
+		if err != nil {
+			sess.iamPAPConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAMPAP Service: %q", err)
+		}
+		sess.iamPAPServiceAPI = iampap
+	})
 	return sess.iamPAPServiceAPI, sess.iamPAPConfigErr
 }
 
 // IAMPAPAPIV2 provides IAM PAP APIs ...
-func (sess clientSession) IAMPAPAPIV2() (iampapv2.IAMPAPAPIV2, error) {
+func (sess *clientSession) IAMPAPAPIV2() (iampapv2.IAMPAPAPIV2, error) {
+	sess.iamPAPOncev2.Do(func() {
+		if sess.iamPAPConfigErrv2 = sess.bluemixClientErr("iam"); sess.iamPAPConfigErrv2 != nil {
+			return
+		}
+		iampap, err := iampapv2.New(sess.serviceSession("iam"))
+		if err != nil {
+			sess.iamPAPConfigErrv2 = fmt.Errorf("Error occured while configuring Bluemix IAMPAP Service: %q", err)
+		}
+		sess.iamPAPServiceAPIv2 = iampap
+	})
 	return sess.iamPAPServiceAPIv2, sess.iamPAPConfigErrv2
 }
 
 // IAMUUMAPI provides IAM UUM APIs ...
-func (sess clientSession) IAMUUMAPI() (iamuumv1.IAMUUMServiceAPI, error) {
+func (sess *clientSession) IAMUUMAPI() (iamuumv1.IAMUUMServiceAPI, error) {
+	sess.iamUUMOnce.Do(func() {
+		if sess.iamUUMConfigErr = sess.bluemixClientErr("iam"); sess.iamUUMConfigErr != nil {
+			return
+		}
+		iamuum, err := iamuumv1.New(sess.serviceSession("iam"))
+		if err != nil {
+			sess.iamUUMConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
+		}
+		sess.iamUUMServiceAPI = iamuum
+	})
 	return sess.iamUUMServiceAPI, sess.iamUUMConfigErr
 }
 
 // IAMUUMAPIV2 provides IAM UUM APIs ...
-func (sess clientSession) IAMUUMAPIV2() (iamuumv2.IAMUUMServiceAPIv2, error) {
+func (sess *clientSession) IAMUUMAPIV2() (iamuumv2.IAMUUMServiceAPIv2, error) {
+	sess.iamUUMOnceV2.Do(func() {
+		if sess.iamUUMConfigErrV2 = sess.bluemixClientErr("iam"); sess.iamUUMConfigErrV2 != nil {
+			return
+		}
+		iamuum, err := iamuumv2.New(sess.serviceSession("iam"))
+		if err != nil {
+			sess.iamUUMConfigErrV2 = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
+		}
+		sess.iamUUMServiceAPIV2 = iamuum
+	})
 	return sess.iamUUMServiceAPIV2, sess.iamUUMConfigErrV2
 }
 
 // IcdAPI provides IBM Cloud Databases APIs ...
-func (sess clientSession) ICDAPI() (icdv4.ICDServiceAPI, error) {
+func (sess *clientSession) ICDAPI() (icdv4.ICDServiceAPI, error) {
+	sess.icdOnce.Do(func() {
+		if sess.icdConfigErr = sess.bluemixClientErr("icd"); sess.icdConfigErr != nil {
+			return
+		}
+		icdAPI, err := icdv4.New(sess.serviceSession("icd"))
+		if err != nil {
+			sess.icdConfigErr = fmt.Errorf("Error occured while configuring IBM Cloud Database Services: %q", err)
+		}
+		sess.icdServiceAPI = icdAPI
+	})
 	return sess.icdServiceAPI, sess.icdConfigErr
 }
 
 // MccpAPI provides Multi Cloud Controller Proxy APIs ...
-func (sess clientSession) MccpAPI() (mccpv2.MccpServiceAPI, error) {
+func (sess *clientSession) MccpAPI() (mccpv2.MccpServiceAPI, error) {
+	sess.cfOnce.Do(func() {
+		if sess.cfConfigErr = sess.bluemixClientErr("mccp"); sess.cfConfigErr != nil {
+			return
+		}
+		if err := sess.authenticateCF(); err != nil {
+			sess.cfConfigErr = fmt.Errorf("Error occured while configuring MCCP service: %q", err)
+			return
+		}
+		cfAPI, err := mccpv2.New(sess.serviceSession("mccp"))
+		if err != nil {
+			sess.cfConfigErr = fmt.Errorf("Error occured while configuring MCCP service: %q", err)
+		}
+		sess.cfServiceAPI = cfAPI
+	})
 	return sess.cfServiceAPI, sess.cfConfigErr
 }
 
 // ResourceCatalogAPI ...
-func (sess clientSession) ResourceCatalogAPI() (catalog.ResourceCatalogAPI, error) {
+func (sess *clientSession) ResourceCatalogAPI() (catalog.ResourceCatalogAPI, error) {
+	sess.resourceCatalogOnce.Do(func() {
+		if sess.resourceCatalogConfigErr = sess.bluemixClientErr("resource_catalog"); sess.resourceCatalogConfigErr != nil {
+			return
+		}
+		resourceCatalogAPI, err := catalog.New(sess.serviceSession("resource_catalog"))
+		if err != nil {
+			sess.resourceCatalogConfigErr = fmt.Errorf("Error occured while configuring Resource Catalog service: %q", err)
+		}
+		sess.resourceCatalogServiceAPI = resourceCatalogAPI
+	})
 	return sess.resourceCatalogServiceAPI, sess.resourceCatalogConfigErr
 }
 
 // ResourceManagementAPI ...
-func (sess clientSession) ResourceManagementAPI() (management.ResourceManagementAPI, error) {
+func (sess *clientSession) ResourceManagementAPI() (management.ResourceManagementAPI, error) {
+	sess.resourceManagementOnce.Do(func() {
+		if sess.resourceManagementConfigErr = sess.bluemixClientErr("resource_manager"); sess.resourceManagementConfigErr != nil {
+			return
+		}
+		resourceManagementAPI, err := management.New(sess.serviceSession("resource_manager"))
+		if err != nil {
+			sess.resourceManagementConfigErr = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
+		}
+		sess.resourceManagementServiceAPI = resourceManagementAPI
+	})
 	return sess.resourceManagementServiceAPI, sess.resourceManagementConfigErr
 }
 
 // ResourceManagementAPIv2 ...
-func (sess clientSession) ResourceManagementAPIv2() (managementv2.ResourceManagementAPIv2, error) {
+func (sess *clientSession) ResourceManagementAPIv2() (managementv2.ResourceManagementAPIv2, error) {
+	sess.resourceManagementOncev2.Do(func() {
+		if sess.resourceManagementConfigErrv2 = sess.bluemixClientErr("resource_manager"); sess.resourceManagementConfigErrv2 != nil {
+			return
+		}
+		resourceManagementAPIv2, err := managementv2.New(sess.serviceSession("resource_manager"))
+		if err != nil {
+			sess.resourceManagementConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
+		}
+		sess.resourceManagementServiceAPIv2 = resourceManagementAPIv2
+	})
 	return sess.resourceManagementServiceAPIv2, sess.resourceManagementConfigErrv2
 }
 
 // ResourceControllerAPI ...
-func (sess clientSession) ResourceControllerAPI() (controller.ResourceControllerAPI, error) {
+func (sess *clientSession) ResourceControllerAPI() (controller.ResourceControllerAPI, error) {
+	sess.resourceControllerOnce.Do(func() {
+		if sess.resourceControllerConfigErr = sess.bluemixClientErr("resource_controller"); sess.resourceControllerConfigErr != nil {
+			return
+		}
+		resourceControllerAPI, err := controller.New(sess.serviceSession("resource_controller"))
+		if err != nil {
+			sess.resourceControllerConfigErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
+		}
+		sess.resourceControllerServiceAPI = resourceControllerAPI
+	})
 	return sess.resourceControllerServiceAPI, sess.resourceControllerConfigErr
 }
 
 // ResourceControllerAPIv2 ...
-func (sess clientSession) ResourceControllerAPIV2() (controllerv2.ResourceControllerAPIV2, error) {
+func (sess *clientSession) ResourceControllerAPIV2() (controllerv2.ResourceControllerAPIV2, error) {
+	sess.resourceControllerOncev2.Do(func() {
+		if sess.resourceControllerConfigErrv2 = sess.bluemixClientErr("resource_controller"); sess.resourceControllerConfigErrv2 != nil {
+			return
+		}
+		resourceControllerAPIv2, err := controllerv2.New(sess.serviceSession("resource_controller"))
+		if err != nil {
+			sess.resourceControllerConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Controller v2 service: %q", err)
+		}
+		sess.resourceControllerServiceAPIv2 = resourceControllerAPIv2
+	})
 	return sess.resourceControllerServiceAPIv2, sess.resourceControllerConfigErrv2
 }
 
 // SoftLayerSession providers SoftLayer Session
-func (sess clientSession) SoftLayerSession() *slsession.Session {
+func (sess *clientSession) SoftLayerSession() *slsession.Session {
+	// A SoftLayer session configured with an IAM token uses the refreshed one.
+	if sess.session.BluemixSession != nil && sess.session.SoftLayerSession.IAMToken != "" {
+		sess.authenticate()
+	}
 	return sess.session.SoftLayerSession
 }
 
 // CertManagementAPI provides Certificate  management APIs ...
-func (sess clientSession) CertificateManagerAPI() (certificatemanager.CertificateManagerServiceAPI, error) {
+func (sess *clientSession) CertificateManagerAPI() (certificatemanager.CertificateManagerServiceAPI, error) {
+	sess.certManagementOnce.Do(func() {
+		if sess.certManagementErr = sess.bluemixClientErr("certificate_manager"); sess.certManagementErr != nil {
+			return
+		}
+		certManagementAPI, err := certificatemanager.New(sess.serviceSession("certificate_manager"))
+		if err != nil {
+			sess.certManagementErr = fmt.Errorf("Error occured while configuring Certificate manager service: %q", err)
+		}
+		sess.certManagementAPI = certManagementAPI
+	})
 	return sess.certManagementAPI, sess.certManagementErr
 }
 
 //apigatewayAPI provides API Gateway APIs
-func (sess clientSession) APIGateway() (*apigateway.ApiGatewayControllerApiV1, error) {
+func (sess *clientSession) APIGateway() (*apigateway.ApiGatewayControllerApiV1, error) {
+	sess.apigatewayOnce.Do(func() {
+		if sess.apigatewayErr = sess.bluemixClientErr("api_gateway"); sess.apigatewayErr != nil {
+			return
+		}
+		apicurl := fmt.Sprintf("https://api.%s.apigw.cloud.ibm.com/controller", sess.config.Region)
+		APIGatewayControllerAPIV1Options := &apigateway.ApiGatewayControllerApiV1Options{
+			URL:           sess.config.serviceEndpoint("api_gateway", apicurl),
+			Authenticator: &core.NoAuthAuthenticator{},
+		}
+		apigatewayAPI, err := apigateway.NewApiGatewayControllerApiV1(APIGatewayControllerAPIV1Options)
+		if err != nil {
+			sess.apigatewayErr = fmt.Errorf("Error occured while configuring  APIGateway service: %q", err)
+			return
+		}
+		apigatewayAPI.Service.SetHTTPClient(sess.sdkHTTPClient("api_gateway"))
+		sess.apigatewayAPI = apigatewayAPI
+	})
 	return sess.apigatewayAPI, sess.apigatewayErr
 }
 
-func (sess clientSession) keyProtectAPI() (*kp.Client, error) {
+func (sess *clientSession) keyProtectAPI() (*kp.Client, error) {
+	sess.kpOnce.Do(func() {
+		if sess.kpErr = sess.bluemixClientErr("kms"); sess.kpErr != nil {
+			return
+		}
+		kpurl := fmt.Sprintf("https://%s.kms.cloud.ibm.com", sess.config.Region)
+		options := kp.ClientConfig{
+			BaseURL:       sess.config.serviceEndpoint("kms", kpurl),
+			Authorization: sess.session.BluemixSession.Config.IAMAccessToken,
+			// InstanceID:    "42fET57nnadurKXzXAedFLOhGqETfIGYxOmQXkFgkJV9",
+			Verbose: kp.VerboseFailOnly,
+		}
+		kpAPIclient, err := kp.New(options, sess.sdkHTTPClient("kms").Transport)
+		if err != nil {
+			sess.kpErr = fmt.Errorf("Error occured while configuring Key Protect Service: %q", err)
+		}
+		sess.kpAPI = kpAPIclient
+	})
 	return sess.kpAPI, sess.kpErr
 }
 
-func (sess clientSession) keyManagementAPI() (*kp.Client, error) {
+func (sess *clientSession) keyManagementAPI() (*kp.Client, error) {
+	sess.kmsOnce.Do(func() {
+		if sess.kmsErr = sess.bluemixClientErr("kms"); sess.kmsErr != nil {
+			return
+		}
+		kmsurl := fmt.Sprintf("https://%s.kms.cloud.ibm.com", sess.config.Region)
+		kmsOptions := kp.ClientConfig{
+			BaseURL:       sess.config.serviceEndpoint("kms", kmsurl),
+			Authorization: sess.session.BluemixSession.Config.IAMAccessToken,
+			// InstanceID:    "5af62d5d-5d90-4b84-bbcd-90d2123ae6c8",
+			Verbose: kp.VerboseFailOnly,
+		}
+		kmsAPIclient, err := kp.New(kmsOptions, sess.sdkHTTPClient("kms").Transport)
+		if err != nil {
+			sess.kmsErr = fmt.Errorf("Error occured while configuring key Service: %q", err)
+		}
+		sess.kmsAPI = kmsAPIclient
+	})
 	return sess.kmsAPI, sess.kmsErr
 }
 
-func (sess clientSession) VpcClassicV1API() (*vpcclassic.VpcClassicV1, error) {
+func (sess *clientSession) VpcClassicV1API() (*vpcclassic.VpcClassicV1, error) {
+	sess.vpcClassicOnce.Do(func() {
+		if sess.vpcClassicErr = sess.bluemixClientErr("vpc_classic"); sess.vpcClassicErr != nil {
+			return
+		}
+		vpcclassicurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", sess.config.Region)
+		vpcclassicoptions := &vpcclassic.VpcClassicV1Options{
+			URL:           sess.config.serviceEndpoint("vpc_classic", vpcclassicurl),
+			Authenticator: sess.authenticator(),
+		}
+		vpcclassicclient, err := vpcclassic.NewVpcClassicV1(vpcclassicoptions)
+		if err != nil {
+			sess.vpcClassicErr = fmt.Errorf("Error occured while configuring vpc classic service: %q", err)
+			return
+		}
+		vpcclassicclient.Service.SetHTTPClient(sess.sdkHTTPClient("vpc_classic"))
+		sess.vpcClassicAPI = vpcclassicclient
+	})
 	return sess.vpcClassicAPI, sess.vpcClassicErr
 }
 
-func (sess clientSession) VpcV1API() (*vpc.VpcV1, error) {
+func (sess *clientSession) VpcV1API() (*vpc.VpcV1, error) {
+	sess.vpcOnce.Do(func() {
+		if sess.vpcErr = sess.bluemixClientErr("vpc"); sess.vpcErr != nil {
+			return
+		}
+		vpcurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", sess.config.Region)
+		vpcoptions := &vpc.VpcV1Options{
+			URL:           sess.config.serviceEndpoint("vpc", vpcurl),
+			Authenticator: sess.authenticator(),
+		}
+		vpcclient, err := vpc.NewVpcV1(vpcoptions)
+		if err != nil {
+			sess.vpcErr = fmt.Errorf("Error occured while configuring vpc service: %q", err)
+			return
+		}
+		vpcclient.Service.SetHTTPClient(sess.sdkHTTPClient("vpc"))
+		sess.vpcAPI = vpcclient
+	})
 	return sess.vpcAPI, sess.vpcErr
 }
 
-func (sess clientSession) DirectlinkV1API() (*dl.DirectLinkV1, error) {
+func (sess *clientSession) DirectlinkV1API() (*dl.DirectLinkV1, error) {
+	sess.directlinkOnce.Do(func() {
+		if sess.directlinkErr = sess.bluemixClientErr("directlink"); sess.directlinkErr != nil {
+			return
+		}
+		directlinkOptions := &dl.DirectLinkV1Options{
+			URL:           sess.config.serviceEndpoint("directlink", "https://directlink.cloud.ibm.com/v1"),
+			Authenticator: sess.authenticator(),
+			Version:       CreateVersionDate(),
+		}
+		sess.directlinkAPI, sess.directlinkErr = dl.NewDirectLinkV1(directlinkOptions)
+		if sess.directlinkErr != nil {
+			sess.directlinkErr = fmt.Errorf("Error occured while configuring Direct Link Service: %s", sess.directlinkErr)
+			return
+		}
+		sess.directlinkAPI.Service.SetHTTPClient(sess.sdkHTTPClient("directlink"))
+	})
 	return sess.directlinkAPI, sess.directlinkErr
 }
-func (sess clientSession) DirectlinkProviderV2API() (*dlProviderV2.DirectLinkProviderV2, error) {
+func (sess *clientSession) DirectlinkProviderV2API() (*dlProviderV2.DirectLinkProviderV2, error) {
+	sess.dlProviderOnce.Do(func() {
+		if sess.dlProviderErr = sess.bluemixClientErr("directlink_provider"); sess.dlProviderErr != nil {
+			return
+		}
+		directLinkProviderV2Options := &dlProviderV2.DirectLinkProviderV2Options{
+			URL:           sess.config.serviceEndpoint("directlink_provider", "https://directlink.cloud.ibm.com/provider/v2"),
+			Authenticator: sess.authenticator(),
+			Version:       CreateVersionDate(),
+		}
+		sess.dlProviderAPI, sess.dlProviderErr = dlProviderV2.NewDirectLinkProviderV2(directLinkProviderV2Options)
+		if sess.dlProviderErr != nil {
+			sess.dlProviderErr = fmt.Errorf("Error occured while configuring Direct Link Provider Service: %s", sess.dlProviderErr)
+			return
+		}
+		sess.dlProviderAPI.Service.SetHTTPClient(sess.sdkHTTPClient("directlink_provider"))
+	})
 	return sess.dlProviderAPI, sess.dlProviderErr
 }
-func (sess clientSession) CosConfigV1API() (*cosconfig.ResourceConfigurationV1, error) {
+func (sess *clientSession) CosConfigV1API() (*cosconfig.ResourceConfigurationV1, error) {
+	sess.cosConfigOnce.Do(func() {
+		if sess.cosConfigErr = sess.bluemixClientErr("cos_config"); sess.cosConfigErr != nil {
+			return
+		}
+		cosconfigoptions := &cosconfig.ResourceConfigurationV1Options{
+			Authenticator: sess.authenticator(),
+			URL:           sess.config.serviceEndpoint("cos_config", "https://config.cloud-object-storage.cloud.ibm.com/v1"),
+		}
+		cosconfigclient, err := cosconfig.NewResourceConfigurationV1(cosconfigoptions)
+		if err != nil {
+			sess.cosConfigErr = fmt.Errorf("Error occured while configuring COS config service: %q", err)
+			return
+		}
+		cosconfigclient.Service.SetHTTPClient(sess.sdkHTTPClient("cos_config"))
+		sess.cosConfigAPI = cosconfigclient
+	})
 	return sess.cosConfigAPI, sess.cosConfigErr
 }
 
-func (sess clientSession) TransitGatewayV1API() (*tg.TransitGatewayApisV1, error) {
+func (sess *clientSession) TransitGatewayV1API() (*tg.TransitGatewayApisV1, error) {
+	sess.transitgatewayOnce.Do(func() {
+		if sess.transitgatewayErr = sess.bluemixClientErr("tg"); sess.transitgatewayErr != nil {
+			return
+		}
+		transitgatewayOptions := &tg.TransitGatewayApisV1Options{
+			URL:           sess.config.serviceEndpoint("tg", "https://transit.cloud.ibm.com/v1"),
+			Authenticator: sess.authenticator(),
+			Version:       CreateVersionDate(),
+		}
+		sess.transitgatewayAPI, sess.transitgatewayErr = tg.NewTransitGatewayApisV1(transitgatewayOptions)
+		if sess.transitgatewayErr != nil {
+			sess.transitgatewayErr = fmt.Errorf("Error occured while configuring Transit Gateway Service: %s", sess.transitgatewayErr)
+			return
+		}
+		sess.transitgatewayAPI.Service.SetHTTPClient(sess.sdkHTTPClient("tg"))
+	})
 	return sess.transitgatewayAPI, sess.transitgatewayErr
 }
 
 // Session to the Power Colo Service
 
-func (sess clientSession) IBMPISession() (*ibmpisession.IBMPISession, error) {
-	return sess.ibmpiSession, sess.powerConfigErr
+func (sess *clientSession) IBMPISession() (*ibmpisession.IBMPISession, error) {
+	sess.ibmpiOnce.Do(func() {
+		if sess.ibmpiConfigErr = sess.bluemixClientErr("power"); sess.ibmpiConfigErr != nil {
+			if sess.session.BluemixSession != nil {
+				sess.ibmpiConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", sess.ibmpiConfigErr)
+			}
+			return
+		}
+		c := sess.config
+		sess.ibmpiSession, sess.ibmpiConfigErr = ibmpisession.New(sess.session.BluemixSession.Config.IAMAccessToken, c.Region, false, c.BluemixTimeout, sess.bmxUserDetails.userAccount, c.Zone)
+		if sess.ibmpiConfigErr != nil {
+			return
+		}
+		// Send the Power requests through the shared transport, which keeps
+		// their IAM token current
+		if runtime, ok := sess.ibmpiSession.Power.Transport.(*httptransport.Runtime); ok {
+			runtime.Transport = sess.session.HTTPClient.Transport
+		}
+	})
+	return sess.ibmpiSession, sess.ibmpiConfigErr
 }
 
 // Private DNS Service
 
-func (sess clientSession) PrivateDNSClientSession() (*dns.DnsSvcsV1, error) {
+func (sess *clientSession) PrivateDNSClientSession() (*dns.DnsSvcsV1, error) {
+	sess.pDNSOnce.Do(func() {
+		if sess.pDNSErr = sess.bluemixClientErr("private_dns"); sess.pDNSErr != nil {
+			return
+		}
+		dnsOptions := &dns.DnsSvcsV1Options{
+			URL:           sess.config.serviceEndpoint("private_dns", "https://api.dns-svcs.cloud.ibm.com/v1"),
+			Authenticator: sess.authenticator(),
+		}
+		sess.pDNSClient, sess.pDNSErr = dns.NewDnsSvcsV1(dnsOptions)
+		if sess.pDNSErr != nil {
+			sess.pDNSErr = fmt.Errorf("Error occured while configuring PrivateDNS Service: %s", sess.pDNSErr)
+			return
+		}
+		sess.pDNSClient.Service.SetHTTPClient(sess.sdkHTTPClient("private_dns"))
+	})
 	return sess.pDNSClient, sess.pDNSErr
 }
 
 // Session to the Namespace cloud function
 
-func (sess clientSession) IAMNamespaceAPI() (*ns.IbmCloudFunctionsNamespaceAPIV1, error) {
+func (sess *clientSession) IAMNamespaceAPI() (*ns.IbmCloudFunctionsNamespaceAPIV1, error) {
+	sess.iamNamespaceOnce.Do(func() {
+		if sess.iamNamespaceErr = sess.bluemixClientErr("functions_namespace"); sess.iamNamespaceErr != nil {
+			return
+		}
+		cfcurl := fmt.Sprintf("https://%s.functions.cloud.ibm.com/api/v1", sess.config.Region)
+		ibmCloudFunctionsNamespaceOptions := &ns.IbmCloudFunctionsNamespaceOptions{
+			URL:           sess.config.serviceEndpoint("functions_namespace", cfcurl),
+			Authenticator: sess.authenticator(),
+		}
+		namespaceAPI, err := ns.NewIbmCloudFunctionsNamespaceAPIV1(ibmCloudFunctionsNamespaceOptions)
+		if err != nil {
+			sess.iamNamespaceErr = fmt.Errorf("Error occured while configuring IAM namespace service: %q", err)
+			return
+		}
+		namespaceAPI.Service.SetHTTPClient(sess.sdkHTTPClient("functions_namespace"))
+		sess.iamNamespaceAPI = namespaceAPI
+	})
 	return sess.iamNamespaceAPI, sess.iamNamespaceErr
 }
 
+// cisEndpoint returns the URL shared by the CIS service clients.
+func (sess *clientSession) cisEndpoint() string {
+	return sess.config.serviceEndpoint("cis", "https://api.cis.cloud.ibm.com")
+}
+
 // CIS Zones Service
-func (sess clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error) {
+func (sess *clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error) {
+	sess.cisZonesOnce.Do(func() {
+		if sess.cisZonesErr = sess.bluemixClientErr("cis"); sess.cisZonesErr != nil {
+			return
+		}
+		cisZonesV1Opt := &ciszonesv1.ZonesV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisZonesV1Client, sess.cisZonesErr = ciszonesv1.NewZonesV1(cisZonesV1Opt)
+		if sess.cisZonesErr != nil {
+			sess.cisZonesErr = fmt.Errorf(
+				"Error occured while configuring CIS Zones service: %s",
+				sess.cisZonesErr)
+			return
+		}
+		sess.cisZonesV1Client.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisZonesErr != nil {
 		return sess.cisZonesV1Client, sess.cisZonesErr
 	}
@@ -646,7 +1373,24 @@ func (sess clientSession) CisZonesV1ClientSession() (*ciszonesv1.ZonesV1, error)
 }
 
 // CIS DNS Service
-func (sess clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecordsV1, error) {
+func (sess *clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecordsV1, error) {
+	sess.cisDNSOnce.Do(func() {
+		if sess.cisDNSErr = sess.bluemixClientErr("cis"); sess.cisDNSErr != nil {
+			return
+		}
+		cisDNSRecordsOpt := &cisdnsrecordsv1.DnsRecordsV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisDNSRecordsClient, sess.cisDNSErr = cisdnsrecordsv1.NewDnsRecordsV1(cisDNSRecordsOpt)
+		if sess.cisDNSErr != nil {
+			sess.cisDNSErr = fmt.Errorf("Error occured while configuring CIS DNS Service: %s", sess.cisDNSErr)
+			return
+		}
+		sess.cisDNSRecordsClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisDNSErr != nil {
 		return sess.cisDNSRecordsClient, sess.cisDNSErr
 	}
@@ -654,7 +1398,26 @@ func (sess clientSession) CisDNSRecordClientSession() (*cisdnsrecordsv1.DnsRecor
 }
 
 // CIS DNS Bulk Service
-func (sess clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsRecordBulkV1, error) {
+func (sess *clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsRecordBulkV1, error) {
+	sess.cisDNSBulkOnce.Do(func() {
+		if sess.cisDNSBulkErr = sess.bluemixClientErr("cis"); sess.cisDNSBulkErr != nil {
+			return
+		}
+		cisDNSRecordBulkOpt := &cisdnsbulkv1.DnsRecordBulkV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisDNSRecordBulkClient, sess.cisDNSBulkErr = cisdnsbulkv1.NewDnsRecordBulkV1(cisDNSRecordBulkOpt)
+		if sess.cisDNSBulkErr != nil {
+			sess.cisDNSBulkErr = fmt.Errorf(
+				"Error occured while configuration CIS DNS bulk service : %s",
+				sess.cisDNSBulkErr)
+			return
+		}
+		sess.cisDNSRecordBulkClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisDNSBulkErr != nil {
 		return sess.cisDNSRecordBulkClient, sess.cisDNSBulkErr
 	}
@@ -662,7 +1425,26 @@ func (sess clientSession) CisDNSRecordBulkClientSession() (*cisdnsbulkv1.DnsReco
 }
 
 // CIS GLB Pool
-func (sess clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBalancerPoolsV0, error) {
+func (sess *clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBalancerPoolsV0, error) {
+	sess.cisGLBPoolOnce.Do(func() {
+		if sess.cisGLBPoolErr = sess.bluemixClientErr("cis"); sess.cisGLBPoolErr != nil {
+			return
+		}
+		cisGLBPoolOpt := &cisglbpoolv0.GlobalLoadBalancerPoolsV0Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisGLBPoolClient, sess.cisGLBPoolErr =
+			cisglbpoolv0.NewGlobalLoadBalancerPoolsV0(cisGLBPoolOpt)
+		if sess.cisGLBPoolErr != nil {
+			sess.cisGLBPoolErr =
+				fmt.Errorf("Error occured while configuring CIS GLB Pool service: %s",
+					sess.cisGLBPoolErr)
+			return
+		}
+		sess.cisGLBPoolClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisGLBPoolErr != nil {
 		return sess.cisGLBPoolClient, sess.cisGLBPoolErr
 	}
@@ -670,7 +1452,26 @@ func (sess clientSession) CisGLBPoolClientSession() (*cisglbpoolv0.GlobalLoadBal
 }
 
 // CIS GLB
-func (sess clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1, error) {
+func (sess *clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1, error) {
+	sess.cisGLBOnce.Do(func() {
+		if sess.cisGLBErr = sess.bluemixClientErr("cis"); sess.cisGLBErr != nil {
+			return
+		}
+		cisGLBOpt := &cisglbv1.GlobalLoadBalancerV1Options{
+			URL:            sess.cisEndpoint(),
+			Authenticator:  sess.authenticator(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+		}
+		sess.cisGLBClient, sess.cisGLBErr = cisglbv1.NewGlobalLoadBalancerV1(cisGLBOpt)
+		if sess.cisGLBErr != nil {
+			sess.cisGLBErr =
+				fmt.Errorf("Error occured while configuring CIS GLB service: %s",
+					sess.cisGLBErr)
+			return
+		}
+		sess.cisGLBClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisGLBErr != nil {
 		return sess.cisGLBClient, sess.cisGLBErr
 	}
@@ -678,7 +1479,26 @@ func (sess clientSession) CisGLBClientSession() (*cisglbv1.GlobalLoadBalancerV1,
 }
 
 // CIS GLB Health Check/Monitor
-func (sess clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1, error) {
+func (sess *clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1, error) {
+	sess.cisGLBHealthCheckOnce.Do(func() {
+		if sess.cisGLBHealthCheckErr = sess.bluemixClientErr("cis"); sess.cisGLBHealthCheckErr != nil {
+			return
+		}
+		cisGLBHealthCheckOpt := &cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisGLBHealthCheckClient, sess.cisGLBHealthCheckErr =
+			cisglbhealthcheckv1.NewGlobalLoadBalancerMonitorV1(cisGLBHealthCheckOpt)
+		if sess.cisGLBHealthCheckErr != nil {
+			sess.cisGLBHealthCheckErr =
+				fmt.Errorf("Error occured while configuring CIS GLB Health Check service: %s",
+					sess.cisGLBHealthCheckErr)
+			return
+		}
+		sess.cisGLBHealthCheckClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisGLBHealthCheckErr != nil {
 		return sess.cisGLBHealthCheckClient, sess.cisGLBHealthCheckErr
 	}
@@ -686,7 +1506,26 @@ func (sess clientSession) CisGLBHealthCheckClientSession() (*cisglbhealthcheckv1
 }
 
 // CIS Zone Rate Limits
-func (sess clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1, error) {
+func (sess *clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1, error) {
+	sess.cisRLOnce.Do(func() {
+		if sess.cisRLErr = sess.bluemixClientErr("cis"); sess.cisRLErr != nil {
+			return
+		}
+		cisRLOpt := &cisratelimitv1.ZoneRateLimitsV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisRLClient, sess.cisRLErr = cisratelimitv1.NewZoneRateLimitsV1(cisRLOpt)
+		if sess.cisRLErr != nil {
+			sess.cisRLErr = fmt.Errorf(
+				"Error occured while cofiguring CIS Zone Rate Limit service: %s",
+				sess.cisRLErr)
+			return
+		}
+		sess.cisRLClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisRLErr != nil {
 		return sess.cisRLClient, sess.cisRLErr
 	}
@@ -694,7 +1533,23 @@ func (sess clientSession) CisRLClientSession() (*cisratelimitv1.ZoneRateLimitsV1
 }
 
 // CIS IP
-func (sess clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
+func (sess *clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
+	sess.cisIPOnce.Do(func() {
+		if sess.cisIPErr = sess.bluemixClientErr("cis"); sess.cisIPErr != nil {
+			return
+		}
+		cisIPOpt := &cisipv1.CisIpApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisIPClient, sess.cisIPErr = cisipv1.NewCisIpApiV1(cisIPOpt)
+		if sess.cisIPErr != nil {
+			sess.cisIPErr = fmt.Errorf("Error occured while configuring CIS IP service: %s",
+				sess.cisIPErr)
+			return
+		}
+		sess.cisIPClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisIPErr != nil {
 		return sess.cisIPClient, sess.cisIPErr
 	}
@@ -702,7 +1557,26 @@ func (sess clientSession) CisIPClientSession() (*cisipv1.CisIpApiV1, error) {
 }
 
 // CIS Page Rules
-func (sess clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApiV1, error) {
+func (sess *clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApiV1, error) {
+	sess.cisPageRuleOnce.Do(func() {
+		if sess.cisPageRuleErr = sess.bluemixClientErr("cis"); sess.cisPageRuleErr != nil {
+			return
+		}
+		cisPageRuleOpt := &cispagerulev1.PageRuleApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			ZoneID:        core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisPageRuleClient, sess.cisPageRuleErr = cispagerulev1.NewPageRuleApiV1(cisPageRuleOpt)
+		if sess.cisPageRuleErr != nil {
+			sess.cisPageRuleErr = fmt.Errorf(
+				"Error occured while cofiguring CIS Page Rule service: %s",
+				sess.cisPageRuleErr)
+			return
+		}
+		sess.cisPageRuleClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisPageRuleErr != nil {
 		return sess.cisPageRuleClient, sess.cisPageRuleErr
 	}
@@ -710,7 +1584,27 @@ func (sess clientSession) CisPageRuleClientSession() (*cispagerulev1.PageRuleApi
 }
 
 // CIS Edge Function
-func (sess clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.EdgeFunctionsApiV1, error) {
+func (sess *clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.EdgeFunctionsApiV1, error) {
+	sess.cisEdgeFunctionOnce.Do(func() {
+		if sess.cisEdgeFunctionErr = sess.bluemixClientErr("cis"); sess.cisEdgeFunctionErr != nil {
+			return
+		}
+		cisEdgeFunctionOpt := &cisedgefunctionv1.EdgeFunctionsApiV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisEdgeFunctionClient, sess.cisEdgeFunctionErr =
+			cisedgefunctionv1.NewEdgeFunctionsApiV1(cisEdgeFunctionOpt)
+		if sess.cisEdgeFunctionErr != nil {
+			sess.cisEdgeFunctionErr =
+				fmt.Errorf("Error occured while configuring CIS Edge Function service: %s",
+					sess.cisEdgeFunctionErr)
+			return
+		}
+		sess.cisEdgeFunctionClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisEdgeFunctionErr != nil {
 		return sess.cisEdgeFunctionClient, sess.cisEdgeFunctionErr
 	}
@@ -718,15 +1612,54 @@ func (sess clientSession) CisEdgeFunctionClientSession() (*cisedgefunctionv1.Edg
 }
 
 // CIS SSL certificate
-func (sess clientSession) CisSSLClientSession() (*cissslv1.SslCertificateApiV1, error) {
-	if sess.cisSSLErr != nil {
-		return sess.cisSSLClient, sess.cisSSLErr
-	}
+func (sess *clientSession) CisSSLClientSession() (*cissslv1.SslCertificateApiV1, error) {
+	sess.cisSSLOnce.Do(func() {
+		if sess.cisSSLErr = sess.bluemixClientErr("cis"); sess.cisSSLErr != nil {
+			return
+		}
+		cisSSLOpt := &cissslv1.SslCertificateApiV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisSSLClient, sess.cisSSLErr = cissslv1.NewSslCertificateApiV1(cisSSLOpt)
+		if sess.cisSSLErr != nil {
+			sess.cisSSLErr =
+				fmt.Errorf("Error occured while configuring CIS SSL certificate service: %s",
+					sess.cisSSLErr)
+			return
+		}
+		sess.cisSSLClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
+	if sess.cisSSLErr != nil {
+		return sess.cisSSLClient, sess.cisSSLErr
+	}
 	return sess.cisSSLClient.Clone(), nil
 }
 
 // CIS WAF Packages
-func (sess clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRulePackagesApiV1, error) {
+func (sess *clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRulePackagesApiV1, error) {
+	sess.cisWAFPackageOnce.Do(func() {
+		if sess.cisWAFPackageErr = sess.bluemixClientErr("cis"); sess.cisWAFPackageErr != nil {
+			return
+		}
+		cisWAFPackageOpt := &ciswafpackagev1.WafRulePackagesApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			ZoneID:        core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisWAFPackageClient, sess.cisWAFPackageErr =
+			ciswafpackagev1.NewWafRulePackagesApiV1(cisWAFPackageOpt)
+		if sess.cisWAFPackageErr != nil {
+			sess.cisWAFPackageErr =
+				fmt.Errorf("Error occured while configuration CIS WAF Package service: %s",
+					sess.cisWAFPackageErr)
+			return
+		}
+		sess.cisWAFPackageClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisWAFPackageErr != nil {
 		return sess.cisWAFPackageClient, sess.cisWAFPackageErr
 	}
@@ -734,7 +1667,27 @@ func (sess clientSession) CisWAFPackageClientSession() (*ciswafpackagev1.WafRule
 }
 
 // CIS Zone Settings
-func (sess clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1.ZonesSettingsV1, error) {
+func (sess *clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1.ZonesSettingsV1, error) {
+	sess.cisDomainSettingsOnce.Do(func() {
+		if sess.cisDomainSettingsErr = sess.bluemixClientErr("cis"); sess.cisDomainSettingsErr != nil {
+			return
+		}
+		cisDomainSettingsOpt := &cisdomainsettingsv1.ZonesSettingsV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisDomainSettingsClient, sess.cisDomainSettingsErr =
+			cisdomainsettingsv1.NewZonesSettingsV1(cisDomainSettingsOpt)
+		if sess.cisDomainSettingsErr != nil {
+			sess.cisDomainSettingsErr =
+				fmt.Errorf("Error occured while configuring CIS Domain Settings service: %s",
+					sess.cisDomainSettingsErr)
+			return
+		}
+		sess.cisDomainSettingsClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisDomainSettingsErr != nil {
 		return sess.cisDomainSettingsClient, sess.cisDomainSettingsErr
 	}
@@ -742,7 +1695,27 @@ func (sess clientSession) CisDomainSettingsClientSession() (*cisdomainsettingsv1
 }
 
 // CIS Routing
-func (sess clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, error) {
+func (sess *clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, error) {
+	sess.cisRoutingOnce.Do(func() {
+		if sess.cisRoutingErr = sess.bluemixClientErr("cis"); sess.cisRoutingErr != nil {
+			return
+		}
+		cisRoutingOpt := &cisroutingv1.RoutingV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisRoutingClient, sess.cisRoutingErr =
+			cisroutingv1.NewRoutingV1(cisRoutingOpt)
+		if sess.cisRoutingErr != nil {
+			sess.cisRoutingErr =
+				fmt.Errorf("Error occured while configuring CIS Routing service: %s",
+					sess.cisRoutingErr)
+			return
+		}
+		sess.cisRoutingClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisRoutingErr != nil {
 		return sess.cisRoutingClient, sess.cisRoutingErr
 	}
@@ -750,7 +1723,27 @@ func (sess clientSession) CisRoutingClientSession() (*cisroutingv1.RoutingV1, er
 }
 
 // CIS WAF Group
-func (sess clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGroupsApiV1, error) {
+func (sess *clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGroupsApiV1, error) {
+	sess.cisWAFGroupOnce.Do(func() {
+		if sess.cisWAFGroupErr = sess.bluemixClientErr("cis"); sess.cisWAFGroupErr != nil {
+			return
+		}
+		cisWAFGroupOpt := &ciswafgroupv1.WafRuleGroupsApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			ZoneID:        core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisWAFGroupClient, sess.cisWAFGroupErr =
+			ciswafgroupv1.NewWafRuleGroupsApiV1(cisWAFGroupOpt)
+		if sess.cisWAFGroupErr != nil {
+			sess.cisWAFGroupErr =
+				fmt.Errorf("Error occured while configuring CIS WAF Group service: %s",
+					sess.cisWAFGroupErr)
+			return
+		}
+		sess.cisWAFGroupClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisWAFGroupErr != nil {
 		return sess.cisWAFGroupClient, sess.cisWAFGroupErr
 	}
@@ -758,7 +1751,27 @@ func (sess clientSession) CisWAFGroupClientSession() (*ciswafgroupv1.WafRuleGrou
 }
 
 // CIS Cache service
-func (sess clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, error) {
+func (sess *clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, error) {
+	sess.cisCacheOnce.Do(func() {
+		if sess.cisCacheErr = sess.bluemixClientErr("cis"); sess.cisCacheErr != nil {
+			return
+		}
+		cisCacheOpt := &ciscachev1.CachingApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			ZoneID:        core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisCacheClient, sess.cisCacheErr =
+			ciscachev1.NewCachingApiV1(cisCacheOpt)
+		if sess.cisCacheErr != nil {
+			sess.cisCacheErr =
+				fmt.Errorf("Error occured while configuring CIS Caching service: %s",
+					sess.cisCacheErr)
+			return
+		}
+		sess.cisCacheClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisCacheErr != nil {
 		return sess.cisCacheClient, sess.cisCacheErr
 	}
@@ -766,7 +1779,27 @@ func (sess clientSession) CisCacheClientSession() (*ciscachev1.CachingApiV1, err
 }
 
 // CIS Zone Settings
-func (sess clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomPagesV1, error) {
+func (sess *clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomPagesV1, error) {
+	sess.cisCustomPageOnce.Do(func() {
+		if sess.cisCustomPageErr = sess.bluemixClientErr("cis"); sess.cisCustomPageErr != nil {
+			return
+		}
+		cisCustomPageOpt := &ciscustompagev1.CustomPagesV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisCustomPageClient, sess.cisCustomPageErr =
+			ciscustompagev1.NewCustomPagesV1(cisCustomPageOpt)
+		if sess.cisCustomPageErr != nil {
+			sess.cisCustomPageErr =
+				fmt.Errorf("Error occured while configuring CIS Custom Pages service: %s",
+					sess.cisCustomPageErr)
+			return
+		}
+		sess.cisCustomPageClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisCustomPageErr != nil {
 		return sess.cisCustomPageClient, sess.cisCustomPageErr
 	}
@@ -774,7 +1807,27 @@ func (sess clientSession) CisCustomPageClientSession() (*ciscustompagev1.CustomP
 }
 
 // CIS Firewall access rule
-func (sess clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFirewallAccessRulesV1, error) {
+func (sess *clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFirewallAccessRulesV1, error) {
+	sess.cisAccessRuleOnce.Do(func() {
+		if sess.cisAccessRuleErr = sess.bluemixClientErr("cis"); sess.cisAccessRuleErr != nil {
+			return
+		}
+		cisAccessRuleOpt := &cisaccessrulev1.ZoneFirewallAccessRulesV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisAccessRuleClient, sess.cisAccessRuleErr =
+			cisaccessrulev1.NewZoneFirewallAccessRulesV1(cisAccessRuleOpt)
+		if sess.cisAccessRuleErr != nil {
+			sess.cisAccessRuleErr =
+				fmt.Errorf("Error occured while configuring CIS Firewall Access Rule service: %s",
+					sess.cisAccessRuleErr)
+			return
+		}
+		sess.cisAccessRuleClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisAccessRuleErr != nil {
 		return sess.cisAccessRuleClient, sess.cisAccessRuleErr
 	}
@@ -782,7 +1835,27 @@ func (sess clientSession) CisAccessRuleClientSession() (*cisaccessrulev1.ZoneFir
 }
 
 // CIS User Agent Blocking rule
-func (sess clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlockingRulesV1, error) {
+func (sess *clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlockingRulesV1, error) {
+	sess.cisUARuleOnce.Do(func() {
+		if sess.cisUARuleErr = sess.bluemixClientErr("cis"); sess.cisUARuleErr != nil {
+			return
+		}
+		cisUARuleOpt := &cisuarulev1.UserAgentBlockingRulesV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisUARuleClient, sess.cisUARuleErr =
+			cisuarulev1.NewUserAgentBlockingRulesV1(cisUARuleOpt)
+		if sess.cisUARuleErr != nil {
+			sess.cisUARuleErr =
+				fmt.Errorf("Error occured while configuring CIS Firewall User Agent Blocking Rule service: %s",
+					sess.cisUARuleErr)
+			return
+		}
+		sess.cisUARuleClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisUARuleErr != nil {
 		return sess.cisUARuleClient, sess.cisUARuleErr
 	}
@@ -790,7 +1863,27 @@ func (sess clientSession) CisUARuleClientSession() (*cisuarulev1.UserAgentBlocki
 }
 
 // CIS Firewall Lockdown rule
-func (sess clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdownV1, error) {
+func (sess *clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdownV1, error) {
+	sess.cisLockdownOnce.Do(func() {
+		if sess.cisLockdownErr = sess.bluemixClientErr("cis"); sess.cisLockdownErr != nil {
+			return
+		}
+		cisLockdownOpt := &cislockdownv1.ZoneLockdownV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisLockdownClient, sess.cisLockdownErr =
+			cislockdownv1.NewZoneLockdownV1(cisLockdownOpt)
+		if sess.cisLockdownErr != nil {
+			sess.cisLockdownErr =
+				fmt.Errorf("Error occured while configuring CIS Firewall Lockdown Rule service: %s",
+					sess.cisLockdownErr)
+			return
+		}
+		sess.cisLockdownClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisLockdownErr != nil {
 		return sess.cisLockdownClient, sess.cisLockdownErr
 	}
@@ -798,7 +1891,27 @@ func (sess clientSession) CisLockdownClientSession() (*cislockdownv1.ZoneLockdow
 }
 
 // CIS Range app rule
-func (sess clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplicationsV1, error) {
+func (sess *clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplicationsV1, error) {
+	sess.cisRangeAppOnce.Do(func() {
+		if sess.cisRangeAppErr = sess.bluemixClientErr("cis"); sess.cisRangeAppErr != nil {
+			return
+		}
+		cisRangeAppOpt := &cisrangeappv1.RangeApplicationsV1Options{
+			URL:            sess.cisEndpoint(),
+			Crn:            core.StringPtr(""),
+			ZoneIdentifier: core.StringPtr(""),
+			Authenticator:  sess.authenticator(),
+		}
+		sess.cisRangeAppClient, sess.cisRangeAppErr =
+			cisrangeappv1.NewRangeApplicationsV1(cisRangeAppOpt)
+		if sess.cisRangeAppErr != nil {
+			sess.cisRangeAppErr =
+				fmt.Errorf("Error occured while configuring CIS Range Application rule service: %s",
+					sess.cisRangeAppErr)
+			return
+		}
+		sess.cisRangeAppClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisRangeAppErr != nil {
 		return sess.cisRangeAppClient, sess.cisRangeAppErr
 	}
@@ -806,7 +1919,27 @@ func (sess clientSession) CisRangeAppClientSession() (*cisrangeappv1.RangeApplic
 }
 
 // CIS WAF Rule
-func (sess clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1, error) {
+func (sess *clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1, error) {
+	sess.cisWAFRuleOnce.Do(func() {
+		if sess.cisWAFRuleErr = sess.bluemixClientErr("cis"); sess.cisWAFRuleErr != nil {
+			return
+		}
+		cisWAFRuleOpt := &ciswafrulev1.WafRulesApiV1Options{
+			URL:           sess.cisEndpoint(),
+			Crn:           core.StringPtr(""),
+			ZoneID:        core.StringPtr(""),
+			Authenticator: sess.authenticator(),
+		}
+		sess.cisWAFRuleClient, sess.cisWAFRuleErr =
+			ciswafrulev1.NewWafRulesApiV1(cisWAFRuleOpt)
+		if sess.cisWAFRuleErr != nil {
+			sess.cisWAFRuleErr = fmt.Errorf(
+				"Error occured while configuring CIS WAF Rules service: %s",
+				sess.cisWAFRuleErr)
+			return
+		}
+		sess.cisWAFRuleClient.Service.SetHTTPClient(sess.sdkHTTPClient("cis"))
+	})
 	if sess.cisWAFRuleErr != nil {
 		return sess.cisWAFRuleClient, sess.cisWAFRuleErr
 	}
@@ -814,760 +1947,47 @@ func (sess clientSession) CisWAFRuleClientSession() (*ciswafrulev1.WafRulesApiV1
 }
 
 // IAM Identity Session
-func (sess clientSession) IAMIdentityV1API() (*iamidentity.IamIdentityV1, error) {
+func (sess *clientSession) IAMIdentityV1API() (*iamidentity.IamIdentityV1, error) {
+	sess.iamIdentityOnce.Do(func() {
+		if sess.iamIdentityErr = sess.bluemixClientErr("iam"); sess.iamIdentityErr != nil {
+			return
+		}
+		// iamIdenityURL := fmt.Sprintf("https://%s.iam.cloud.ibm.com/v1", c.Region)
+		iamIdentityOptions := &iamidentity.IamIdentityV1Options{
+			Authenticator: sess.authenticator(),
+			URL:           sess.config.serviceEndpoint("iam", "https://iam.cloud.ibm.com"),
+		}
+		iamIdentityClient, err := iamidentity.NewIamIdentityV1(iamIdentityOptions)
+		if err != nil {
+			sess.iamIdentityErr = fmt.Errorf("Error occured while configuring IAM Identity service: %q", err)
+			return
+		}
+		iamIdentityClient.Service.SetHTTPClient(sess.sdkHTTPClient("iam"))
+		sess.iamIdentityAPI = iamIdentityClient
+	})
 	return sess.iamIdentityAPI, sess.iamIdentityErr
 }
 
-// ClientSession configures and returns a fully initialized ClientSession
+// ClientSession configures and returns a ClientSession whose service clients
+// are built on first use
 func (c *Config) ClientSession() (interface{}, error) {
 	sess, err := newSession(c)
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("[INFO] Configured Region: %s\n", c.Region)
-	session := clientSession{
+	session := &clientSession{
+		config:  c,
 		session: sess,
 	}
 
 	if sess.BluemixSession == nil {
 		//Can be nil only  if bluemix_api_key is not provided
 		log.Println("Skipping Bluemix Clients configuration")
-		session.bluemixSessionErr = errEmptyBluemixCredentials
-		session.accountConfigErr = errEmptyBluemixCredentials
-		session.accountV1ConfigErr = errEmptyBluemixCredentials
-		session.csConfigErr = errEmptyBluemixCredentials
-		session.csv2ConfigErr = errEmptyBluemixCredentials
-		session.crv1ConfigErr = errEmptyBluemixCredentials
-		session.kpErr = errEmptyBluemixCredentials
-		session.kmsErr = errEmptyBluemixCredentials
-		session.stxConfigErr = errEmptyBluemixCredentials
-		session.cfConfigErr = errEmptyBluemixCredentials
-		session.cisConfigErr = errEmptyBluemixCredentials
-		session.functionConfigErr = errEmptyBluemixCredentials
-		session.globalSearchConfigErr = errEmptyBluemixCredentials
-		session.globalTaggingConfigErr = errEmptyBluemixCredentials
-		session.hpcsEndpointErr = errEmptyBluemixCredentials
-		session.iamConfigErr = errEmptyBluemixCredentials
-		session.iamPAPConfigErr = errEmptyBluemixCredentials
-		session.iamPAPConfigErrv2 = errEmptyBluemixCredentials
-		session.iamUUMConfigErr = errEmptyBluemixCredentials
-		session.iamUUMConfigErrV2 = errEmptyBluemixCredentials
-		session.icdConfigErr = errEmptyBluemixCredentials
-		session.resourceCatalogConfigErr = errEmptyBluemixCredentials
-		session.resourceManagementConfigErr = errEmptyBluemixCredentials
-		session.resourceManagementConfigErrv2 = errEmptyBluemixCredentials
-		session.resourceControllerConfigErr = errEmptyBluemixCredentials
-		session.resourceControllerConfigErrv2 = errEmptyBluemixCredentials
-		session.powerConfigErr = errEmptyBluemixCredentials
-		session.ibmpiConfigErr = errEmptyBluemixCredentials
-		session.userManagementErr = errEmptyBluemixCredentials
-		session.certManagementErr = errEmptyBluemixCredentials
-		session.vpcClassicErr = errEmptyBluemixCredentials
-		session.vpcErr = errEmptyBluemixCredentials
-		session.apigatewayErr = errEmptyBluemixCredentials
-		session.pDNSErr = errEmptyBluemixCredentials
-		session.bmxUserFetchErr = errEmptyBluemixCredentials
-		session.directlinkErr = errEmptyBluemixCredentials
-		session.dlProviderErr = errEmptyBluemixCredentials
-		session.cosConfigErr = errEmptyBluemixCredentials
-		session.transitgatewayErr = errEmptyBluemixCredentials
-		session.iamNamespaceErr = errEmptyBluemixCredentials
-		session.cisDNSErr = errEmptyBluemixCredentials
-		session.cisDNSBulkErr = errEmptyBluemixCredentials
-		session.cisGLBPoolErr = errEmptyBluemixCredentials
-		session.cisGLBErr = errEmptyBluemixCredentials
-		session.cisGLBHealthCheckErr = errEmptyBluemixCredentials
-		session.cisIPErr = errEmptyBluemixCredentials
-		session.cisZonesErr = errEmptyBluemixCredentials
-		session.cisRLErr = errEmptyBluemixCredentials
-		session.cisPageRuleErr = errEmptyBluemixCredentials
-		session.cisEdgeFunctionErr = errEmptyBluemixCredentials
-		session.cisSSLErr = errEmptyBluemixCredentials
-		session.cisWAFPackageErr = errEmptyBluemixCredentials
-		session.cisDomainSettingsErr = errEmptyBluemixCredentials
-		session.cisRoutingErr = errEmptyBluemixCredentials
-		session.cisWAFGroupErr = errEmptyBluemixCredentials
-		session.cisCacheErr = errEmptyBluemixCredentials
-		session.cisCustomPageErr = errEmptyBluemixCredentials
-		session.cisAccessRuleErr = errEmptyBluemixCredentials
-		session.cisUARuleErr = errEmptyBluemixCredentials
-		session.cisLockdownErr = errEmptyBluemixCredentials
-		session.cisRangeAppErr = errEmptyBluemixCredentials
-		session.cisWAFRuleErr = errEmptyBluemixCredentials
-		session.iamIdentityErr = errEmptyBluemixCredentials
-
 		return session, nil
 	}
-
-	if sess.BluemixSession.Config.BluemixAPIKey != "" {
-		err = authenticateAPIKey(sess.BluemixSession)
-		if err != nil {
-			session.bmxUserFetchErr = fmt.Errorf("Error occured while fetching account user details: %q", err)
-			session.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
-			session.powerConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
-			session.ibmpiConfigErr = fmt.Errorf("Error occured while fetching the auth key for power iaas: %q", err)
-		}
-		err = authenticateCF(sess.BluemixSession)
-		if err != nil {
-			session.functionConfigErr = fmt.Errorf("Error occured while fetching auth key for function: %q", err)
-		}
-	}
-
-	if sess.BluemixSession.Config.IAMAccessToken != "" && sess.BluemixSession.Config.BluemixAPIKey == "" {
-		err := refreshToken(sess.BluemixSession)
-		if err != nil {
-			return nil, err
-		}
-
-	}
-	userConfig, err := fetchUserDetails(sess.BluemixSession, c.Generation)
-	if err != nil {
-		session.bmxUserFetchErr = fmt.Errorf("Error occured while fetching account user details: %q", err)
-	}
-	session.bmxUserDetails = userConfig
-
-	if sess.SoftLayerSession != nil && sess.SoftLayerSession.IAMToken != "" {
-		sess.SoftLayerSession.IAMToken = sess.BluemixSession.Config.IAMAccessToken
-		sess.SoftLayerSession.IAMRefreshToken = sess.BluemixSession.Config.IAMRefreshToken
-	}
-
-	session.functionClient, session.functionConfigErr = FunctionClient(sess.BluemixSession.Config)
-
 	BluemixRegion = sess.BluemixSession.Config.Region
 
-	accv1API, err := accountv1.New(sess.BluemixSession)
-	if err != nil {
-		session.accountV1ConfigErr = fmt.Errorf("Error occured while configuring Bluemix Accountv1 Service: %q", err)
-	}
-	session.bmxAccountv1ServiceAPI = accv1API
-
-	accAPI, err := accountv2.New(sess.BluemixSession)
-	if err != nil {
-		session.accountConfigErr = fmt.Errorf("Error occured while configuring  Account Service: %q", err)
-	}
-	session.bmxAccountServiceAPI = accAPI
-
-	cfAPI, err := mccpv2.New(sess.BluemixSession)
-	if err != nil {
-		session.cfConfigErr = fmt.Errorf("Error occured while configuring MCCP service: %q", err)
-	}
-	session.cfServiceAPI = cfAPI
-
-	clusterAPI, err := containerv1.New(sess.BluemixSession)
-	if err != nil {
-		session.csConfigErr = fmt.Errorf("Error occured while configuring Container Service for K8s cluster: %q", err)
-	}
-	session.csServiceAPI = clusterAPI
-
-	v2clusterAPI, err := containerv2.New(sess.BluemixSession)
-	if err != nil {
-		session.csv2ConfigErr = fmt.Errorf("Error occured while configuring vpc Container Service for K8s cluster: %q", err)
-	}
-	session.csv2ServiceAPI = v2clusterAPI
-
-	v1registryAPI, err := registryv1.New(sess.BluemixSession)
-	if err != nil {
-		session.crv1ConfigErr = fmt.Errorf("Error occured while configuring Container Registry: %q", err)
-	}
-	session.crv1ServiceAPI = v1registryAPI
-
-	hpcsAPI, err := hpcs.New(sess.BluemixSession)
-	if err != nil {
-		session.hpcsEndpointErr = fmt.Errorf("Error occured while configuring hpcs Endpoint: %q", err)
-	}
-	session.hpcsEndpointAPI = hpcsAPI
-
-	kpurl := fmt.Sprintf("https://%s.kms.cloud.ibm.com", c.Region)
-	options := kp.ClientConfig{
-		BaseURL:       envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kpurl),
-		Authorization: sess.BluemixSession.Config.IAMAccessToken,
-		// InstanceID:    "42fET57nnadurKXzXAedFLOhGqETfIGYxOmQXkFgkJV9",
-		Verbose: kp.VerboseFailOnly,
-	}
-	kpAPIclient, err := kp.New(options, kp.DefaultTransport())
-	if err != nil {
-		session.kpErr = fmt.Errorf("Error occured while configuring Key Protect Service: %q", err)
-	}
-	session.kpAPI = kpAPIclient
-
-	kmsurl := fmt.Sprintf("https://%s.kms.cloud.ibm.com", c.Region)
-	kmsOptions := kp.ClientConfig{
-		BaseURL:       envFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kmsurl),
-		Authorization: sess.BluemixSession.Config.IAMAccessToken,
-		// InstanceID:    "5af62d5d-5d90-4b84-bbcd-90d2123ae6c8",
-		Verbose: kp.VerboseFailOnly,
-	}
-	kmsAPIclient, err := kp.New(kmsOptions, DefaultTransport())
-	if err != nil {
-		session.kmsErr = fmt.Errorf("Error occured while configuring key Service: %q", err)
-	}
-	session.kmsAPI = kmsAPIclient
-
-	var authenticator *core.BearerTokenAuthenticator
-	if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken[7:],
-		}
-	} else {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken,
-		}
-	}
-
-	vpcclassicurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", c.Region)
-	vpcclassicoptions := &vpcclassic.VpcClassicV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_IS_API_ENDPOINT"}, vpcclassicurl),
-		Authenticator: authenticator,
-	}
-	vpcclassicclient, err := vpcclassic.NewVpcClassicV1(vpcclassicoptions)
-	if err != nil {
-		session.vpcErr = fmt.Errorf("Error occured while configuring vpc classic service: %q", err)
-	}
-	session.vpcClassicAPI = vpcclassicclient
-
-	vpcurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", c.Region)
-	vpcoptions := &vpc.VpcV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_IS_NG_API_ENDPOINT"}, vpcurl),
-		Authenticator: authenticator,
-	}
-	vpcclient, err := vpc.NewVpcV1(vpcoptions)
-	if err != nil {
-		session.vpcErr = fmt.Errorf("Error occured while configuring vpc service: %q", err)
-	}
-	session.vpcAPI = vpcclient
-
-	//cosconfigurl := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", c.Region)
-	cosconfigoptions := &cosconfig.ResourceConfigurationV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, "https://config.cloud-object-storage.cloud.ibm.com/v1"),
-	}
-	cosconfigclient, err := cosconfig.NewResourceConfigurationV1(cosconfigoptions)
-	if err != nil {
-		session.cosConfigErr = fmt.Errorf("Error occured while configuring COS config service: %q", err)
-	}
-	session.cosConfigAPI = cosconfigclient
-
-	schematicService, err := schematics.New(sess.BluemixSession)
-	if err != nil {
-		session.stxConfigErr = fmt.Errorf("Error occured while fetching schematics Configuration: %q", err)
-	}
-	session.stxServiceAPI = schematicService
-
-	cisAPI, err := cisv1.New(sess.BluemixSession)
-	if err != nil {
-		session.cisConfigErr = fmt.Errorf("Error occured while configuring Cloud Internet Services: %q", err)
-	}
-	session.cisServiceAPI = cisAPI
-
-	globalSearchAPI, err := globalsearchv2.New(sess.BluemixSession)
-	if err != nil {
-		session.globalSearchConfigErr = fmt.Errorf("Error occured while configuring Global Search: %q", err)
-	}
-	session.globalSearchServiceAPI = globalSearchAPI
-
-	globalTaggingAPI, err := globaltaggingv3.New(sess.BluemixSession)
-	if err != nil {
-		session.globalTaggingConfigErr = fmt.Errorf("Error occured while configuring Global Tagging: %q", err)
-	}
-	session.globalTaggingServiceAPI = globalTaggingAPI
-
-	iampap, err := iampapv1.New(sess.BluemixSession)
-	if err != nil {
-		session.iamPAPConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAMPAP Service: %q", err)
-	}
-	session.iamPAPServiceAPI = iampap
-
-	iampapv2, err := iampapv2.New(sess.BluemixSession)
-	if err != nil {
-		session.iamPAPConfigErrv2 = fmt.Errorf("Error occured while configuring Bluemix IAMPAP Service: %q", err)
-	}
-	session.iamPAPServiceAPIv2 = iampapv2
-
-	iam, err := iamv1.New(sess.BluemixSession)
-	if err != nil {
-		session.iamConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAM Service: %q", err)
-	}
-	session.iamServiceAPI = iam
-
-	iamuum, err := iamuumv1.New(sess.BluemixSession)
-	if err != nil {
-		session.iamUUMConfigErr = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
-	}
-	session.iamUUMServiceAPI = iamuum
-
-	iamuumv2, err := iamuumv2.New(sess.BluemixSession)
-	if err != nil {
-		session.iamUUMConfigErrV2 = fmt.Errorf("Error occured while configuring Bluemix IAMUUM Service: %q", err)
-	}
-	session.iamUUMServiceAPIV2 = iamuumv2
-
-	icdAPI, err := icdv4.New(sess.BluemixSession)
-	if err != nil {
-		session.icdConfigErr = fmt.Errorf("Error occured while configuring IBM Cloud Database Services: %q", err)
-	}
-	session.icdServiceAPI = icdAPI
-
-	resourceCatalogAPI, err := catalog.New(sess.BluemixSession)
-	if err != nil {
-		session.resourceCatalogConfigErr = fmt.Errorf("Error occured while configuring Resource Catalog service: %q", err)
-	}
-	session.resourceCatalogServiceAPI = resourceCatalogAPI
-
-	resourceManagementAPI, err := management.New(sess.BluemixSession)
-	if err != nil {
-		session.resourceManagementConfigErr = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
-	}
-	session.resourceManagementServiceAPI = resourceManagementAPI
-
-	resourceManagementAPIv2, err := managementv2.New(sess.BluemixSession)
-	if err != nil {
-		session.resourceManagementConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Management service: %q", err)
-	}
-	session.resourceManagementServiceAPIv2 = resourceManagementAPIv2
-
-	resourceControllerAPI, err := controller.New(sess.BluemixSession)
-	if err != nil {
-		session.resourceControllerConfigErr = fmt.Errorf("Error occured while configuring Resource Controller service: %q", err)
-	}
-	session.resourceControllerServiceAPI = resourceControllerAPI
-
-	ResourceControllerAPIv2, err := controllerv2.New(sess.BluemixSession)
-	if err != nil {
-		session.resourceControllerConfigErrv2 = fmt.Errorf("Error occured while configuring Resource Controller v2 service: %q", err)
-	}
-	session.resourceControllerServiceAPIv2 = ResourceControllerAPIv2
-
-	userManagementAPI, err := usermanagementv2.New(sess.BluemixSession)
-	if err != nil {
-		session.userManagementErr = fmt.Errorf("Error occured while configuring user management service: %q", err)
-	}
-	session.userManagementAPI = userManagementAPI
-	certManagementAPI, err := certificatemanager.New(sess.BluemixSession)
-	if err != nil {
-		session.certManagementErr = fmt.Errorf("Error occured while configuring Certificate manager service: %q", err)
-	}
-	session.certManagementAPI = certManagementAPI
-
-	apicurl := fmt.Sprintf("https://api.%s.apigw.cloud.ibm.com/controller", c.Region)
-	APIGatewayControllerAPIV1Options := &apigateway.ApiGatewayControllerApiV1Options{
-		URL:           envFallBack([]string{"IBMCLOUD_API_GATEWAY_ENDPOINT"}, apicurl),
-		Authenticator: &core.NoAuthAuthenticator{},
-	}
-	apigatewayAPI, err := apigateway.NewApiGatewayControllerApiV1(APIGatewayControllerAPIV1Options)
-	if err != nil {
-		session.apigatewayErr = fmt.Errorf("Error occured while configuring  APIGateway service: %q", err)
-	}
-	session.apigatewayAPI = apigatewayAPI
-
-	ibmpisession, err := ibmpisession.New(sess.BluemixSession.Config.IAMAccessToken, c.Region, false, c.BluemixTimeout, session.bmxUserDetails.userAccount, c.Zone)
-	if err != nil {
-		session.ibmpiConfigErr = err
-		return nil, err
-	}
-
-	session.ibmpiSession = ibmpisession
-
-	bluemixToken := ""
-	if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
-		bluemixToken = sess.BluemixSession.Config.IAMAccessToken[7:len(sess.BluemixSession.Config.IAMAccessToken)]
-	} else {
-		bluemixToken = sess.BluemixSession.Config.IAMAccessToken
-	}
-
-	dnsOptions := &dns.DnsSvcsV1Options{
-		URL: envFallBack([]string{"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT"}, "https://api.dns-svcs.cloud.ibm.com/v1"),
-		Authenticator: &core.BearerTokenAuthenticator{
-			BearerToken: bluemixToken,
-		},
-	}
-
-	session.pDNSClient, session.pDNSErr = dns.NewDnsSvcsV1(dnsOptions)
-	if session.pDNSErr != nil {
-		session.pDNSErr = fmt.Errorf("Error occured while configuring PrivateDNS Service: %s", session.pDNSErr)
-	}
-	version := time.Now().Format("2006-01-02")
-
-	directlinkOptions := &dl.DirectLinkV1Options{
-		URL: envFallBack([]string{"IBMCLOUD_DL_API_ENDPOINT"}, "https://directlink.cloud.ibm.com/v1"),
-		Authenticator: &core.BearerTokenAuthenticator{
-			BearerToken: bluemixToken,
-		},
-		Version: &version,
-	}
-
-	session.directlinkAPI, session.directlinkErr = dl.NewDirectLinkV1(directlinkOptions)
-	if session.directlinkErr != nil {
-		session.directlinkErr = fmt.Errorf("Error occured while configuring Direct Link Service: %s", session.directlinkErr)
-	}
-
-	//Direct link provider
-	directLinkProviderV2Options := &dlProviderV2.DirectLinkProviderV2Options{
-		URL: envFallBack([]string{"IBMCLOUD_DL_PROVIDER_API_ENDPOINT"}, "https://directlink.cloud.ibm.com/provider/v2"),
-		Authenticator: &core.BearerTokenAuthenticator{
-			BearerToken: bluemixToken,
-		},
-		Version: &version,
-	}
-
-	session.dlProviderAPI, session.dlProviderErr = dlProviderV2.NewDirectLinkProviderV2(directLinkProviderV2Options)
-	if session.dlProviderErr != nil {
-		session.dlProviderErr = fmt.Errorf("Error occured while configuring Direct Link Provider Service: %s", session.dlProviderErr)
-	}
-	transitgatewayOptions := &tg.TransitGatewayApisV1Options{
-		URL: envFallBack([]string{"IBMCLOUD_TG_API_ENDPOINT"}, "https://transit.cloud.ibm.com/v1"),
-		Authenticator: &core.BearerTokenAuthenticator{
-			BearerToken: bluemixToken,
-		},
-		Version: CreateVersionDate(),
-	}
-
-	session.transitgatewayAPI, session.transitgatewayErr = tg.NewTransitGatewayApisV1(transitgatewayOptions)
-	if session.transitgatewayErr != nil {
-		session.transitgatewayErr = fmt.Errorf("Error occured while configuring Transit Gateway Service: %s", session.transitgatewayErr)
-	}
-
-	cfcurl := fmt.Sprintf("https://%s.functions.cloud.ibm.com/api/v1", c.Region)
-	ibmCloudFunctionsNamespaceOptions := &ns.IbmCloudFunctionsNamespaceOptions{
-		URL: envFallBack([]string{"IBMCLOUD_NAMESPACE_API_ENDPOINT"}, cfcurl),
-		Authenticator: &core.BearerTokenAuthenticator{
-			BearerToken: bluemixToken,
-		},
-	}
-
-	session.iamNamespaceAPI, err = ns.NewIbmCloudFunctionsNamespaceAPIV1(ibmCloudFunctionsNamespaceOptions)
-	if err != nil {
-		session.iamNamespaceErr = fmt.Errorf("Error occured while configuring IAM namespace service: %q", err)
-	}
-
-	// CIS Service instances starts here.
-	cisEndPoint := envFallBack([]string{"IBMCLOUD_CIS_API_ENDPOINT"}, "https://api.cis.cloud.ibm.com")
-
-	// IBM Network CIS Zones service
-	cisZonesV1Opt := &ciszonesv1.ZonesV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisZonesV1Client, session.cisZonesErr = ciszonesv1.NewZonesV1(cisZonesV1Opt)
-	if session.cisZonesErr != nil {
-		session.cisZonesErr = fmt.Errorf(
-			"Error occured while configuring CIS Zones service: %s",
-			session.cisZonesErr)
-	}
-
-	// IBM Network CIS DNS Record service
-	cisDNSRecordsOpt := &cisdnsrecordsv1.DnsRecordsV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisDNSRecordsClient, session.cisDNSErr = cisdnsrecordsv1.NewDnsRecordsV1(cisDNSRecordsOpt)
-	if session.cisDNSErr != nil {
-		session.cisDNSErr = fmt.Errorf("Error occured while configuring CIS DNS Service: %s", session.cisDNSErr)
-	}
-
-	// IBM Network CIS DNS Record bulk service
-	cisDNSRecordBulkOpt := &cisdnsbulkv1.DnsRecordBulkV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisDNSRecordBulkClient, session.cisDNSBulkErr = cisdnsbulkv1.NewDnsRecordBulkV1(cisDNSRecordBulkOpt)
-	if session.cisDNSBulkErr != nil {
-		session.cisDNSBulkErr = fmt.Errorf(
-			"Error occured while configuration CIS DNS bulk service : %s",
-			session.cisDNSBulkErr)
-	}
-
-	// IBM Network CIS Global load balancer pool
-	cisGLBPoolOpt := &cisglbpoolv0.GlobalLoadBalancerPoolsV0Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisGLBPoolClient, session.cisGLBPoolErr =
-		cisglbpoolv0.NewGlobalLoadBalancerPoolsV0(cisGLBPoolOpt)
-	if session.cisGLBPoolErr != nil {
-		session.cisGLBPoolErr =
-			fmt.Errorf("Error occured while configuring CIS GLB Pool service: %s",
-				session.cisGLBPoolErr)
-	}
-
-	// IBM Network CIS Global load balancer
-	cisGLBOpt := &cisglbv1.GlobalLoadBalancerV1Options{
-		URL:            cisEndPoint,
-		Authenticator:  authenticator,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-	}
-	session.cisGLBClient, session.cisGLBErr = cisglbv1.NewGlobalLoadBalancerV1(cisGLBOpt)
-	if session.cisGLBErr != nil {
-		session.cisGLBErr =
-			fmt.Errorf("Error occured while configuring CIS GLB service: %s",
-				session.cisGLBErr)
-	}
-
-	// IBM Network CIS Global load balancer health check/monitor
-	cisGLBHealthCheckOpt := &cisglbhealthcheckv1.GlobalLoadBalancerMonitorV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisGLBHealthCheckClient, session.cisGLBHealthCheckErr =
-		cisglbhealthcheckv1.NewGlobalLoadBalancerMonitorV1(cisGLBHealthCheckOpt)
-	if session.cisGLBHealthCheckErr != nil {
-		session.cisGLBHealthCheckErr =
-			fmt.Errorf("Error occured while configuring CIS GLB Health Check service: %s",
-				session.cisGLBHealthCheckErr)
-	}
-
-	// IBM Network CIS IP
-	cisIPOpt := &cisipv1.CisIpApiV1Options{
-		URL:           cisEndPoint,
-		Authenticator: authenticator,
-	}
-	session.cisIPClient, session.cisIPErr = cisipv1.NewCisIpApiV1(cisIPOpt)
-	if session.cisIPErr != nil {
-		session.cisIPErr = fmt.Errorf("Error occured while configuring CIS IP service: %s",
-			session.cisIPErr)
-	}
-
-	// IBM Network CIS Zone Rate Limit
-	cisRLOpt := &cisratelimitv1.ZoneRateLimitsV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisRLClient, session.cisRLErr = cisratelimitv1.NewZoneRateLimitsV1(cisRLOpt)
-	if session.cisRLErr != nil {
-		session.cisRLErr = fmt.Errorf(
-			"Error occured while cofiguring CIS Zone Rate Limit service: %s",
-			session.cisRLErr)
-	}
-
-	// IBM Network CIS Page Rules
-	cisPageRuleOpt := &cispagerulev1.PageRuleApiV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisPageRuleClient, session.cisPageRuleErr = cispagerulev1.NewPageRuleApiV1(cisPageRuleOpt)
-	if session.cisPageRuleErr != nil {
-		session.cisPageRuleErr = fmt.Errorf(
-			"Error occured while cofiguring CIS Page Rule service: %s",
-			session.cisPageRuleErr)
-	}
-
-	// IBM Network CIS Edge Function
-	cisEdgeFunctionOpt := &cisedgefunctionv1.EdgeFunctionsApiV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisEdgeFunctionClient, session.cisEdgeFunctionErr =
-		cisedgefunctionv1.NewEdgeFunctionsApiV1(cisEdgeFunctionOpt)
-	if session.cisEdgeFunctionErr != nil {
-		session.cisEdgeFunctionErr =
-			fmt.Errorf("Error occured while configuring CIS Edge Function service: %s",
-				session.cisEdgeFunctionErr)
-	}
-
-	// IBM Network CIS SSL certificate
-	cisSSLOpt := &cissslv1.SslCertificateApiV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-
-	session.cisSSLClient, session.cisSSLErr = cissslv1.NewSslCertificateApiV1(cisSSLOpt)
-	if session.cisSSLErr != nil {
-		session.cisSSLErr =
-			fmt.Errorf("Error occured while configuring CIS SSL certificate service: %s",
-				session.cisSSLErr)
-	}
-
-	// IBM Network CIS WAF Package
-	cisWAFPackageOpt := &ciswafpackagev1.WafRulePackagesApiV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisWAFPackageClient, session.cisWAFPackageErr =
-		ciswafpackagev1.NewWafRulePackagesApiV1(cisWAFPackageOpt)
-	if session.cisWAFPackageErr != nil {
-		session.cisWAFPackageErr =
-			fmt.Errorf("Error occured while configuration CIS WAF Package service: %s",
-				session.cisWAFPackageErr)
-	}
-
-	// IBM Network CIS Domain settings
-	cisDomainSettingsOpt := &cisdomainsettingsv1.ZonesSettingsV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisDomainSettingsClient, session.cisDomainSettingsErr =
-		cisdomainsettingsv1.NewZonesSettingsV1(cisDomainSettingsOpt)
-	if session.cisDomainSettingsErr != nil {
-		session.cisDomainSettingsErr =
-			fmt.Errorf("Error occured while configuring CIS Domain Settings service: %s",
-				session.cisDomainSettingsErr)
-	}
-
-	// IBM Network CIS Routing
-	cisRoutingOpt := &cisroutingv1.RoutingV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisRoutingClient, session.cisRoutingErr =
-		cisroutingv1.NewRoutingV1(cisRoutingOpt)
-	if session.cisRoutingErr != nil {
-		session.cisRoutingErr =
-			fmt.Errorf("Error occured while configuring CIS Routing service: %s",
-				session.cisRoutingErr)
-	}
-
-	// IBM Network CIS WAF Group
-	cisWAFGroupOpt := &ciswafgroupv1.WafRuleGroupsApiV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisWAFGroupClient, session.cisWAFGroupErr =
-		ciswafgroupv1.NewWafRuleGroupsApiV1(cisWAFGroupOpt)
-	if session.cisWAFGroupErr != nil {
-		session.cisWAFGroupErr =
-			fmt.Errorf("Error occured while configuring CIS WAF Group service: %s",
-				session.cisWAFGroupErr)
-	}
-
-	// IBM Network CIS Cache service
-	cisCacheOpt := &ciscachev1.CachingApiV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisCacheClient, session.cisCacheErr =
-		ciscachev1.NewCachingApiV1(cisCacheOpt)
-	if session.cisCacheErr != nil {
-		session.cisCacheErr =
-			fmt.Errorf("Error occured while configuring CIS Caching service: %s",
-				session.cisCacheErr)
-	}
-
-	// IBM Network CIS Custom pages service
-	cisCustomPageOpt := &ciscustompagev1.CustomPagesV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-
-	session.cisCustomPageClient, session.cisCustomPageErr =
-		ciscustompagev1.NewCustomPagesV1(cisCustomPageOpt)
-	if session.cisCustomPageErr != nil {
-		session.cisCustomPageErr =
-			fmt.Errorf("Error occured while configuring CIS Custom Pages service: %s",
-				session.cisCustomPageErr)
-	}
-
-	// IBM Network CIS Firewall Access rule
-	cisAccessRuleOpt := &cisaccessrulev1.ZoneFirewallAccessRulesV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisAccessRuleClient, session.cisAccessRuleErr =
-		cisaccessrulev1.NewZoneFirewallAccessRulesV1(cisAccessRuleOpt)
-	if session.cisAccessRuleErr != nil {
-		session.cisAccessRuleErr =
-			fmt.Errorf("Error occured while configuring CIS Firewall Access Rule service: %s",
-				session.cisAccessRuleErr)
-	}
-
-	// IBM Network CIS Firewall User Agent Blocking rule
-	cisUARuleOpt := &cisuarulev1.UserAgentBlockingRulesV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisUARuleClient, session.cisUARuleErr =
-		cisuarulev1.NewUserAgentBlockingRulesV1(cisUARuleOpt)
-	if session.cisUARuleErr != nil {
-		session.cisUARuleErr =
-			fmt.Errorf("Error occured while configuring CIS Firewall User Agent Blocking Rule service: %s",
-				session.cisUARuleErr)
-	}
-
-	// IBM Network CIS Firewall Lockdown rule
-	cisLockdownOpt := &cislockdownv1.ZoneLockdownV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisLockdownClient, session.cisLockdownErr =
-		cislockdownv1.NewZoneLockdownV1(cisLockdownOpt)
-	if session.cisLockdownErr != nil {
-		session.cisLockdownErr =
-			fmt.Errorf("Error occured while configuring CIS Firewall Lockdown Rule service: %s",
-				session.cisLockdownErr)
-	}
-
-	// IBM Network CIS Range Application rule
-	cisRangeAppOpt := &cisrangeappv1.RangeApplicationsV1Options{
-		URL:            cisEndPoint,
-		Crn:            core.StringPtr(""),
-		ZoneIdentifier: core.StringPtr(""),
-		Authenticator:  authenticator,
-	}
-	session.cisRangeAppClient, session.cisRangeAppErr =
-		cisrangeappv1.NewRangeApplicationsV1(cisRangeAppOpt)
-	if session.cisRangeAppErr != nil {
-		session.cisRangeAppErr =
-			fmt.Errorf("Error occured while configuring CIS Range Application rule service: %s",
-				session.cisRangeAppErr)
-	}
-
-	// IBM Network CIS WAF Rule Service
-	cisWAFRuleOpt := &ciswafrulev1.WafRulesApiV1Options{
-		URL:           cisEndPoint,
-		Crn:           core.StringPtr(""),
-		ZoneID:        core.StringPtr(""),
-		Authenticator: authenticator,
-	}
-	session.cisWAFRuleClient, session.cisWAFRuleErr =
-		ciswafrulev1.NewWafRulesApiV1(cisWAFRuleOpt)
-	if session.cisWAFRuleErr != nil {
-		session.cisWAFRuleErr = fmt.Errorf(
-			"Error occured while configuring CIS WAF Rules service: %s",
-			session.cisWAFRuleErr)
-	}
-	// iamIdenityURL := fmt.Sprintf("https://%s.iam.cloud.ibm.com/v1", c.Region)
-	iamIdentityOptions := &iamidentity.IamIdentityV1Options{
-		Authenticator: authenticator,
-		URL:           envFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, "https://iam.cloud.ibm.com"),
-	}
-	iamIdentityClient, err := iamidentity.NewIamIdentityV1(iamIdentityOptions)
-	if err != nil {
-		session.vpcErr = fmt.Errorf("Error occured while configuring IAM Identity service: %q", err)
-	}
-	session.iamIdentityAPI = iamIdentityClient
-
 	return session, nil
 }
 
@@ -1578,7 +1998,44 @@ func CreateVersionDate() *string {
 }
 
 func newSession(c *Config) (*Session, error) {
-	ibmSession := &Session{}
+	transport, err := newHTTPTransport(c)
+	if err != nil {
+		return nil, err
+	}
+	if (c.IAMToken != "" && c.IAMRefreshToken == "") || (c.IAMToken == "" && c.IAMRefreshToken != "") {
+		return nil, fmt.Errorf("iam_token and iam_refresh_token must be provided")
+	}
+	trustedProfile := c.IAMProfileID != "" || c.IAMProfileName != ""
+	if trustedProfile && (c.BluemixAPIKey != "" || c.IAMToken != "") {
+		return nil, fmt.Errorf("iam_profile_id and iam_profile_name cannot be combined with ibmcloud_api_key or iam_token")
+	}
+	// Every client gets its IAM tokens from one source, which refreshes them
+	// before they expire
+	var tokenSource *iamTokenSource
+	if c.BluemixAPIKey != "" || c.IAMToken != "" || trustedProfile {
+		tokenSource = newIAMTokenSource(c, &gohttp.Client{Transport: transport, Timeout: c.BluemixTimeout})
+		transport = &iamTokenTransport{next: transport, source: tokenSource}
+	}
+	ibmSession := &Session{
+		HTTPClient: &gohttp.Client{
+			Transport: transport,
+			Timeout:   c.BluemixTimeout,
+		},
+		RateLimiters:   map[string]*tokenBucket{},
+		IAMTokenSource: tokenSource,
+		RetryHTTPClient: &gohttp.Client{
+			Transport: &retryTransport{
+				next:        transport,
+				maxAttempts: c.RetryMaxAttempts,
+				minBackoff:  c.RetryMinBackoff,
+				maxBackoff:  c.RetryMaxBackoff,
+				timeout:     c.BluemixTimeout,
+			},
+		},
+	}
+	for service, rate := range c.RateLimits {
+		ibmSession.RateLimiters[service] = newTokenBucket(rate)
+	}
 
 	softlayerSession := &slsession.Session{
 		Endpoint:  c.SoftLayerEndpointURL,
@@ -1588,6 +2045,8 @@ func newSession(c *Config) (*Session, error) {
 		Debug:     os.Getenv("TF_LOG") != "",
 		Retries:   c.RetryCount,
 		RetryWait: c.RetryDelay,
+		// The SoftLayer session sets its own timeout on the client
+		HTTPClient: &gohttp.Client{Transport: transport},
 	}
 
 	if c.IAMToken != "" {
@@ -1603,10 +2062,6 @@ func newSession(c *Config) (*Session, error) {
 	softlayerSession.AppendUserAgent(fmt.Sprintf("terraform-provider-ibm/%s", version.Version))
 	ibmSession.SoftLayerSession = softlayerSession
 
-	if (c.IAMToken != "" && c.IAMRefreshToken == "") || (c.IAMToken == "" && c.IAMRefreshToken != "") {
-		return nil, fmt.Errorf("iam_token and iam_refresh_token must be provided")
-	}
-
 	if c.IAMToken != "" && c.IAMRefreshToken != "" {
 		log.Println("Configuring IBM Cloud Session with token")
 		var sess *bxsession.Session
@@ -1620,6 +2075,10 @@ func newSession(c *Config) (*Session, error) {
 			ResourceGroup: c.ResourceGroup,
 			RetryDelay:    &c.RetryDelay,
 			MaxRetries:    &c.RetryCount,
+			HTTPClient:    ibmSession.HTTPClient,
+		}
+		if iamURL := c.bluemixEndpoint("iam"); iamURL != "" {
+			bmxConfig.TokenProviderEndpoint = &iamURL
 		}
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
@@ -1628,6 +2087,31 @@ func newSession(c *Config) (*Session, error) {
 		ibmSession.BluemixSession = sess
 	}
 
+	if trustedProfile {
+		log.Println("Configuring IBM Cloud Session with trusted profile")
+		bmxConfig := &bluemix.Config{
+			HTTPTimeout:   c.BluemixTimeout,
+			Region:        c.Region,
+			ResourceGroup: c.ResourceGroup,
+			RetryDelay:    &c.RetryDelay,
+			MaxRetries:    &c.RetryCount,
+			HTTPClient:    ibmSession.HTTPClient,
+		}
+		if iamURL := c.bluemixEndpoint("iam"); iamURL != "" {
+			bmxConfig.TokenProviderEndpoint = &iamURL
+		}
+		sess, err := bxsession.New(bmxConfig)
+		if err != nil {
+			return nil, err
+		}
+		// bxsession.New falls back to the credentials of the environment, the
+		// trusted profile replaces them
+		sess.Config.BluemixAPIKey = ""
+		sess.Config.IAMAccessToken = ""
+		sess.Config.IAMRefreshToken = ""
+		ibmSession.BluemixSession = sess
+	}
+
 	if c.BluemixAPIKey != "" {
 		log.Println("Configuring IBM Cloud Session with API key")
 		var sess *bxsession.Session
@@ -1640,8 +2124,12 @@ func newSession(c *Config) (*Session, error) {
 			ResourceGroup: c.ResourceGroup,
 			RetryDelay:    &c.RetryDelay,
 			MaxRetries:    &c.RetryCount,
+			HTTPClient:    ibmSession.HTTPClient,
 			//PowerServiceInstance: c.PowerServiceInstance,
 		}
+		if iamURL := c.bluemixEndpoint("iam"); iamURL != "" {
+			bmxConfig.TokenProviderEndpoint = &iamURL
+		}
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
 			return nil, err
@@ -1652,25 +2140,13 @@ func newSession(c *Config) (*Session, error) {
 	return ibmSession, nil
 }
 
-func authenticateAPIKey(sess *bxsession.Session) error {
-	config := sess.Config
-	tokenRefresher, err := authentication.NewIAMAuthRepository(config, &rest.Client{
-		DefaultHeader: gohttp.Header{
-			"User-Agent": []string{http.UserAgent()},
-		},
-	})
-	if err != nil {
-		return err
-	}
-	return tokenRefresher.AuthenticateAPIKey(config.BluemixAPIKey)
-}
-
 func authenticateCF(sess *bxsession.Session) error {
 	config := sess.Config
 	tokenRefresher, err := authentication.NewUAARepository(config, &rest.Client{
 		DefaultHeader: gohttp.Header{
 			"User-Agent": []string{http.UserAgent()},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
@@ -1714,24 +2190,10 @@ func fetchUserDetails(sess *bxsession.Session, generation int) (*UserConfig, err
 	return &user, nil
 }
 
-func refreshToken(sess *bxsession.Session) error {
-	config := sess.Config
-	tokenRefresher, err := authentication.NewIAMAuthRepository(config, &rest.Client{
-		DefaultHeader: gohttp.Header{
-			"User-Agent": []string{http.UserAgent()},
-		},
-	})
-	if err != nil {
-		return err
-	}
-	_, err = tokenRefresher.RefreshToken()
-	return err
-}
-
 func envFallBack(envs []string, defaultValue string) string {
 	for _, k := range envs {
 		if v := os.Getenv(k); v != "" {
-			if strings.Contains(v, "https://") {
+			if strings.Contains(v, "https://") || strings.Contains(v, "http://") {
 				return v
 			} else {
 				return fmt.Sprintf("https://%s/v1", v)
@@ -1741,15 +2203,152 @@ func envFallBack(envs []string, defaultValue string) string {
 	return defaultValue
 }
 
-// DefaultTransport ...
-func DefaultTransport() gohttp.RoundTripper {
-	transport := &gohttp.Transport{
-		Proxy:               gohttp.ProxyFromEnvironment,
-		DisableKeepAlives:   true,
-		MaxIdleConnsPerHost: -1,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: false,
-		},
+// serviceEndpointEnvs lists the services whose URL can be overridden through the
+// provider endpoints block or endpoints_file_path, together with the environment
+// variables that are honoured when no override is configured.
+var serviceEndpointEnvs = map[string][]string{
+	"account":             {"IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT"},
+	"api_gateway":         {"IBMCLOUD_API_GATEWAY_ENDPOINT"},
+	"certificate_manager": {"IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT"},
+	"cis":                 {"IBMCLOUD_CIS_API_ENDPOINT"},
+	"container":           {"IBMCLOUD_CS_API_ENDPOINT"},
+	"container_registry":  {"IBMCLOUD_CR_API_ENDPOINT"},
+	"cos_config":          {"IBMCLOUD_COS_CONFIG_ENDPOINT"},
+	"directlink":          {"IBMCLOUD_DL_API_ENDPOINT"},
+	"directlink_provider": {"IBMCLOUD_DL_PROVIDER_API_ENDPOINT"},
+	"functions_namespace": {"IBMCLOUD_NAMESPACE_API_ENDPOINT"},
+	"global_search":       {"IBMCLOUD_GS_API_ENDPOINT"},
+	"global_tagging":      {"IBMCLOUD_GT_API_ENDPOINT"},
+	"hpcs":                {"IBMCLOUD_HPCS_API_ENDPOINT"},
+	"iam":                 {"IBMCLOUD_IAM_API_ENDPOINT"},
+	"icd":                 {"IBMCLOUD_ICD_API_ENDPOINT"},
+	"kms":                 {"IBMCLOUD_KP_API_ENDPOINT"},
+	"mccp":                {"IBMCLOUD_MCCP_API_ENDPOINT"},
+	"private_dns":         {"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT"},
+	"resource_catalog":    {"IBMCLOUD_RESOURCE_CATALOG_API_ENDPOINT"},
+	"resource_controller": {"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT"},
+	"resource_manager":    {"IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT"},
+	"schematics":          {"IBMCLOUD_SCHEMATICS_API_ENDPOINT"},
+	"tg":                  {"IBMCLOUD_TG_API_ENDPOINT"},
+	"user_management":     {"IBMCLOUD_USER_MANAGEMENT_ENDPOINT"},
+	"vpc":                 {"IBMCLOUD_IS_NG_API_ENDPOINT"},
+	"vpc_classic":         {"IBMCLOUD_IS_API_ENDPOINT"},
+}
+
+// privateServiceEndpoints lists the services reachable through a private
+// endpoint. {region} is replaced with the configured region.
+var privateServiceEndpoints = map[string]string{
+	"container":           "https://private.{region}.containers.cloud.ibm.com/global",
+	"cos_config":          "https://config.private.cloud-object-storage.cloud.ibm.com/v1",
+	"directlink":          "https://private.directlink.cloud.ibm.com/v1",
+	"directlink_provider": "https://private.directlink.cloud.ibm.com/provider/v2",
+	"global_search":       "https://api.private.global-search-tagging.cloud.ibm.com",
+	"global_tagging":      "https://tags.private.global-search-tagging.cloud.ibm.com",
+	"iam":                 "https://private.iam.cloud.ibm.com",
+	"kms":                 "https://private.{region}.kms.cloud.ibm.com",
+	"private_dns":         "https://api.private.dns-svcs.cloud.ibm.com/v1",
+	"resource_controller": "https://private.resource-controller.cloud.ibm.com",
+	"resource_manager":    "https://private.resource-controller.cloud.ibm.com",
+	"tg":                  "https://private.transit.cloud.ibm.com/v1",
+	"vpc":                 "https://{region}.private.iaas.cloud.ibm.com/v1",
+	"vpc_classic":         "https://{region}.private.iaas.cloud.ibm.com/v1",
+}
+
+// privateEndpoint returns the private URL of service when the configured
+// visibility asks for one and the service offers it.
+func (c *Config) privateEndpoint(service string) string {
+	if c.Visibility != "private" && c.Visibility != "public-and-private" {
+		return ""
+	}
+	return strings.Replace(privateServiceEndpoints[service], "{region}", c.Region, -1)
+}
+
+// privateEndpointErr reports a service that cannot be reached when visibility
+// is private, i.e. one without a private endpoint or an explicit override.
+func (c *Config) privateEndpointErr(service string) error {
+	if c.Visibility != "private" || c.Endpoints[service] != "" {
+		return nil
+	}
+	if _, ok := privateServiceEndpoints[service]; ok {
+		return nil
+	}
+	if _, ok := serviceEndpointEnvs[service]; !ok {
+		// The URL of the service can't be overridden, e.g. functions and power
+		// build it from the region
+		return fmt.Errorf("The %s service does not support private endpoints, set visibility to \"public\" or \"public-and-private\"", service)
+	}
+	return fmt.Errorf("The %s service does not support private endpoints, set visibility to \"public\" or \"public-and-private\" or configure its URL in the endpoints block", service)
+}
+
+// serviceEndpoint returns the URL of service. An override from the provider
+// configuration wins over the environment, which wins over the private
+// endpoint selected by visibility and finally over defaultValue.
+func (c *Config) serviceEndpoint(service, defaultValue string) string {
+	if ep := c.Endpoints[service]; ep != "" {
+		return ep
+	}
+	if private := c.privateEndpoint(service); private != "" {
+		defaultValue = private
+	}
+	return envFallBack(serviceEndpointEnvs[service], defaultValue)
+}
+
+// bluemixEndpoint returns the URL bluemix-go must use for service, or an empty
+// string when bluemix-go should resolve it from the environment or its defaults.
+func (c *Config) bluemixEndpoint(service string) string {
+	if ep := c.Endpoints[service]; ep != "" {
+		return ep
+	}
+	for _, env := range serviceEndpointEnvs[service] {
+		if os.Getenv(env) != "" {
+			return ""
+		}
+	}
+	return c.privateEndpoint(service)
+}
+
+// bluemixServiceSession returns the session used to build the bluemix-go client
+// of service.
+func (c *Config) bluemixServiceSession(sess *bxsession.Session, service string) *bxsession.Session {
+	if ep := c.bluemixEndpoint(service); ep != "" {
+		return sess.Copy(&bluemix.Config{Endpoint: &ep})
+	}
+	return sess
+}
+
+// loadEndpointsFile reads a JSON object mapping service names to URLs.
+func loadEndpointsFile(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading endpoints file %s: %s", path, err)
+	}
+	endpoints := map[string]string{}
+	if err := json.Unmarshal(content, &endpoints); err != nil {
+		return nil, fmt.Errorf("Error parsing endpoints file %s: %s", path, err)
+	}
+	for service, url := range endpoints {
+		if _, ok := serviceEndpointEnvs[service]; !ok {
+			return nil, fmt.Errorf("Error parsing endpoints file %s: unsupported service %q, supported services are %s", path, service, strings.Join(supportedEndpointServices(), ", "))
+		}
+		if err := validateEndpointURL(url); err != nil {
+			return nil, fmt.Errorf("Error parsing endpoints file %s: %s: %s", path, service, err)
+		}
+	}
+	return endpoints, nil
+}
+
+func validateEndpointURL(url string) error {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return fmt.Errorf("%q must be an absolute http:// or https:// URL", url)
+	}
+	return nil
+}
+
+func supportedEndpointServices() []string {
+	services := make([]string, 0, len(serviceEndpointEnvs))
+	for service := range serviceEndpointEnvs {
+		services = append(services, service)
 	}
-	return transport
+	sort.Strings(services)
+	return services
 }
@@ -0,0 +1,229 @@
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	gohttp "net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const crTokenGrantType = "urn:ibm:params:oauth:grant-type:cr-token"
+
+// defaultCRTokenFiles are the service account tokens projected into IKS and
+// OpenShift pods. Container authentication reads the first one that exists
+// when cr_token_file_path is not set.
+var defaultCRTokenFiles = []string{
+	"/var/run/secrets/tokens/vault-token",
+	"/var/run/secrets/tokens/sa-token",
+}
+
+// iamTokenSource is the single place the provider gets IAM access tokens from.
+// It exchanges the API key, the refresh token or, for a trusted profile, the
+// compute resource token, and exchanges it again once 80% of the lifetime of
+// the access token has passed. The compute resource token file is read anew
+// for every exchange because the platform rotates it.
+type iamTokenSource struct {
+	client      *gohttp.Client
+	endpoint    string
+	apiKey      string
+	profileID   string
+	profileName string
+	crTokenFile string
+
+	mu           sync.Mutex
+	token        string
+	refreshToken string
+	refresh      time.Time
+	issued       map[string]bool
+}
+
+func newIAMTokenSource(c *Config, client *gohttp.Client) *iamTokenSource {
+	return &iamTokenSource{
+		client:       client,
+		endpoint:     c.serviceEndpoint("iam", "https://iam.cloud.ibm.com") + "/identity/token",
+		apiKey:       c.BluemixAPIKey,
+		profileID:    c.IAMProfileID,
+		profileName:  c.IAMProfileName,
+		crTokenFile:  c.CRTokenFile,
+		refreshToken: c.IAMRefreshToken,
+		issued:       map[string]bool{},
+	}
+}
+
+// trustedProfile reports whether s authenticates as a trusted profile.
+func (s *iamTokenSource) trustedProfile() bool {
+	return s.profileID != "" || s.profileName != ""
+}
+
+// Token returns a valid IAM access token, without the "Bearer " prefix.
+func (s *iamTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Before(s.refresh) {
+		return s.token, nil
+	}
+
+	form, err := s.grant()
+	if err != nil {
+		return "", err
+	}
+	req, err := gohttp.NewRequest(gohttp.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !s.trustedProfile() {
+		// The client ID bluemix-go and the CLI use, refresh tokens are bound to it
+		req.SetBasicAuth("bx", "bx")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error fetching an IAM token: %s", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		ErrorCode    string `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != gohttp.StatusOK {
+		return "", fmt.Errorf("Error fetching an IAM token: %s %s %s", resp.Status, body.ErrorCode, body.ErrorMessage)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error fetching an IAM token: %s", err)
+	}
+
+	s.token = body.AccessToken
+	if !s.trustedProfile() {
+		s.refreshToken = body.RefreshToken
+	}
+	s.refresh = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second * 8 / 10)
+	s.issued[s.token] = true
+	return s.token, nil
+}
+
+// RefreshToken returns the refresh token of the last exchange. Trusted
+// profiles have none.
+func (s *iamTokenSource) RefreshToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refreshToken
+}
+
+// grant returns the form of the next token request.
+func (s *iamTokenSource) grant() (url.Values, error) {
+	switch {
+	case s.trustedProfile():
+		crToken, err := s.readCRToken()
+		if err != nil {
+			return nil, err
+		}
+		form := url.Values{
+			"grant_type": {crTokenGrantType},
+			"cr_token":   {crToken},
+		}
+		if s.profileID != "" {
+			form.Set("profile_id", s.profileID)
+		}
+		if s.profileName != "" {
+			form.Set("profile_name", s.profileName)
+		}
+		return form, nil
+	case s.apiKey != "":
+		return url.Values{
+			"grant_type":    {"urn:ibm:params:oauth:grant-type:apikey"},
+			"apikey":        {s.apiKey},
+			"response_type": {"cloud_iam"},
+		}, nil
+	default:
+		return url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {s.refreshToken},
+			"response_type": {"cloud_iam"},
+		}, nil
+	}
+}
+
+// issuedToken reports whether token was handed out by s, current or not.
+func (s *iamTokenSource) issuedToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issued[token]
+}
+
+func (s *iamTokenSource) readCRToken() (string, error) {
+	files := defaultCRTokenFiles
+	if s.crTokenFile != "" {
+		files = []string{s.crTokenFile}
+	}
+	for _, file := range files {
+		token, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) && s.crTokenFile == "" {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("Error reading the compute resource token %s: %s", file, err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	return "", fmt.Errorf("Error reading the compute resource token: none of %s exists, set cr_token_file_path", strings.Join(files, ", "))
+}
+
+// iamTokenAuthenticator authenticates the requests of the go SDK clients with
+// the current token of source.
+type iamTokenAuthenticator struct {
+	source *iamTokenSource
+}
+
+func (a *iamTokenAuthenticator) AuthenticationType() string {
+	return "bearerToken"
+}
+
+func (a *iamTokenAuthenticator) Authenticate(req *gohttp.Request) error {
+	token, err := a.source.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *iamTokenAuthenticator) Validate() error {
+	return nil
+}
+
+// iamTokenTransport keeps the IAM token of the requests sent by the provider
+// current. The bluemix-go, Key Protect, Power and SoftLayer clients copy the
+// token when they are built, so a request carrying a token issued by source
+// gets the latest token of source instead.
+type iamTokenTransport struct {
+	next   gohttp.RoundTripper
+	source *iamTokenSource
+}
+
+func (t *iamTokenTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	auth := req.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if token == auth || !t.source.issuedToken(token) {
+		return t.next.RoundTrip(req)
+	}
+	current, err := t.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	if current != token {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+current)
+	}
+	return t.next.RoundTrip(req)
+}
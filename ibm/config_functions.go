@@ -20,7 +20,11 @@ func FunctionClient(c *bluemix.Config) (*whisk.Client, error) {
 	baseEndpoint := getBaseURL(c.Region)
 	u, _ := url.Parse(fmt.Sprintf("%s/api", baseEndpoint))
 
-	functionsClient, err := whisk.NewClient(http.DefaultClient, &whisk.Config{
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	functionsClient, err := whisk.NewClient(httpClient, &whisk.Config{
 		Host:    u.Host,
 		Version: "v1",
 	})
@@ -0,0 +1,348 @@
+package ibm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	gohttp "net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedHeaders are replaced in traced requests and responses so that
+// credentials never reach the logs.
+var redactedHeaders = []string{"Authorization", "X-Auth-Refresh-Token", "X-Auth-User-Token"}
+
+// newHTTPTransport returns the transport shared by every client of the
+// provider. Connections are kept alive so large applies reuse them instead of
+// doing a TLS handshake per request.
+func newHTTPTransport(c *Config) (gohttp.RoundTripper, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CABundleFile != "" {
+		pool, err := loadCABundle(c.CABundleFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	var transport gohttp.RoundTripper = &gohttp.Transport{
+		Proxy: gohttp.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   20 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+	if c.HTTPTrace != nil {
+		transport = &tracingTransport{next: transport, trace: c.HTTPTrace}
+	}
+	return transport, nil
+}
+
+// loadCABundle returns the system cert pool extended with the PEM encoded
+// certificates of path, e.g. the CA of a corporate proxy.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading ca_bundle_file %s: %s", path, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("Error reading ca_bundle_file %s: no PEM encoded certificate found", path)
+	}
+	return pool, nil
+}
+
+// tracingTransport passes a dump of every request and response to trace.
+// Credential headers are redacted, and so are the bodies of token exchanges,
+// which carry API keys and tokens.
+type tracingTransport struct {
+	next  gohttp.RoundTripper
+	trace func(string)
+}
+
+func (t *tracingTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	sensitive := strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+
+	var body []byte
+	if req.Body != nil && req.Body != gohttp.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	var dump bytes.Buffer
+	fmt.Fprintf(&dump, "%s %s %s\nHost: %s\n", req.Method, req.URL.RequestURI(), req.Proto, req.URL.Host)
+	redactHeader(req.Header).Write(&dump)
+	t.trace(fmt.Sprintf("HTTP request:\n%s\n%s", dump.String(), tracedBody(body, sensitive)))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.trace(fmt.Sprintf("HTTP request to %s failed: %s", req.URL.Host, err))
+		return resp, err
+	}
+
+	header := resp.Header
+	resp.Header = redactHeader(header)
+	respDump, err := httputil.DumpResponse(resp, !sensitive)
+	resp.Header = header
+	if err != nil {
+		return nil, err
+	}
+	if sensitive {
+		respDump = append(respDump, "REDACTED\n"...)
+	}
+	t.trace(fmt.Sprintf("HTTP response:\n%s", respDump))
+	return resp, nil
+}
+
+func redactHeader(header gohttp.Header) gohttp.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+func tracedBody(body []byte, sensitive bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if sensitive {
+		return "REDACTED\n"
+	}
+	return string(body) + "\n"
+}
+
+// retryTransport retries requests that were rate limited or hit a transient
+// server error. It waits for the Retry-After the server asked for, or else
+// backs off exponentially with jitter between minBackoff and maxBackoff.
+// timeout bounds every attempt rather than the whole retried request.
+type retryTransport struct {
+	next        gohttp.RoundTripper
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	timeout     time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	if req.Body != nil && req.Body != gohttp.NoBody && req.GetBody == nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// Buffer the body on a copy, a RoundTripper must not modify req.
+		req = req.WithContext(req.Context())
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		resp, err := t.roundTrip(attemptReq)
+		if attempt >= t.maxAttempts || !retryableResponse(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s returned %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.Status, wait, attempt+1, t.maxAttempts)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxAttempts)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTrip sends one attempt, bounded by timeout until its body is closed.
+func (t *retryTransport) roundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns the wait before the attempt following attempt.
+func (t *retryTransport) backoff(attempt int, resp *gohttp.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+	backoff := t.minBackoff
+	for i := 1; i < attempt && backoff < t.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > t.maxBackoff {
+		backoff = t.maxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Equal jitter keeps at least half of the backoff while spreading out the
+	// clients that were throttled at the same time.
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// retryableResponse reports whether the request can be sent again. Rate
+// limiting and 503 mean the request was not processed, so any method is
+// retried. Other server and connection errors are retried for idempotent
+// methods only.
+func retryableResponse(req *gohttp.Request, resp *gohttp.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	idempotent := req.Method != gohttp.MethodPost && req.Method != gohttp.MethodPatch
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case gohttp.StatusTooManyRequests, gohttp.StatusServiceUnavailable:
+		return true
+	case gohttp.StatusInternalServerError, gohttp.StatusBadGateway, gohttp.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := gohttp.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// tokenBucket allows rate requests per second on average with bursts of up
+// to rate requests. One bucket is shared by every client of a service.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done. Tokens are reserved
+// in call order, so waiting requests are served first come, first served.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitTransport sends requests no faster than its bucket allows.
+type rateLimitTransport struct {
+	next    gohttp.RoundTripper
+	limiter *tokenBucket
+}
+
+func (t *rateLimitTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,65 @@
+package ibm
+
+import (
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceIBMCISDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMCISDNSZoneExportRead,
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisDNSZoneFileContent: {
+				Type:        schema.TypeString,
+				Description: "DNS records of the domain rendered as a BIND zone file",
+				Computed:    true,
+			},
+			cisDNSZoneFileZoneName: {
+				Type:        schema.TypeString,
+				Description: "Name of the CIS domain",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceIBMCISDNSZoneExportRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	zoneName, err := getCISZoneName(meta, crn, zoneID)
+	if err != nil {
+		return err
+	}
+	all, err := listAllCISDNSRecords(sess)
+	if err != nil {
+		return err
+	}
+	records := make([]cisDNSZoneRecord, 0, len(all))
+	for _, details := range all {
+		records = append(records, cisDNSZoneRecordFromDetails(details))
+	}
+
+	d.SetId(convertCisToTfTwoVar(zoneID, crn))
+	d.Set(cisDNSZoneFileZoneName, zoneName)
+	d.Set(cisDNSZoneFileContent, renderCISDNSZoneFile(zoneName, records))
+	return nil
+}
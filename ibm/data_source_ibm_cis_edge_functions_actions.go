@@ -42,6 +42,16 @@ func dataSourceIBMCISEdgeFunctionsActions() *schema.Resource {
 				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						cisEdgeFunctionsActionActionName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Edge function action script name",
+						},
+						cisEdgeFunctionsActionScriptSHA256: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SHA-256 of the deployed edge function action script",
+						},
 						cisEdgeFunctionsActionEtag: {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -109,13 +119,16 @@ func dataSourceIBMCISEdgeFunctionsActionsRead(d *schema.ResourceData, meta inter
 	cisClient.Crn = core.StringPtr(crn)
 	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
 
-	opt := cisClient.NewListEdgeFunctionsActionsOptions()
-	result, _, err := cisClient.ListEdgeFunctionsActions(opt)
+	names, scripts, _, err := listCISEdgeFunctionsActions(cisClient)
 	if err != nil {
 		return fmt.Errorf("Error: %v", err)
 	}
 	scriptInfo := make([]map[string]interface{}, 0)
-	for _, script := range result.Result {
+	for i, script := range scripts {
+		content, resp, err := getCISEdgeFunctionsActionScript(cisClient, names[i])
+		if err != nil {
+			return fmt.Errorf("Error getting edge functions action %s: %v\n%s", names[i], err, resp)
+		}
 		routes := make([]map[string]interface{}, 0)
 		for _, route := range script.Routes {
 			r := map[string]interface{}{
@@ -131,11 +144,13 @@ func dataSourceIBMCISEdgeFunctionsActionsRead(d *schema.ResourceData, meta inter
 			handlers = append(handlers, h)
 		}
 		l := map[string]interface{}{
-			cisEdgeFunctionsActionEtag:       *script.Etag,
-			cisEdgeFunctionsActionHandlers:   handlers,
-			cisEdgeFunctionsActionCreatedOn:  (*script.CreatedOn).String(),
-			cisEdgeFunctionsActionModifiedOn: (*script.ModifiedOn).String(),
-			cisEdgeFunctionsActionRoutes:     routes,
+			cisEdgeFunctionsActionActionName:   names[i],
+			cisEdgeFunctionsActionScriptSHA256: cisEdgeFunctionsScriptSHA256(content),
+			cisEdgeFunctionsActionEtag:         *script.Etag,
+			cisEdgeFunctionsActionHandlers:     handlers,
+			cisEdgeFunctionsActionCreatedOn:    (*script.CreatedOn).String(),
+			cisEdgeFunctionsActionModifiedOn:   (*script.ModifiedOn).String(),
+			cisEdgeFunctionsActionRoutes:       routes,
 		}
 		scriptInfo = append(scriptInfo, l)
 	}
@@ -17,6 +17,7 @@ func TestAccIBMCisEdgeFunctionsActionsDataSource_basic(t *testing.T) {
 				Config: testAccCheckIBMCisEdgeFunctionsActionsDataSourceConfig(),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(node, "cis_edge_functions_actions.0.etag"),
+					resource.TestCheckResourceAttrSet(node, "cis_edge_functions_actions.0.script_sha256"),
 				),
 			},
 		},
@@ -0,0 +1,100 @@
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	cisFilters = "filters"
+)
+
+func dataSourceIBMCISFilters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMCISFiltersRead,
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisFilters: {
+				Type:        schema.TypeList,
+				Description: "Collection of filters",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter id",
+						},
+						cisFilterID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter identifier",
+						},
+						cisFilterExpression: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter expression",
+						},
+						cisFilterPaused: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Filter is paused",
+						},
+						cisFilterDescription: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter description",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMCISFiltersRead(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	filters, response, err := listCISFilters(cisClient)
+	if err != nil {
+		return fmt.Errorf("Error listing filters: %s\n%s", err, response)
+	}
+	filterList := make([]map[string]interface{}, 0)
+	for _, filter := range filters {
+		l := map[string]interface{}{
+			"id":                convertCisToTfThreeVar(*filter.ID, zoneID, crn),
+			cisFilterID:         *filter.ID,
+			cisFilterExpression: *filter.Expression,
+		}
+		if filter.Paused != nil {
+			l[cisFilterPaused] = *filter.Paused
+		}
+		if filter.Description != nil {
+			l[cisFilterDescription] = *filter.Description
+		}
+		filterList = append(filterList, l)
+	}
+	d.SetId(convertCisToTfTwoVar(zoneID, crn))
+	d.Set(cisFilters, filterList)
+	return nil
+}
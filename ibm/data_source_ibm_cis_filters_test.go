@@ -0,0 +1,32 @@
+package ibm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccIBMCisFiltersDataSource_Basic(t *testing.T) {
+	node := "data.ibm_cis_filters.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisFiltersDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(node, "filters.0.filter_id"),
+					resource.TestCheckResourceAttrSet(node, "filters.0.expression"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisFiltersDataSourceConfig() string {
+	return testAccCheckIBMCisFilterConfigBasic(`http.request.uri.path contains \"/admin\"`, "admin pages") + `
+	data "ibm_cis_filters" "test" {
+		cis_id    = ibm_cis_filter.test.cis_id
+		domain_id = ibm_cis_filter.test.domain_id
+	}`
+}
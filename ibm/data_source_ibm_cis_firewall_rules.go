@@ -0,0 +1,131 @@
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	cisFirewallRules = "firewall_rules"
+)
+
+func dataSourceIBMCISFirewallRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMCISFirewallRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisFirewallRules: {
+				Type:        schema.TypeList,
+				Description: "Collection of firewall rules",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall rule id",
+						},
+						cisFirewallRuleID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall rule identifier",
+						},
+						cisFirewallRuleFilterID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter identifier",
+						},
+						cisFilterExpression: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Filter expression",
+						},
+						cisFirewallRuleAction: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall rule action",
+						},
+						cisFirewallRulePriority: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Firewall rule priority",
+						},
+						cisFirewallRulePaused: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Firewall rule is paused",
+						},
+						cisFirewallRuleDescription: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall rule description",
+						},
+						cisFirewallRuleProducts: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Security features that a bypass rule skips",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMCISFirewallRulesRead(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	rules, response, err := listCISFirewallRules(cisClient)
+	if err != nil {
+		return fmt.Errorf("Error listing firewall rules: %s\n%s", err, response)
+	}
+	ruleList := make([]map[string]interface{}, 0)
+	for _, rule := range rules {
+		l := map[string]interface{}{
+			"id":                    convertCisToTfThreeVar(*rule.ID, zoneID, crn),
+			cisFirewallRuleID:       *rule.ID,
+			cisFirewallRuleAction:   *rule.Action,
+			cisFirewallRuleProducts: rule.Products,
+		}
+		if rule.Filter != nil {
+			l[cisFirewallRuleFilterID] = *rule.Filter.ID
+			if rule.Filter.Expression != nil {
+				l[cisFilterExpression] = *rule.Filter.Expression
+			}
+		}
+		if rule.Priority != nil {
+			l[cisFirewallRulePriority] = *rule.Priority
+		}
+		if rule.Paused != nil {
+			l[cisFirewallRulePaused] = *rule.Paused
+		}
+		if rule.Description != nil {
+			l[cisFirewallRuleDescription] = *rule.Description
+		}
+		ruleList = append(ruleList, l)
+	}
+	d.SetId(convertCisToTfTwoVar(zoneID, crn))
+	d.Set(cisFirewallRules, ruleList)
+	return nil
+}
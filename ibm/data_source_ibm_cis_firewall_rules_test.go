@@ -0,0 +1,33 @@
+package ibm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccIBMCisFirewallRulesDataSource_Basic(t *testing.T) {
+	node := "data.ibm_cis_firewall_rules.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisFirewallRulesDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(node, "firewall_rules.0.firewall_rule_id"),
+					resource.TestCheckResourceAttrSet(node, "firewall_rules.0.filter_id"),
+					resource.TestCheckResourceAttrSet(node, "firewall_rules.0.action"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisFirewallRulesDataSourceConfig() string {
+	return testAccCheckIBMCisFirewallRuleConfigBasic("block", 10, "") + `
+	data "ibm_cis_firewall_rules" "test" {
+		cis_id    = ibm_cis_firewall_rule.test.cis_id
+		domain_id = ibm_cis_firewall_rule.test.domain_id
+	}`
+}
@@ -0,0 +1,97 @@
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	isSecurityGroupTargets    = "targets"
+	isSecurityGroupTargetHref = "href"
+)
+
+func dataSourceIBMISSecurityGroupTargets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMISSecurityGroupTargetsRead,
+
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupTargetSecurityGroup: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Security group identifier",
+			},
+
+			isSecurityGroupTargets: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of network interfaces, load balancers and endpoint gateways the security group is attached to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isSecurityGroupTargetID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the security group target",
+						},
+						isSecurityGroupTargetName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the security group target",
+						},
+						isSecurityGroupTargetCRN: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "CRN of the security group target",
+						},
+						isSecurityGroupTargetHref: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL of the security group target",
+						},
+						isSecurityGroupTargetResourceType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Resource type of the security group target: network_interface, load_balancer or endpoint_gateway",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMISSecurityGroupTargetsRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	sgID := d.Get(isSecurityGroupTargetSecurityGroup).(string)
+
+	targets, response, err := listSecurityGroupTargets(sess, sgID)
+	if err != nil {
+		return fmt.Errorf("Error fetching Targets for the Security Group (%s) : %s\n%s", sgID, err, response)
+	}
+
+	targetsInfo := make([]map[string]interface{}, 0)
+	for _, target := range targets {
+		l := map[string]interface{}{
+			isSecurityGroupTargetID: *target.ID,
+		}
+		if target.Name != nil {
+			l[isSecurityGroupTargetName] = *target.Name
+		}
+		if target.CRN != nil {
+			l[isSecurityGroupTargetCRN] = *target.CRN
+		}
+		if target.Href != nil {
+			l[isSecurityGroupTargetHref] = *target.Href
+		}
+		if target.ResourceType != nil {
+			l[isSecurityGroupTargetResourceType] = *target.ResourceType
+		}
+		targetsInfo = append(targetsInfo, l)
+	}
+	d.SetId(sgID)
+	d.Set(isSecurityGroupTargets, targetsInfo)
+	return nil
+}
@@ -0,0 +1,42 @@
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccIBMISSecurityGroupTargetsDatasource_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tfsgt-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tfsgt-subnet-%d", acctest.RandIntRange(10, 100))
+	lbname := fmt.Sprintf("tfsgt-lb-%d", acctest.RandIntRange(10, 100))
+	sgname := fmt.Sprintf("tfsgt-sg-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISSecurityGroupTargetsDataSourceConfig(vpcname, subnetname, ISZoneName, ISCIDR, lbname, sgname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ibm_is_security_group_targets.testacc_targets", "targets.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_is_security_group_targets.testacc_targets", "targets.0.name", lbname),
+					resource.TestCheckResourceAttr(
+						"data.ibm_is_security_group_targets.testacc_targets", "targets.0.resource_type", "load_balancer"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISSecurityGroupTargetsDataSourceConfig(vpcname, subnetname, zone, cidr, lbname, sgname string) string {
+	return testAccCheckIBMISSecurityGroupTargetConfig(vpcname, subnetname, zone, cidr, lbname, sgname) + `
+
+	data "ibm_is_security_group_targets" "testacc_targets" {
+		security_group = ibm_is_security_group_target.testacc_target.security_group
+	}`
+}
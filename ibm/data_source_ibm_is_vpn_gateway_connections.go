@@ -110,19 +110,38 @@ func dataSourceIBMISVPNGatewayConnections() *schema.Resource {
 							Computed:    true,
 							Optional:    true,
 							MinItems:    0,
-							Description: "The VPN tunnel configuration for this VPN gateway connection (in static route mode)",
+							Description: "The VPN tunnel configuration for this VPN gateway connection, one per VPN gateway member",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"address": {
 										Type:        schema.TypeString,
 										Computed:    true,
+										Deprecated:  "use public_ip instead",
 										Description: "The IP address of the VPN gateway member in which the tunnel resides",
 									},
 
+									isVPNGatewayConnectionTunnelPublicIP: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The public IP address of the VPN gateway member in which the tunnel resides",
+									},
+
+									isVPNGatewayConnectionTunnelRole: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The high availability role of the VPN gateway member",
+									},
+
+									isVPNGatewayConnectionTunnelMemberStatus: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The status of the VPN gateway member",
+									},
+
 									"status": {
 										Type:        schema.TypeString,
 										Computed:    true,
-										Description: "The status of the VPN Tunnel",
+										Description: "The status of the VPN Tunnel (in static route mode)",
 									},
 								},
 							},
@@ -162,6 +181,13 @@ func dataSourceIBMVPNGatewayConnectionsRead(d *schema.ResourceData, meta interfa
 	if err != nil {
 		return fmt.Errorf("Error reading list of VPN Gateway Connections:%s\n%s", err, detail)
 	}
+	vpngatewayIntf, detail, err := sess.GetVPNGateway(&vpcv1.GetVPNGatewayOptions{
+		ID: &vpngatewayID,
+	})
+	if err != nil {
+		return fmt.Errorf("Error Getting VPN Gateway : %s\n%s", err, detail)
+	}
+	members := vpngatewayIntf.(*vpcv1.VPNGateway).Members
 	vpngatewayconnections := make([]map[string]interface{}, 0)
 	for _, instance := range availableVPNGatewayConnections.Connections {
 		gatewayconnection := map[string]interface{}{}
@@ -191,23 +217,7 @@ func dataSourceIBMVPNGatewayConnectionsRead(d *schema.ResourceData, meta interfa
 		gatewayconnection[isVPNGatewayConnectionPeerAddress] = *data.PeerAddress
 		gatewayconnection[isVPNGatewayConnectionResourcetype] = *data.ResourceType
 		gatewayconnection[isVPNGatewayConnectionStatus] = *data.Status
-		//if data.Tunnels != nil {
-		if len(data.Tunnels) > 0 {
-			vpcTunnelsList := make([]map[string]interface{}, 0)
-			for _, vpcTunnel := range data.Tunnels {
-				currentTunnel := map[string]interface{}{}
-				if vpcTunnel.PublicIP != nil {
-					if vpcTunnel.PublicIP != nil {
-						currentTunnel["address"] = *vpcTunnel.PublicIP.Address
-					}
-					if vpcTunnel.Status != nil {
-						currentTunnel["status"] = *vpcTunnel.Status
-					}
-					vpcTunnelsList = append(vpcTunnelsList, currentTunnel)
-				}
-			}
-			gatewayconnection[isVPNGatewayConnectionTunnels] = vpcTunnelsList
-		}
+		gatewayconnection[isVPNGatewayConnectionTunnels] = flattenVPNGatewayConnectionTunnels(members, data.Tunnels)
 
 		vpngatewayconnections = append(vpngatewayconnections, gatewayconnection)
 	}
@@ -0,0 +1,525 @@
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+const (
+	mockAccountID = "mockaccount0000000000000000000000"
+	mockRegion    = "us-south"
+)
+
+// mockService describes one of the APIs served by the mock backend. Every
+// service is mounted under its own path prefix so that a single httptest
+// server can stand in for all of them at once.
+type mockService struct {
+	prefix string
+	// listKey returns the JSON key under which a collection is listed.
+	listKey func(collection string) string
+	// defaults fills in the server generated fields of a new object.
+	defaults func(m *mockBackend, collection, id string, obj map[string]interface{})
+}
+
+// mockBackend is an in-process stand-in for the IAM token endpoint, the
+// global tagging service, VPC, Transit Gateway, Direct Link and Resource
+// Controller APIs. It is wired in through the same IBMCLOUD_*_API_ENDPOINT
+// overrides that envFallBack and bluemix-go read, so resource.UnitTest cases
+// can run full CRUD cycles without credentials or network access.
+type mockBackend struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	seq     int
+	objects map[string]map[string]map[string]interface{}
+	tags    map[string][]string
+
+	// TokenRequests counts the calls made to the IAM token endpoint.
+	TokenRequests int
+	// CRTokens records the compute resource tokens exchanged for IAM tokens.
+	CRTokens []string
+	// TokenLifetime is the validity of the issued IAM tokens, an hour if unset.
+	TokenLifetime time.Duration
+}
+
+var mockServices = []mockService{
+	{
+		prefix:   "/vpc/v1",
+		listKey:  func(collection string) string { return collection },
+		defaults: mockVPCDefaults,
+	},
+	{
+		prefix:   "/transit/v1",
+		listKey:  func(collection string) string { return collection },
+		defaults: mockTransitGatewayDefaults,
+	},
+	{
+		prefix:   "/directlink/v1",
+		listKey:  func(collection string) string { return collection },
+		defaults: mockDirectLinkDefaults,
+	},
+	{
+		prefix:   "/rc/v2",
+		listKey:  func(collection string) string { return "resources" },
+		defaults: mockResourceControllerDefaults,
+	},
+}
+
+// newMockBackend starts the mock backend and points the provider at it for
+// the lifetime of the test. The previous environment is restored on cleanup.
+func newMockBackend(t *testing.T) *mockBackend {
+	m := &mockBackend{
+		objects: map[string]map[string]map[string]interface{}{},
+		tags:    map[string][]string{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/iam/identity/token", m.handleIAMToken)
+	mux.HandleFunc("/uaa/oauth/token", m.handleUAAToken)
+	mux.HandleFunc("/tagging/v3/tags", m.handleTags)
+	mux.HandleFunc("/tagging/v3/tags/", m.handleTags)
+	for _, svc := range mockServices {
+		svc := svc
+		mux.HandleFunc(svc.prefix+"/", func(w http.ResponseWriter, r *http.Request) {
+			m.handleCollection(svc, w, r)
+		})
+	}
+	m.server = httptest.NewServer(mux)
+
+	env := map[string]string{
+		"IC_API_KEY":                                "mock-api-key",
+		"IBMCLOUD_IAM_API_ENDPOINT":                 m.URL("/iam"),
+		"IBMCLOUD_UAA_ENDPOINT":                     m.URL("/uaa"),
+		"IBMCLOUD_GT_API_ENDPOINT":                  m.URL("/tagging"),
+		"IBMCLOUD_IS_API_ENDPOINT":                  m.URL("/vpc/v1"),
+		"IBMCLOUD_IS_NG_API_ENDPOINT":               m.URL("/vpc/v1"),
+		"IBMCLOUD_TG_API_ENDPOINT":                  m.URL("/transit/v1"),
+		"IBMCLOUD_DL_API_ENDPOINT":                  m.URL("/directlink/v1"),
+		"IBMCLOUD_DL_PROVIDER_API_ENDPOINT":         m.URL("/directlink/provider/v2"),
+		"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT": m.URL("/rc"),
+	}
+	for k, v := range env {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		k := k
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		})
+	}
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// URL returns the absolute URL of path on the mock backend.
+func (m *mockBackend) URL(path string) string {
+	return m.server.URL + path
+}
+
+// Put seeds an object into a collection, bypassing the service defaults.
+func (m *mockBackend) Put(collection, id string, obj map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.objects[collection] == nil {
+		m.objects[collection] = map[string]map[string]interface{}{}
+	}
+	m.objects[collection][id] = obj
+}
+
+// Get returns a copy of the stored object or nil when it does not exist.
+func (m *mockBackend) Get(collection, id string) map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	obj, ok := m.objects[collection][id]
+	if !ok {
+		return nil
+	}
+	cp := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		cp[k] = v
+	}
+	return cp
+}
+
+// Count returns the number of objects stored in a collection.
+func (m *mockBackend) Count(collection string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.objects[collection])
+}
+
+func (m *mockBackend) nextID(collection string) string {
+	m.seq++
+	return fmt.Sprintf("mock-%s-%04d", strings.TrimSuffix(collection, "s"), m.seq)
+}
+
+func (m *mockBackend) crn(service, resourceType, id string) string {
+	return fmt.Sprintf("crn:v1:bluemix:public:%s:%s:a/%s::%s:%s", service, mockRegion, mockAccountID, resourceType, id)
+}
+
+func (m *mockBackend) accessToken(iamID string, lifetime time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"id":      iamID,
+		"iam_id":  iamID,
+		"email":   "mock@example.com",
+		"account": map[string]interface{}{"bss": mockAccountID},
+		"iss":     "https://iam.cloud.ibm.com/identity",
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(lifetime).Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("mock"))
+}
+
+// handleIAMToken issues tokens for API keys, refresh tokens and compute
+// resource tokens. Like IAM, it issues no refresh token for trusted profiles.
+func (m *mockBackend) handleIAMToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	m.mu.Lock()
+	m.TokenRequests++
+	lifetime := m.TokenLifetime
+	if lifetime == 0 {
+		lifetime = time.Hour
+	}
+	iamID, refreshToken := "IBMid-mock", "mock-refresh-token"
+	if r.PostForm.Get("grant_type") == "urn:ibm:params:oauth:grant-type:cr-token" {
+		profile := r.PostForm.Get("profile_id") + r.PostForm.Get("profile_name")
+		if r.PostForm.Get("cr_token") == "" || profile == "" {
+			m.mu.Unlock()
+			mockJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode":    "BXNIM0109E",
+				"errorMessage": "cr_token and profile_id or profile_name are required",
+			})
+			return
+		}
+		m.CRTokens = append(m.CRTokens, r.PostForm.Get("cr_token"))
+		iamID, refreshToken = "iam-"+profile, "not_supported"
+	}
+	m.mu.Unlock()
+
+	token, err := m.accessToken(iamID, lifetime)
+	if err != nil {
+		mockError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  token,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(lifetime.Seconds()),
+		"expiration":    time.Now().Add(lifetime).Unix(),
+	})
+}
+
+// expiredToken reports whether r carries an IAM token of the mock that has
+// expired. Other tokens are accepted as they are.
+func (m *mockBackend) expiredToken(r *http.Request) bool {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	_, err := jwt.Parse(auth, func(token *jwt.Token) (interface{}, error) {
+		return []byte("mock"), nil
+	})
+	if verr, ok := err.(*jwt.ValidationError); ok {
+		return verr.Errors&jwt.ValidationErrorExpired != 0
+	}
+	return false
+}
+
+func (m *mockBackend) handleUAAToken(w http.ResponseWriter, r *http.Request) {
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  "mock-uaa-token",
+		"refresh_token": "mock-uaa-refresh-token",
+		"token_type":    "bearer",
+	})
+}
+
+func (m *mockBackend) handleTags(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet:
+		items := []map[string]string{}
+		for _, name := range m.tags[r.URL.Query().Get("attached_to")] {
+			items = append(items, map[string]string{"name": name})
+		}
+		mockJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+	case r.Method == http.MethodPost:
+		var body struct {
+			Resources []struct {
+				ResourceID string `json:"resource_id"`
+			} `json:"resources"`
+			TagNames []string `json:"tag_names"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			mockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		detach := strings.HasSuffix(r.URL.Path, "/detach")
+		for _, res := range body.Resources {
+			m.tags[res.ResourceID] = mockUpdateTags(m.tags[res.ResourceID], body.TagNames, detach)
+		}
+		mockJSON(w, http.StatusOK, map[string]interface{}{"results": []interface{}{}})
+	case r.Method == http.MethodDelete:
+		mockJSON(w, http.StatusOK, map[string]interface{}{"results": []interface{}{}})
+	default:
+		mockError(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+func mockUpdateTags(current, names []string, detach bool) []string {
+	set := map[string]bool{}
+	for _, t := range current {
+		set[t] = true
+	}
+	for _, t := range names {
+		set[t] = !detach
+	}
+	tags := []string{}
+	for t, ok := range set {
+		if ok {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// handleCollection serves the generic REST collections of a mock service:
+// GET/POST on /<collection> and GET/PATCH/PUT/DELETE on /<collection>/<id>.
+func (m *mockBackend) handleCollection(svc mockService, w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, svc.prefix), "/"), "/")
+	collection := parts[0]
+	id := ""
+	if len(parts) > 1 {
+		id = strings.Join(parts[1:], "/")
+	}
+	if m.expiredToken(r) {
+		mockError(w, http.StatusUnauthorized, "the IAM token has expired")
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.objects[collection] == nil {
+		m.objects[collection] = map[string]map[string]interface{}{}
+	}
+	store := m.objects[collection]
+
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := []interface{}{}
+			for _, obj := range store {
+				list = append(list, obj)
+			}
+			mockJSON(w, http.StatusOK, map[string]interface{}{
+				svc.listKey(collection): list,
+				"limit":                 len(list) + 1,
+				"total_count":           len(list),
+				"rows_count":            len(list),
+				"first":                 map[string]string{"href": m.URL(r.URL.Path)},
+			})
+		case http.MethodPost:
+			obj := map[string]interface{}{}
+			if err := mockDecode(r, &obj); err != nil {
+				mockError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			id := m.nextID(collection)
+			svc.defaults(m, collection, id, obj)
+			if v, ok := obj["id"].(string); ok {
+				id = v
+			}
+			store[id] = obj
+			mockJSON(w, http.StatusCreated, obj)
+		default:
+			mockError(w, http.StatusMethodNotAllowed, r.Method)
+		}
+		return
+	}
+
+	obj, ok := store[id]
+	if !ok {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", collection, id))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		mockJSON(w, http.StatusOK, obj)
+	case http.MethodPatch, http.MethodPut:
+		patch := map[string]interface{}{}
+		if err := mockDecode(r, &patch); err != nil {
+			mockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for k, v := range patch {
+			obj[k] = v
+		}
+		obj["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+		mockJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		delete(store, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		mockError(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+func mockVPCDefaults(m *mockBackend, collection, id string, obj map[string]interface{}) {
+	mockCommonDefaults(m, "is", strings.TrimSuffix(collection, "s"), id, obj)
+	obj["href"] = m.URL(fmt.Sprintf("/vpc/v1/%s/%s", collection, id))
+	obj["status"] = "available"
+	if collection != "vpcs" {
+		return
+	}
+	if _, ok := obj["classic_access"]; !ok {
+		obj["classic_access"] = false
+	}
+	obj["cse_source_ips"] = []interface{}{}
+	obj["default_network_acl"] = map[string]interface{}{
+		"id":   id + "-acl",
+		"name": "default-acl",
+		"crn":  m.crn("is", "network-acl", id+"-acl"),
+		"href": m.URL("/vpc/v1/network_acls/" + id + "-acl"),
+	}
+	obj["default_security_group"] = map[string]interface{}{
+		"id":   id + "-sg",
+		"name": "default-sg",
+		"crn":  m.crn("is", "security-group", id+"-sg"),
+		"href": m.URL("/vpc/v1/security_groups/" + id + "-sg"),
+	}
+}
+
+func mockTransitGatewayDefaults(m *mockBackend, collection, id string, obj map[string]interface{}) {
+	mockCommonDefaults(m, "transit", "gateway", id, obj)
+	obj["status"] = "available"
+}
+
+func mockDirectLinkDefaults(m *mockBackend, collection, id string, obj map[string]interface{}) {
+	mockCommonDefaults(m, "directlink", "dedicated", id, obj)
+	obj["operational_status"] = "provisioned"
+	obj["bgp_status"] = "active"
+	obj["link_status"] = "up"
+	obj["location_display_name"] = obj["location_name"]
+}
+
+func mockResourceControllerDefaults(m *mockBackend, collection, id string, obj map[string]interface{}) {
+	crn := m.crn("mock-service", "", id)
+	obj["id"] = crn
+	obj["guid"] = id
+	obj["crn"] = crn
+	obj["state"] = "active"
+	obj["account_id"] = mockAccountID
+	obj["created_at"] = time.Now().UTC().Format(time.RFC3339)
+}
+
+func mockCommonDefaults(m *mockBackend, service, resourceType, id string, obj map[string]interface{}) {
+	obj["id"] = id
+	obj["crn"] = m.crn(service, resourceType, id)
+	obj["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	rg, _ := obj["resource_group"].(map[string]interface{})
+	if rg == nil {
+		rg = map[string]interface{}{"id": "mock-default-resource-group"}
+	}
+	if _, ok := rg["name"]; !ok {
+		rg["name"] = "Default"
+	}
+	obj["resource_group"] = rg
+}
+
+func mockDecode(r *http.Request, v interface{}) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
+
+func mockJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func mockError(w http.ResponseWriter, status int, message string) {
+	mockJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{
+			{"code": http.StatusText(status), "message": message},
+		},
+		"message":     message,
+		"status_code": status,
+	})
+}
+
+func TestMockBackend_clientSession(t *testing.T) {
+	mock := newMockBackend(t)
+	config := Config{
+		BluemixAPIKey:  "mock-api-key",
+		Region:         mockRegion,
+		BluemixTimeout: 10 * time.Second,
+		RetryDelay:     time.Millisecond,
+		Generation:     2,
+	}
+	meta, err := config.ClientSession()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if mock.TokenRequests == 0 {
+		t.Fatal("expected the provider to authenticate against the mock IAM endpoint")
+	}
+	if userDetails.userAccount != mockAccountID {
+		t.Fatalf("expected account %s, got %s", mockAccountID, userDetails.userAccount)
+	}
+
+	crn := mock.crn("mock-service", "", "instance-1")
+	mock.Put("resource_instances", crn, map[string]interface{}{
+		"id":    crn,
+		"guid":  "instance-1",
+		"crn":   crn,
+		"name":  "mock-instance",
+		"state": "active",
+	})
+	rsConClient, err := meta.(ClientSession).ResourceControllerAPIV2()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	instance, err := rsConClient.ResourceServiceInstanceV2().GetInstance(crn)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if instance.Name != "mock-instance" {
+		t.Fatalf("expected instance name mock-instance, got %s", instance.Name)
+	}
+
+	dlClient, err := meta.(ClientSession).DirectlinkV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	gateways, _, err := dlClient.ListGateways(&directlinkv1.ListGatewaysOptions{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(gateways.Gateways) != 0 {
+		t.Fatalf("expected no direct link gateways, got %d", len(gateways.Gateways))
+	}
+}
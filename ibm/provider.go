@@ -1,7 +1,10 @@
 package ibm
 
 import (
+	"fmt"
+	"log"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -150,6 +153,108 @@ func Provider() terraform.ResourceProvider {
 				Description: "IAM Authentication refresh token",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_REFRESH_TOKEN", "IBMCLOUD_IAM_REFRESH_TOKEN"}, nil),
 			},
+			"iam_profile_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "ID of the IAM trusted profile to authenticate as with a compute resource token",
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"IC_IAM_PROFILE_ID", "IBMCLOUD_IAM_PROFILE_ID"}, nil),
+				ConflictsWith: []string{"iam_profile_name"},
+			},
+			"iam_profile_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Name of the IAM trusted profile to authenticate as with a compute resource token",
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"IC_IAM_PROFILE_NAME", "IBMCLOUD_IAM_PROFILE_NAME"}, nil),
+				ConflictsWith: []string{"iam_profile_id"},
+			},
+			"cr_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of the compute resource token exchanged for the IAM token of the trusted profile. Defaults to the service account token projected into IKS and OpenShift pods",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_CR_TOKEN_FILE_PATH", "IBMCLOUD_CR_TOKEN_FILE_PATH"}, nil),
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"public", "private", "public-and-private"}),
+				Description:  "Visibility of the IBM Cloud service endpoints. Allowed values are public, private and public-and-private",
+				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"IC_VISIBILITY", "IBMCLOUD_VISIBILITY"}, "public"),
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Overrides the URL of individual IBM Cloud service endpoints",
+				Elem:        &schema.Resource{Schema: providerEndpointsSchema()},
+			},
+			"endpoints_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a JSON file that maps service names to endpoint URLs",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_ENDPOINTS_FILE_PATH", "IBMCLOUD_ENDPOINTS_FILE_PATH"}, nil),
+			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a PEM file with additional CA certificates to trust, e.g. the CA of a TLS intercepting proxy",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_CA_BUNDLE_FILE", "IBMCLOUD_CA_BUNDLE_FILE"}, nil),
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip TLS certificate verification. Only meant for lab environments",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_INSECURE_SKIP_VERIFY", "IBMCLOUD_INSECURE_SKIP_VERIFY"}, false),
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry policy of the IBM Cloud API clients on rate limiting and server errors",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultRetryMaxAttempts,
+							ValidateFunc: validateAllowedRangeInt(1, 20),
+							Description:  "Maximum number of attempts of a request, including the first one",
+						},
+						"min_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultRetryMinBackoff,
+							ValidateFunc: validateAllowedRangeInt(0, 300),
+							Description:  "Minimum wait in seconds before retrying a request",
+						},
+						"max_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultRetryMaxBackoff,
+							ValidateFunc: validateAllowedRangeInt(0, 300),
+							Description:  "Maximum wait in seconds before retrying a request",
+						},
+					},
+				},
+			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Tags attached to every resource that supports tags, in addition to the tags of the resource",
+			},
+			"rate_limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Maximum requests per second sent to a service, keyed by the service names of the endpoints block",
+			},
+			"http_trace": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Log every HTTP request and response, with credentials redacted",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_HTTP_TRACE", "IBMCLOUD_HTTP_TRACE"}, false),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -168,12 +273,15 @@ func Provider() terraform.ResourceProvider {
 			"ibm_certificate_manager_certificate":    dataIBMCertificateManagerCertificate(),
 			"ibm_cis":                                dataSourceIBMCISInstance(),
 			"ibm_cis_dns_records":                    dataSourceIBMCISDNSRecords(),
+			"ibm_cis_dns_zone_export":                dataSourceIBMCISDNSZoneExport(),
 			"ibm_cis_certificates":                   dataIBMCISCertificates(),
 			"ibm_cis_global_load_balancers":          dataSourceIBMCISGlbs(),
 			"ibm_cis_origin_pools":                   dataSourceIBMCISOriginPools(),
 			"ibm_cis_healthchecks":                   dataSourceIBMCISHealthChecks(),
 			"ibm_cis_domain":                         dataSourceIBMCISDomain(),
 			"ibm_cis_firewall":                       dataIBMCISFirewallsRecord(),
+			"ibm_cis_filters":                        dataSourceIBMCISFilters(),
+			"ibm_cis_firewall_rules":                 dataSourceIBMCISFirewallRules(),
 			"ibm_cis_waf_packages":                   dataSourceIBMCISWAFPackages(),
 			"ibm_cis_range_apps":                     dataSourceIBMCISRangeApps(),
 			"ibm_cis_custom_certificates":            dataSourceIBMCISCustomCertificates(),
@@ -247,6 +355,7 @@ func Provider() terraform.ResourceProvider {
 			"ibm_is_subnet":                          dataSourceIBMISSubnet(),
 			"ibm_is_subnets":                         dataSourceIBMISSubnets(),
 			"ibm_is_security_group":                  dataSourceIBMISSecurityGroup(),
+			"ibm_is_security_group_targets":          dataSourceIBMISSecurityGroupTargets(),
 			"ibm_is_volume":                          dataSourceIBMISVolume(),
 			"ibm_is_vpc":                             dataSourceIBMISVPC(),
 			"ibm_is_vpn_gateways":                    dataSourceIBMISVPNGateways(),
@@ -339,6 +448,9 @@ func Provider() terraform.ResourceProvider {
 			"ibm_cis_domain":                                     resourceIBMCISDomain(),
 			"ibm_cis_domain_settings":                            resourceIBMCISSettings(),
 			"ibm_cis_firewall":                                   resourceIBMCISFirewallRecord(),
+			"ibm_cis_filter":                                     resourceIBMCISFilter(),
+			"ibm_cis_firewall_rule":                              resourceIBMCISFirewallRule(),
+			"ibm_cis_logpush_job":                                resourceIBMCISLogpushJob(),
 			"ibm_cis_range_app":                                  resourceIBMCISRangeApp(),
 			"ibm_cis_healthcheck":                                resourceIBMCISHealthCheck(),
 			"ibm_cis_origin_pool":                                resourceIBMCISPool(),
@@ -346,6 +458,7 @@ func Provider() terraform.ResourceProvider {
 			"ibm_cis_certificate_upload":                         resourceIBMCISCertificateUpload(),
 			"ibm_cis_dns_record":                                 resourceIBMCISDnsRecord(),
 			"ibm_cis_dns_records_import":                         resourceIBMCISDNSRecordsImport(),
+			"ibm_cis_dns_zone_file":                              resourceIBMCISDNSZoneFile(),
 			"ibm_cis_rate_limit":                                 resourceIBMCISRateLimit(),
 			"ibm_cis_page_rule":                                  resourceIBMCISPageRule(),
 			"ibm_cis_edge_functions_action":                      resourceIBMCISEdgeFunctionsAction(),
@@ -407,9 +520,12 @@ func Provider() terraform.ResourceProvider {
 			"ibm_is_floating_ip":                                 resourceIBMISFloatingIP(),
 			"ibm_is_flow_log":                                    resourceIBMISFlowLog(),
 			"ibm_is_instance":                                    resourceIBMISInstance(),
+			"ibm_is_instance_action":                             resourceIBMISInstanceAction(),
 			"ibm_is_instance_group":                              resourceIBMISInstanceGroup(),
 			"ibm_is_instance_group_manager":                      resourceIBMISInstanceGroupManager(),
 			"ibm_is_instance_group_manager_policy":               resourceIBMISInstanceGroupManagerPolicy(),
+			"ibm_is_instance_network_interface":                  resourceIBMISInstanceNetworkInterface(),
+			"ibm_is_instance_volume_attachment":                  resourceIBMISInstanceVolumeAttachment(),
 			"ibm_is_virtual_endpoint_gateway":                    resourceIBMISEndpointGateway(),
 			"ibm_is_virtual_endpoint_gateway_ip":                 resourceIBMISEndpointGatewayIP(),
 			"ibm_is_instance_template":                           resourceIBMISInstanceTemplate(),
@@ -422,12 +538,16 @@ func Provider() terraform.ResourceProvider {
 			"ibm_is_lb_pool":                                     resourceIBMISLBPool(),
 			"ibm_is_lb_pool_member":                              resourceIBMISLBPoolMember(),
 			"ibm_is_network_acl":                                 resourceIBMISNetworkACL(),
+			"ibm_is_network_acl_rule":                            resourceIBMISNetworkACLRule(),
 			"ibm_is_public_gateway":                              resourceIBMISPublicGateway(),
 			"ibm_is_security_group":                              resourceIBMISSecurityGroup(),
 			"ibm_is_security_group_rule":                         resourceIBMISSecurityGroupRule(),
+			"ibm_is_security_group_rules":                        resourceIBMISSecurityGroupRules(),
 			"ibm_is_security_group_network_interface_attachment": resourceIBMISSecurityGroupNetworkInterfaceAttachment(),
+			"ibm_is_security_group_target":                       resourceIBMISSecurityGroupTarget(),
 			"ibm_is_subnet":                                      resourceIBMISSubnet(),
 			"ibm_is_subnet_network_acl_attachment":               resourceIBMISSubnetNetworkACLAttachment(),
+			"ibm_is_subnet_routing_table_attachment":             resourceIBMISSubnetRoutingTableAttachment(),
 			"ibm_is_ssh_key":                                     resourceIBMISSSHKey(),
 			"ibm_is_volume":                                      resourceIBMISVolume(),
 			"ibm_is_vpn_gateway":                                 resourceIBMISVPNGateway(),
@@ -511,6 +631,19 @@ func Provider() terraform.ResourceProvider {
 	}
 }
 
+func providerEndpointsSchema() map[string]*schema.Schema {
+	endpoints := map[string]*schema.Schema{}
+	for _, service := range supportedEndpointServices() {
+		endpoints[service] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateServiceEndpoint,
+			Description:  fmt.Sprintf("The %s service endpoint URL", service),
+		}
+	}
+	return endpoints
+}
+
 var globalValidatorDict ValidatorDict
 var initOnce sync.Once
 
@@ -519,28 +652,31 @@ func Validator() ValidatorDict {
 	initOnce.Do(func() {
 		globalValidatorDict = ValidatorDict{
 			ResourceValidatorDictionary: map[string]*ResourceValidator{
-				"ibm_iam_custom_role":        resourceIBMIAMCustomRoleValidator(),
-				"ibm_cis_healthcheck":        resourceIBMCISHealthCheckValidator(),
-				"ibm_cis_rate_limit":         resourceIBMCISRateLimitValidator(),
-				"ibm_cis_domain_settings":    resourceIBMCISDomainSettingValidator(),
-				"ibm_cis_tls_settings":       resourceIBMCISTLSSettingsValidator(),
-				"ibm_cis_routing":            resourceIBMCISRoutingValidator(),
-				"ibm_cis_page_rule":          resourceCISPageRuleValidator(),
-				"ibm_cis_waf_package":        resourceIBMCISWAFPackageValidator(),
-				"ibm_cis_waf_group":          resourceIBMCISWAFGroupValidator(),
-				"ibm_cis_certificate_upload": resourceCISCertificateUploadValidator(),
-				"ibm_cis_cache_settings":     resourceIBMCISCacheSettingsValidator(),
-				"ibm_cis_custom_page":        resourceIBMCISCustomPageValidator(),
-				"ibm_cis_firewall":           resourceIBMCISFirewallValidator(),
-				"ibm_cis_range_app":          resourceIBMCISRangeAppValidator(),
-				"ibm_cis_waf_rule":           resourceIBMCISWAFRuleValidator(),
-				"ibm_cis_certificate_order":  resourceIBMCISCertificateOrderValidator(),
-				"ibm_cr_namespace":           resourceIBMCrNamespaceValidator(),
-				"ibm_tg_gateway":             resourceIBMTGValidator(),
-				"ibm_tg_connection":          resourceIBMTransitGatewayConnectionValidator(),
-				"ibm_dl_virtual_connection":  resourceIBMdlGatewayVCValidator(),
-				"ibm_dl_gateway":             resourceIBMDLGatewayValidator(),
-				"ibm_dl_provider_gateway":    resourceIBMDLProviderGatewayValidator(),
+				"ibm_iam_custom_role":           resourceIBMIAMCustomRoleValidator(),
+				"ibm_cis_healthcheck":           resourceIBMCISHealthCheckValidator(),
+				"ibm_cis_rate_limit":            resourceIBMCISRateLimitValidator(),
+				"ibm_cis_domain_settings":       resourceIBMCISDomainSettingValidator(),
+				"ibm_cis_tls_settings":          resourceIBMCISTLSSettingsValidator(),
+				"ibm_cis_routing":               resourceIBMCISRoutingValidator(),
+				"ibm_cis_page_rule":             resourceCISPageRuleValidator(),
+				"ibm_cis_waf_package":           resourceIBMCISWAFPackageValidator(),
+				"ibm_cis_waf_group":             resourceIBMCISWAFGroupValidator(),
+				"ibm_cis_certificate_upload":    resourceCISCertificateUploadValidator(),
+				"ibm_cis_cache_settings":        resourceIBMCISCacheSettingsValidator(),
+				"ibm_cis_custom_page":           resourceIBMCISCustomPageValidator(),
+				"ibm_cis_edge_functions_action": resourceIBMCISEdgeFunctionsActionValidator(),
+				"ibm_cis_firewall":              resourceIBMCISFirewallValidator(),
+				"ibm_cis_firewall_rule":         resourceIBMCISFirewallRuleValidator(),
+				"ibm_cis_logpush_job":           resourceIBMCISLogpushJobValidator(),
+				"ibm_cis_range_app":             resourceIBMCISRangeAppValidator(),
+				"ibm_cis_waf_rule":              resourceIBMCISWAFRuleValidator(),
+				"ibm_cis_certificate_order":     resourceIBMCISCertificateOrderValidator(),
+				"ibm_cr_namespace":              resourceIBMCrNamespaceValidator(),
+				"ibm_tg_gateway":                resourceIBMTGValidator(),
+				"ibm_tg_connection":             resourceIBMTransitGatewayConnectionValidator(),
+				"ibm_dl_virtual_connection":     resourceIBMdlGatewayVCValidator(),
+				"ibm_dl_gateway":                resourceIBMDLGatewayValidator(),
+				"ibm_dl_provider_gateway":       resourceIBMDLProviderGatewayValidator(),
 
 				"ibm_function_package":                 resourceIBMFuncPackageValidator(),
 				"ibm_function_action":                  resourceIBMFuncActionValidator(),
@@ -647,6 +783,45 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	wskNameSpace := d.Get("function_namespace").(string)
 	riaasEndPoint := d.Get("riaas_endpoint").(string)
 	generation := d.Get("generation").(int)
+	visibility := d.Get("visibility").(string)
+
+	retryMaxAttempts, retryMinBackoff, retryMaxBackoff := defaultRetryMaxAttempts, defaultRetryMinBackoff, defaultRetryMaxBackoff
+	if v, ok := d.GetOk("retry"); ok && v.([]interface{})[0] != nil {
+		retry := v.([]interface{})[0].(map[string]interface{})
+		retryMaxAttempts = retry["max_attempts"].(int)
+		retryMinBackoff = retry["min_backoff"].(int)
+		retryMaxBackoff = retry["max_backoff"].(int)
+	}
+	if retryMinBackoff > retryMaxBackoff {
+		return nil, fmt.Errorf("retry min_backoff (%d) must not be greater than max_backoff (%d)", retryMinBackoff, retryMaxBackoff)
+	}
+
+	rateLimits := map[string]int{}
+	for service, rate := range d.Get("rate_limits").(map[string]interface{}) {
+		if _, ok := serviceEndpointEnvs[service]; !ok {
+			return nil, fmt.Errorf("rate_limits: unsupported service %q, supported services are %s", service, strings.Join(supportedEndpointServices(), ", "))
+		}
+		if rate.(int) <= 0 {
+			return nil, fmt.Errorf("rate_limits: the limit of %s must be greater than 0, got %d", service, rate.(int))
+		}
+		rateLimits[service] = rate.(int)
+	}
+
+	endpoints := map[string]string{}
+	if path, ok := d.GetOk("endpoints_file_path"); ok {
+		fileEndpoints, err := loadEndpointsFile(path.(string))
+		if err != nil {
+			return nil, err
+		}
+		endpoints = fileEndpoints
+	}
+	if v, ok := d.GetOk("endpoints"); ok && v.([]interface{})[0] != nil {
+		for service, url := range v.([]interface{})[0].(map[string]interface{}) {
+			if url.(string) != "" {
+				endpoints[service] = url.(string)
+			}
+		}
+	}
 
 	wskEnvVal, err := schema.EnvDefaultFunc("FUNCTION_NAMESPACE", "")()
 	if err != nil {
@@ -673,9 +848,27 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Generation:           generation,
 		IAMToken:             iamToken,
 		IAMRefreshToken:      iamRefreshToken,
+		IAMProfileID:         d.Get("iam_profile_id").(string),
+		IAMProfileName:       d.Get("iam_profile_name").(string),
+		CRTokenFile:          d.Get("cr_token_file_path").(string),
 		Zone:                 zone,
+		Endpoints:            endpoints,
+		Visibility:           visibility,
+		CABundleFile:         d.Get("ca_bundle_file").(string),
+		InsecureSkipVerify:   d.Get("insecure_skip_verify").(bool),
+		RetryMaxAttempts:     retryMaxAttempts,
+		RetryMinBackoff:      time.Duration(retryMinBackoff) * time.Second,
+		RetryMaxBackoff:      time.Duration(retryMaxBackoff) * time.Second,
+		RateLimits:           rateLimits,
+		DefaultTags:          expandStringList(d.Get("default_tags").(*schema.Set).List()),
 		//PowerServiceInstance: powerServiceInstance,
 	}
 
+	if d.Get("http_trace").(bool) {
+		config.HTTPTrace = func(dump string) {
+			log.Printf("[DEBUG] %s", dump)
+		}
+	}
+
 	return config.ClientSession()
 }
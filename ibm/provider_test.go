@@ -1,10 +1,19 @@
 package ibm
 
 import (
+	"context"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	gohttp "net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
@@ -471,6 +480,109 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_endpoints(t *testing.T) {
+	mock := newMockBackend(t)
+	os.Unsetenv("IBMCLOUD_TG_API_ENDPOINT")
+	os.Unsetenv("IBMCLOUD_IS_NG_API_ENDPOINT")
+
+	file, err := ioutil.TempFile("", "endpoints*.json")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(file.Name())
+	fmt.Fprintf(file, `{"vpc": %q, "tg": "https://transit.example.com/v1"}`, mock.URL("/vpc/v1"))
+	file.Close()
+
+	raw := map[string]interface{}{
+		"ibmcloud_api_key":    "mock-api-key",
+		"endpoints_file_path": file.Name(),
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"tg": mock.URL("/transit/v1"),
+			},
+		},
+	}
+	p := Provider().(*schema.Provider)
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	tgClient, err := meta.(ClientSession).TransitGatewayV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if url := tgClient.Service.Options.URL; url != mock.URL("/transit/v1") {
+		t.Fatalf("expected the endpoints block to win over the file, got %s", url)
+	}
+	vpcClient, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if url := vpcClient.Service.Options.URL; url != mock.URL("/vpc/v1") {
+		t.Fatalf("expected the vpc endpoint from the file, got %s", url)
+	}
+}
+
+func TestProvider_privateVisibility(t *testing.T) {
+	newMockBackend(t)
+	os.Unsetenv("IBMCLOUD_TG_API_ENDPOINT")
+	os.Unsetenv("IBMCLOUD_IS_NG_API_ENDPOINT")
+
+	raw := map[string]interface{}{
+		"ibmcloud_api_key": "mock-api-key",
+		"region":           "eu-de",
+		"visibility":       "private",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"schematics": "https://schematics.example.com",
+			},
+		},
+	}
+	p := Provider().(*schema.Provider)
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	vpcClient, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if url := vpcClient.Service.Options.URL; url != "https://eu-de.private.iaas.cloud.ibm.com/v1" {
+		t.Fatalf("expected the private vpc endpoint, got %s", url)
+	}
+	tgClient, err := meta.(ClientSession).TransitGatewayV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if url := tgClient.Service.Options.URL; url != "https://private.transit.cloud.ibm.com/v1" {
+		t.Fatalf("expected the private transit gateway endpoint, got %s", url)
+	}
+	if _, err := meta.(ClientSession).CisDNSRecordClientSession(); err == nil || !strings.Contains(err.Error(), "cis service does not support private endpoints") {
+		t.Fatalf("expected a private endpoint error for cis, got %v", err)
+	}
+	if _, err := meta.(ClientSession).SchematicsAPI(); err != nil {
+		t.Fatalf("expected the schematics override to be usable with private visibility, got %s", err)
+	}
+	if _, err := meta.(ClientSession).IBMPISession(); err == nil || strings.Contains(err.Error(), "endpoints block") {
+		t.Fatalf("expected a private endpoint error for power that doesn't suggest an override, got %v", err)
+	}
+}
+
+func TestProvider_endpointsFileUnsupportedService(t *testing.T) {
+	file, err := ioutil.TempFile("", "endpoints*.json")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(file.Name())
+	fmt.Fprint(file, `{"unknown": "https://example.com"}`)
+	file.Close()
+
+	_, err = loadEndpointsFile(file.Name())
+	if err == nil || !strings.Contains(err.Error(), `unsupported service "unknown"`) {
+		t.Fatalf("expected an unsupported service error, got %v", err)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("IC_API_KEY"); v == "" {
 		t.Fatal("IC_API_KEY must be set for acceptance tests")
@@ -523,3 +635,406 @@ func testAccPreCheckEncryptedImage(t *testing.T) {
 		t.Fatal("IS_IMAGE_ENCRYPTION_KEY must be set for acceptance tests")
 	}
 }
+
+func TestProvider_lazyClientSession(t *testing.T) {
+	mock := newMockBackend(t)
+
+	raw := map[string]interface{}{
+		"ibmcloud_api_key": "mock-api-key",
+		"region":           "us-south",
+	}
+	p := Provider().(*schema.Provider)
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if mock.TokenRequests != 0 {
+		t.Fatalf("expected no token exchange while configuring the provider, got %d", mock.TokenRequests)
+	}
+
+	first, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	second, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if first != second {
+		t.Fatalf("expected the vpc client to be built once")
+	}
+	if _, err := meta.(ClientSession).TransitGatewayV1API(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if mock.TokenRequests != 1 {
+		t.Fatalf("expected the clients to share one token exchange, got %d", mock.TokenRequests)
+	}
+}
+
+func TestProvider_httpTrace(t *testing.T) {
+	newMockBackend(t)
+
+	var traces []string
+	config := Config{
+		BluemixAPIKey:  "mock-api-key",
+		Region:         mockRegion,
+		BluemixTimeout: 10 * time.Second,
+		RetryDelay:     time.Millisecond,
+		Generation:     2,
+		HTTPTrace: func(dump string) {
+			traces = append(traces, dump)
+		},
+	}
+	meta, err := config.ClientSession()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	vpcClient, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, _, err := vpcClient.ListVpcs(&vpcv1.ListVpcsOptions{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	all := strings.Join(traces, "\n")
+	if !strings.Contains(all, "/identity/token") || !strings.Contains(all, "/vpc/v1/vpcs") {
+		t.Fatalf("expected the token exchange and the vpc request to be traced, got:\n%s", all)
+	}
+	if !strings.Contains(all, "Authorization: REDACTED") {
+		t.Fatalf("expected the Authorization header to be redacted, got:\n%s", all)
+	}
+	if strings.Contains(all, "mock-api-key") || strings.Contains(all, "Bearer ey") {
+		t.Fatalf("expected no credentials in the trace, got:\n%s", all)
+	}
+}
+
+func TestProvider_caBundleFile(t *testing.T) {
+	server := httptest.NewTLSServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		w.WriteHeader(gohttp.StatusNoContent)
+	}))
+	defer server.Close()
+
+	get := func(config *Config) error {
+		transport, err := newHTTPTransport(config)
+		if err != nil {
+			return err
+		}
+		resp, err := (&gohttp.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	if err := get(&Config{}); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+	if err := get(&Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("expected insecure_skip_verify to accept the certificate, got %s", err)
+	}
+
+	bundle, err := ioutil.TempFile("", "ca-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(bundle.Name())
+	pem.Encode(bundle, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	bundle.Close()
+	if err := get(&Config{CABundleFile: bundle.Name()}); err != nil {
+		t.Fatalf("expected ca_bundle_file to trust the certificate, got %s", err)
+	}
+
+	ioutil.WriteFile(bundle.Name(), []byte("not a certificate"), 0600)
+	if _, err := newHTTPTransport(&Config{CABundleFile: bundle.Name()}); err == nil || !strings.Contains(err.Error(), "no PEM encoded certificate") {
+		t.Fatalf("expected an error for a file without certificates, got %v", err)
+	}
+}
+
+func TestProvider_retryTransport(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d: expected the request body to be replayed, got %q", calls, body)
+		}
+		switch {
+		case r.URL.Path == "/throttled" && calls < 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(gohttp.StatusTooManyRequests)
+		case r.URL.Path == "/error":
+			w.WriteHeader(gohttp.StatusInternalServerError)
+		default:
+			w.WriteHeader(gohttp.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := &gohttp.Client{Transport: &retryTransport{
+		next:        gohttp.DefaultTransport,
+		maxAttempts: 4,
+		minBackoff:  time.Millisecond,
+		maxBackoff:  10 * time.Millisecond,
+		timeout:     10 * time.Second,
+	}}
+	post := func(path string) int {
+		calls = 0
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post("/throttled"); status != gohttp.StatusOK || calls != 3 {
+		t.Fatalf("expected a throttled POST to succeed on the third attempt, got %d after %d attempts", status, calls)
+	}
+	if status := post("/error"); status != gohttp.StatusInternalServerError || calls != 1 {
+		t.Fatalf("expected a failed POST not to be retried, got %d after %d attempts", status, calls)
+	}
+
+	req, _ := gohttp.NewRequest(gohttp.MethodPut, server.URL+"/error", strings.NewReader("payload"))
+	calls = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	if calls != 4 {
+		t.Fatalf("expected a failed PUT to be attempted 4 times, got %d", calls)
+	}
+}
+
+func TestProvider_retryBackoff(t *testing.T) {
+	transport := &retryTransport{minBackoff: time.Second, maxBackoff: 8 * time.Second}
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		wait := transport.backoff(attempt+1, nil)
+		if wait < max/2 || wait > max {
+			t.Fatalf("attempt %d: expected a backoff between %s and %s, got %s", attempt+1, max/2, max, wait)
+		}
+	}
+
+	resp := &gohttp.Response{Header: gohttp.Header{"Retry-After": []string{"12"}}}
+	if wait := transport.backoff(1, resp); wait != 12*time.Second {
+		t.Fatalf("expected Retry-After to be honoured, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(gohttp.TimeFormat))
+	if wait := transport.backoff(1, resp); wait != 0 {
+		t.Fatalf("expected a Retry-After date in the past not to wait, got %s", wait)
+	}
+}
+
+func TestProvider_rateLimits(t *testing.T) {
+	bucket := newTokenBucket(10)
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		if err := bucket.wait(context.Background()); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected requests past the burst of 10 to be throttled, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.wait(ctx); err != context.Canceled {
+		t.Fatalf("expected a cancelled wait to fail, got %v", err)
+	}
+
+	newMockBackend(t)
+	p := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"ibmcloud_api_key": "mock-api-key",
+		"region":           "us-south",
+		"rate_limits":      map[string]interface{}{"vpc": 20, "cis": 5},
+	}
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	sess := meta.(*clientSession)
+	limiter := func(client *gohttp.Client) *tokenBucket {
+		if limited, ok := client.Transport.(*retryTransport).next.(*rateLimitTransport); ok {
+			return limited.limiter
+		}
+		return nil
+	}
+	if limiter(sess.sdkHTTPClient("vpc")) != sess.session.RateLimiters["vpc"] || limiter(sess.sdkHTTPClient("vpc")) == nil {
+		t.Fatalf("expected the vpc clients to share one rate limiter")
+	}
+	if limiter(sess.sdkHTTPClient("tg")) != nil {
+		t.Fatalf("expected services without a rate limit not to be throttled")
+	}
+
+	raw["rate_limits"] = map[string]interface{}{"unknown": 5}
+	if _, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw)); err == nil || !strings.Contains(err.Error(), `unsupported service "unknown"`) {
+		t.Fatalf("expected an unsupported service to be rejected, got %v", err)
+	}
+	raw["rate_limits"] = map[string]interface{}{"vpc": 0}
+	if _, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw)); err == nil {
+		t.Fatalf("expected a rate limit of 0 to be rejected")
+	}
+}
+
+func TestProvider_trustedProfile(t *testing.T) {
+	mock := newMockBackend(t)
+	mock.TokenLifetime = 2 * time.Second
+	os.Unsetenv("IC_API_KEY")
+
+	dir, err := ioutil.TempDir("", "cr-token")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	crTokenFile := dir + "/cr-token"
+	if err := ioutil.WriteFile(crTokenFile, []byte("mock-cr-token-1\n"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"iam_profile_id":     "Profile-mock",
+		"cr_token_file_path": crTokenFile,
+		"region":             "us-south",
+	}
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	vpc, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, _, err := vpc.ListVpcs(&vpcv1.ListVpcsOptions{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if userDetails.userID != "iam-Profile-mock" || userDetails.userAccount != mockAccountID {
+		t.Fatalf("expected the user details of the trusted profile, got %+v", userDetails)
+	}
+	if mock.TokenRequests != 1 || len(mock.CRTokens) != 1 || mock.CRTokens[0] != "mock-cr-token-1" {
+		t.Fatalf("expected one exchange of the compute resource token, got %d with %v", mock.TokenRequests, mock.CRTokens)
+	}
+
+	// The platform rotates the token file, and the first IAM token expires
+	if err := ioutil.WriteFile(crTokenFile, []byte("mock-cr-token-2\n"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	time.Sleep(mock.TokenLifetime + time.Second)
+	if _, _, err := vpc.ListVpcs(&vpcv1.ListVpcsOptions{}); err != nil {
+		t.Fatalf("expected the IAM token to be refreshed, got %s", err)
+	}
+	if mock.TokenRequests != 2 || mock.CRTokens[1] != "mock-cr-token-2" {
+		t.Fatalf("expected the rotated compute resource token to be exchanged, got %d with %v", mock.TokenRequests, mock.CRTokens)
+	}
+
+	raw["cr_token_file_path"] = dir + "/missing"
+	meta, err = providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := meta.(ClientSession).VpcV1API(); err == nil || !strings.Contains(err.Error(), "Error reading the compute resource token") {
+		t.Fatalf("expected a missing token file to be reported, got %v", err)
+	}
+
+	raw["ibmcloud_api_key"] = "mock-api-key"
+	if _, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw)); err == nil {
+		t.Fatalf("expected a trusted profile and an API key to be rejected")
+	}
+}
+
+func TestProvider_containerAuth(t *testing.T) {
+	mock := newMockBackend(t)
+	os.Unsetenv("IC_API_KEY")
+
+	dir, err := ioutil.TempDir("", "cr-token")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	defaults := defaultCRTokenFiles
+	defer func() { defaultCRTokenFiles = defaults }()
+	defaultCRTokenFiles = []string{dir + "/vault-token", dir + "/sa-token"}
+	if err := ioutil.WriteFile(dir+"/sa-token", []byte("mock-sa-token"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"iam_profile_name": "mock-profile",
+		"region":           "us-south",
+	}
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := meta.(ClientSession).TransitGatewayV1API(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(mock.CRTokens) != 1 || mock.CRTokens[0] != "mock-sa-token" {
+		t.Fatalf("expected the projected service account token to be exchanged, got %v", mock.CRTokens)
+	}
+}
+
+func TestProvider_tokenRefresh(t *testing.T) {
+	mock := newMockBackend(t)
+	mock.TokenLifetime = 2 * time.Second
+
+	p := Provider().(*schema.Provider)
+	raw := map[string]interface{}{
+		"ibmcloud_api_key": "mock-api-key",
+		"region":           "us-south",
+	}
+	meta, err := providerConfigure(schema.TestResourceDataRaw(t, p.Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	vpc, err := meta.(ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, _, err := vpc.ListVpcs(&vpcv1.ListVpcsOptions{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	bmxSess, err := meta.(ClientSession).BluemixSession()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	staleToken := bmxSess.Config.IAMAccessToken
+
+	time.Sleep(mock.TokenLifetime + time.Second)
+	if _, _, err := vpc.ListVpcs(&vpcv1.ListVpcsOptions{}); err != nil {
+		t.Fatalf("expected the go SDK client to refresh the IAM token, got %s", err)
+	}
+	if mock.TokenRequests != 2 {
+		t.Fatalf("expected the expired IAM token to be refreshed once, got %d token requests", mock.TokenRequests)
+	}
+
+	// Clients that copied the token when they were built, like Key Protect and
+	// Power, get the current one from the shared transport
+	send := func(client *gohttp.Client) int {
+		req, _ := gohttp.NewRequest(gohttp.MethodGet, mock.URL("/vpc/v1/vpcs"), nil)
+		req.Header.Set("Authorization", staleToken)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := send(gohttp.DefaultClient); status != gohttp.StatusUnauthorized {
+		t.Fatalf("expected the stale token to be rejected, got %d", status)
+	}
+	if status := send(meta.(*clientSession).session.HTTPClient); status != gohttp.StatusOK {
+		t.Fatalf("expected the stale token to be replaced, got %d", status)
+	}
+	if mock.TokenRequests != 2 {
+		t.Fatalf("expected the clients to share the refreshed IAM token, got %d token requests", mock.TokenRequests)
+	}
+}
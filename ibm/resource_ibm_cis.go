@@ -11,6 +11,7 @@ import (
 	"github.com/IBM-Cloud/bluemix-go/models"
 
 	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -40,6 +41,12 @@ func resourceIBMCISInstance() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(diff *schema.ResourceDiff, v interface{}) error {
+				return resourceDefaultTagsCustomizeDiff(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -94,6 +101,8 @@ func resourceIBMCISInstance() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -196,8 +205,8 @@ func resourceIBMCISInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		rsInst.Parameters = parameters.(map[string]interface{})
 	}
 
-	if _, ok := d.GetOk("tags"); ok {
-		rsInst.Tags = getServiceTags(d)
+	if _, tags := resourceTagsAllChange(d, meta); tags.(*schema.Set).Len() > 0 {
+		rsInst.Tags = expandStringList(tags.(*schema.Set).List())
 	}
 
 	instance, err := rsConClient.ResourceServiceInstance().CreateInstance(rsInst)
@@ -242,7 +251,7 @@ func resourceIBMCISInstanceRead(d *schema.ResourceData, meta interface{}) error
 		return nil
 	}
 
-	d.Set("tags", instance.Tags)
+	setResourceTags(d, meta, newStringSet(resourceIBMVPCHash, instance.Tags))
 	d.Set("name", instance.Name)
 	d.Set("status", instance.State)
 	d.Set("resource_group_id", instance.ResourceGroupID)
@@ -314,9 +323,9 @@ func resourceIBMCISInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	}
 
-	if d.HasChange("tags") {
-		tags := getServiceTags(d)
-		updateReq.Tags = tags
+	if d.HasChange("tags") || d.HasChange(tagsAll) {
+		_, tags := resourceTagsAllChange(d, meta)
+		updateReq.Tags = expandStringList(tags.(*schema.Set).List())
 	}
 
 	_, err = rsConClient.ResourceServiceInstance().UpdateInstance(instanceID, updateReq)
@@ -0,0 +1,368 @@
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	cisDNSZoneFileFile     = "file"
+	cisDNSZoneFileContent  = "content"
+	cisDNSZoneFileZoneName = "zone_name"
+
+	cisDNSZoneFilePerPage = 1000
+)
+
+func resourceIBMCISDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceIBMCISDNSZoneFileUpdate,
+		Read:          resourceIBMCISDNSZoneFileRead,
+		Update:        resourceIBMCISDNSZoneFileUpdate,
+		Delete:        resourceIBMCISDNSZoneFileDelete,
+		CustomizeDiff: resourceIBMCISDNSZoneFileCustomizeDiff,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+				ForceNew:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisDNSZoneFileFile: {
+				Type:        schema.TypeString,
+				Description: "BIND zone file that holds every DNS record of the domain",
+				Required:    true,
+			},
+			cisDNSZoneFileContent: {
+				Type:        schema.TypeString,
+				Description: "DNS records of the domain rendered as a BIND zone file",
+				Computed:    true,
+			},
+			cisDNSZoneFileZoneName: {
+				Type:        schema.TypeString,
+				Description: "Name of the CIS domain",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceIBMCISDNSZoneFileCustomizeDiff renders the zone file the way Read
+// renders the live records, so that a change to the file or to the live
+// records shows up as a change of content.
+func resourceIBMCISDNSZoneFileCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown(cisDNSZoneFileFile) || !diff.NewValueKnown(cisID) || !diff.NewValueKnown(cisDomainID) {
+		return diff.SetNewComputed(cisDNSZoneFileContent)
+	}
+	zoneName := diff.Get(cisDNSZoneFileZoneName).(string)
+	if zoneName == "" || diff.HasChange(cisDomainID) {
+		zoneID, _, _ := convertTftoCisTwoVar(diff.Get(cisDomainID).(string))
+		var err error
+		zoneName, err = getCISZoneName(meta, diff.Get(cisID).(string), zoneID)
+		if err != nil {
+			return err
+		}
+	}
+	records, err := readCISDNSZoneFile(diff.Get(cisDNSZoneFileFile).(string), zoneName)
+	if err != nil {
+		return err
+	}
+	content := renderCISDNSZoneFile(zoneName, records)
+	if content != diff.Get(cisDNSZoneFileContent).(string) {
+		return diff.SetNew(cisDNSZoneFileContent, content)
+	}
+	return nil
+}
+
+func resourceIBMCISDNSZoneFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	zoneName, err := getCISZoneName(meta, crn, zoneID)
+	if err != nil {
+		return err
+	}
+	desired, err := readCISDNSZoneFile(d.Get(cisDNSZoneFileFile).(string), zoneName)
+	if err != nil {
+		return err
+	}
+	live, err := listCISDNSZoneRecords(sess)
+	if err != nil {
+		return err
+	}
+
+	// Records are removed first, so that a name can change from a CNAME
+	// record to other records and back.
+	create, update, remove := diffCISDNSZoneRecords(desired, live)
+	for _, record := range remove {
+		log.Printf("[INFO] Deleting dns record %s", record)
+		opt := sess.NewDeleteDnsRecordOptions(record.ID)
+		_, response, err := sess.DeleteDnsRecord(opt)
+		if err != nil {
+			return fmt.Errorf("Error deleting dns record %s: %s\n%s", record, err, response)
+		}
+	}
+	for _, record := range update {
+		log.Printf("[INFO] Updating dns record %s", record)
+		if err := updateCISDNSZoneRecord(sess, record); err != nil {
+			return err
+		}
+	}
+	for _, record := range create {
+		log.Printf("[INFO] Creating dns record %s", record)
+		opt := sess.NewCreateDnsRecordOptions()
+		opt.SetName(record.Name)
+		opt.SetType(record.Type)
+		opt.SetTTL(record.TTL)
+		switch record.Type {
+		case cisDNSRecordTypeSRV, cisDNSRecordTypeCAA:
+			opt.SetData(record.Data)
+		case cisDNSRecordTypeMX:
+			opt.SetContent(record.Content)
+			opt.SetPriority(record.Priority)
+		default:
+			opt.SetContent(record.Content)
+		}
+		result, response, err := sess.CreateDnsRecord(opt)
+		if err != nil {
+			return fmt.Errorf("Error creating dns record %s: %s\n%s", record, err, response)
+		}
+		// Records are always created unproxied.
+		if record.Proxied {
+			record.ID = *result.Result.ID
+			if err := updateCISDNSZoneRecord(sess, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	d.SetId(convertCisToTfTwoVar(zoneID, crn))
+	return resourceIBMCISDNSZoneFileRead(d, meta)
+}
+
+func resourceIBMCISDNSZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return err
+	}
+	zoneID, crn, err := convertTftoCisTwoVar(d.Id())
+	if err != nil {
+		return err
+	}
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	zoneName, err := getCISZoneName(meta, crn, zoneID)
+	if err != nil {
+		return err
+	}
+	live, err := listCISDNSZoneRecords(sess)
+	if err != nil {
+		return err
+	}
+	d.Set(cisID, crn)
+	d.Set(cisDomainID, zoneID)
+	d.Set(cisDNSZoneFileZoneName, zoneName)
+	d.Set(cisDNSZoneFileContent, renderCISDNSZoneFile(zoneName, live))
+	return nil
+}
+
+// resourceIBMCISDNSZoneFileDelete deletes the records of the zone file as they
+// were last read, leaving records created since then alone.
+func resourceIBMCISDNSZoneFileDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := meta.(ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return err
+	}
+	zoneID, crn, err := convertTftoCisTwoVar(d.Id())
+	if err != nil {
+		return err
+	}
+	sess.Crn = core.StringPtr(crn)
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	managed, err := parseCISDNSZoneFile(d.Get(cisDNSZoneFileZoneName).(string), strings.NewReader(d.Get(cisDNSZoneFileContent).(string)))
+	if err != nil {
+		return err
+	}
+	live, err := listCISDNSZoneRecords(sess)
+	if err != nil {
+		return err
+	}
+	managedRecords := map[string]int{}
+	for _, record := range managed {
+		managedRecords[record.String()]++
+	}
+	for _, record := range live {
+		if managedRecords[record.String()] == 0 {
+			continue
+		}
+		managedRecords[record.String()]--
+		log.Printf("[INFO] Deleting dns record %s", record)
+		opt := sess.NewDeleteDnsRecordOptions(record.ID)
+		_, response, err := sess.DeleteDnsRecord(opt)
+		if err != nil {
+			return fmt.Errorf("Error deleting dns record %s: %s\n%s", record, err, response)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// readCISDNSZoneFile parses the BIND zone file at path for the zone zoneName.
+func readCISDNSZoneFile(path, zoneName string) ([]cisDNSZoneRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := parseCISDNSZoneFile(zoneName, f)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing zone file %s: %s", path, err)
+	}
+	return records, nil
+}
+
+// getCISZoneName returns the name of the CIS domain zoneID.
+func getCISZoneName(meta interface{}, crn, zoneID string) (string, error) {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return "", err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	opt := cisClient.NewGetZoneOptions(zoneID)
+	result, response, err := cisClient.GetZone(opt)
+	if err != nil {
+		return "", fmt.Errorf("Error getting zone %s: %s\n%s", zoneID, err, response)
+	}
+	return *result.Result.Name, nil
+}
+
+// listCISDNSZoneRecords returns the live records of the zone that a zone
+// file can hold.
+func listCISDNSZoneRecords(sess *dnsrecordsv1.DnsRecordsV1) ([]cisDNSZoneRecord, error) {
+	all, err := listAllCISDNSRecords(sess)
+	if err != nil {
+		return nil, err
+	}
+	records := []cisDNSZoneRecord{}
+	for _, details := range all {
+		if cisDNSZoneFileTypes[*details.Type] {
+			records = append(records, cisDNSZoneRecordFromDetails(details))
+		}
+	}
+	return records, nil
+}
+
+// listAllCISDNSRecords returns every live record of the zone, following the
+// pages of the list.
+func listAllCISDNSRecords(sess *dnsrecordsv1.DnsRecordsV1) ([]dnsrecordsv1.DnsrecordDetails, error) {
+	records := []dnsrecordsv1.DnsrecordDetails{}
+	for page := int64(1); ; page++ {
+		opt := sess.NewListAllDnsRecordsOptions()
+		opt.SetPage(page)
+		opt.SetPerPage(cisDNSZoneFilePerPage)
+		result, response, err := sess.ListAllDnsRecords(opt)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading dns records: %s\n%s", err, response)
+		}
+		records = append(records, result.Result...)
+		if len(result.Result) < cisDNSZoneFilePerPage || result.ResultInfo == nil ||
+			result.ResultInfo.TotalCount == nil || int64(len(records)) >= *result.ResultInfo.TotalCount {
+			return records, nil
+		}
+	}
+}
+
+// cisDNSZoneRecordFromDetails converts a live record into the form a zone file
+// is parsed into.
+func cisDNSZoneRecordFromDetails(details dnsrecordsv1.DnsrecordDetails) cisDNSZoneRecord {
+	record := cisDNSZoneRecord{
+		ID:   *details.ID,
+		Name: strings.ToLower(*details.Name),
+		Type: *details.Type,
+	}
+	if details.TTL != nil {
+		record.TTL = *details.TTL
+	}
+	if details.Content != nil {
+		record.Content = *details.Content
+	}
+	if details.Proxied != nil {
+		record.Proxied = *details.Proxied
+	}
+
+	data, _ := details.Data.(map[string]interface{})
+	switch record.Type {
+	case cisDNSRecordTypeCNAME, cisDNSRecordTypeNS, cisDNSRecordTypePTR:
+		record.Content = strings.ToLower(strings.TrimSuffix(record.Content, "."))
+	case cisDNSRecordTypeMX:
+		record.Content = strings.ToLower(strings.TrimSuffix(record.Content, "."))
+		if details.Priority != nil {
+			record.Priority = *details.Priority
+		}
+	case cisDNSRecordTypeSRV:
+		record.Data = map[string]interface{}{
+			"service":  cisDNSZoneDataString(data["service"]),
+			"proto":    cisDNSZoneDataString(data["proto"]),
+			"name":     cisDNSZoneDataString(data["name"]),
+			"priority": cisDNSZoneDataInt(data["priority"]),
+			"weight":   cisDNSZoneDataInt(data["weight"]),
+			"port":     cisDNSZoneDataInt(data["port"]),
+			"target":   strings.ToLower(strings.TrimSuffix(cisDNSZoneDataString(data["target"]), ".")),
+		}
+		record.Content = ""
+	case cisDNSRecordTypeCAA:
+		record.Data = map[string]interface{}{
+			"flags": cisDNSZoneDataInt(data["flags"]),
+			"tag":   cisDNSZoneDataString(data["tag"]),
+			"value": cisDNSZoneDataString(data["value"]),
+		}
+		record.Content = ""
+	}
+	return record
+}
+
+func updateCISDNSZoneRecord(sess *dnsrecordsv1.DnsRecordsV1, record cisDNSZoneRecord) error {
+	opt := sess.NewUpdateDnsRecordOptions(record.ID)
+	opt.SetName(record.Name)
+	opt.SetType(record.Type)
+	opt.SetTTL(record.TTL)
+	switch record.Type {
+	case cisDNSRecordTypeSRV, cisDNSRecordTypeCAA:
+		opt.SetData(record.Data)
+	case cisDNSRecordTypeMX:
+		opt.SetContent(record.Content)
+		opt.SetPriority(record.Priority)
+	default:
+		opt.SetContent(record.Content)
+	}
+	if record.Type == cisDNSRecordTypeA || record.Type == cisDNSRecordTypeAAAA || record.Type == cisDNSRecordTypeCNAME {
+		opt.SetProxied(record.Proxied)
+	}
+	_, response, err := sess.UpdateDnsRecord(opt)
+	if err != nil {
+		return fmt.Errorf("Error updating dns record %s: %s\n%s", record, err, response)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package ibm
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccIBMCisDNSZoneFile_basic(t *testing.T) {
+	name := "ibm_cis_dns_zone_file.test"
+	testDomain := uuid.New().String() + cisDomainTest
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisDNSZoneFileConfig(testDomain, "test-fixtures/dns_zone_file.txt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_name", testDomain),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(`www\.`+regexp.QuoteMeta(testDomain)+`\.\t1\tIN\tA\t192\.168\.0\.10\t; cf_tags=cf-proxied:true`)),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(`_sip\._tcp\.`+regexp.QuoteMeta(testDomain)+`\.\t3600\tIN\tSRV\t10 5 5060 mail\.`)),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisDNSZoneFileConfig(testDomain, "test-fixtures/dns_zone_file_updated.txt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(`192\.168\.0\.20`)),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(`AAAA\t2001:db8::1`)),
+					resource.TestCheckResourceAttrSet("data.ibm_cis_dns_zone_export.test", "content"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file"},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisDNSZoneFileConfig(domain, file string) string {
+	return testAccCheckCisDomainConfigCisRIbasic("test", domain) + fmt.Sprintf(`
+	resource "ibm_cis_dns_zone_file" "test" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = ibm_cis_domain.cis_domain.id
+		file      = "%[1]s"
+	}
+
+	data "ibm_cis_dns_zone_export" "test" {
+		cis_id    = ibm_cis_dns_zone_file.test.cis_id
+		domain_id = ibm_cis_dns_zone_file.test.domain_id
+	}`, file)
+}
+
+func TestParseCISDNSZoneFile(t *testing.T) {
+	zone := `$TTL 1h
+$ORIGIN Example.com.
+@        IN SOA ns1.example.com. admin.example.com. (
+                2020080101 ; serial
+                7200       ; refresh
+                3600 1209600 300 )
+@           NS    ns1.example.net.
+@        300   MX    10 mail
+         IN    TXT   "v=spf1 mx -all" ; same owner as the MX record
+www         A     192.168.0.10 ; cf_tags=cf-proxied:true
+api  600 IN AAAA  2001:DB8:0:0::1
+docs        CNAME www.example.org.
+sub         NS    ns1.dns.example.org.
+_sip._tcp   SRV   10 5 5060 sip
+@           CAA   0 issue "letsencrypt.org"
+long        TXT   ( "part one "
+                    "part \"two\"" )
+`
+	records, err := parseCISDNSZoneFile("example.com", strings.NewReader(zone))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []cisDNSZoneRecord{
+		{Name: "example.com", Type: "MX", TTL: 300, Content: "mail.example.com", Priority: 10},
+		{Name: "example.com", Type: "TXT", TTL: 3600, Content: "v=spf1 mx -all"},
+		{Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.10", Proxied: true},
+		{Name: "api.example.com", Type: "AAAA", TTL: 600, Content: "2001:db8::1"},
+		{Name: "docs.example.com", Type: "CNAME", TTL: 3600, Content: "www.example.org"},
+		{Name: "sub.example.com", Type: "NS", TTL: 3600, Content: "ns1.dns.example.org"},
+		{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 3600, Data: map[string]interface{}{
+			"service": "_sip", "proto": "_tcp", "name": "example.com",
+			"priority": 10, "weight": 5, "port": 5060, "target": "sip.example.com",
+		}},
+		{Name: "example.com", Type: "CAA", TTL: 3600, Data: map[string]interface{}{
+			"flags": 0, "tag": "issue", "value": "letsencrypt.org",
+		}},
+		{Name: "long.example.com", Type: "TXT", TTL: 3600, Content: `part one part "two"`},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("parseCISDNSZoneFile:\n got %#v\nwant %#v", records, expected)
+	}
+}
+
+func TestParseCISDNSZoneFileErrors(t *testing.T) {
+	testcases := map[string]string{
+		"www A 192.168.0.10\nwww LOC 37 46 46 N 122 23 35 W 0m": "line 2: record type LOC is not supported",
+		"$INCLUDE other.zone":    "line 1: directive $INCLUDE is not supported",
+		"www A 2001:db8::1":      `line 1: invalid A record address "2001:db8::1"`,
+		"@ MX mail":              "line 1: MX record takes 2 values, got 1",
+		"www TXT \"unterminated": "line 1: unterminated quoted string",
+		"www TXT ( \"open\"":     "line 1: unbalanced parentheses",
+		"sip SRV 10 5 5060 sip":  `line 1: SRV record name "sip.example.com" is not of the form _service._proto.name`,
+		"\n  A 192.168.0.10":     "line 2: record has no name",
+		"www CH A 192.168.0.10":  "line 1: class CH is not supported",
+		"$TTL forever":           "line 1: $TTL takes a TTL",
+		"www 300":                "line 1: record has no type",
+		"@ MX 70000 mail":        `line 1: invalid priority "70000" in MX record`,
+		"www TXT":                "line 1: TXT record has no data",
+		"$ORIGIN":                "line 1: $ORIGIN takes a domain name",
+		"www A 192.168.0.10 ; fine\nmail AAAA 10.0.0.1": `line 2: invalid AAAA record address "10.0.0.1"`,
+	}
+	for zone, expected := range testcases {
+		_, err := parseCISDNSZoneFile("example.com", strings.NewReader(zone))
+		if err == nil || err.Error() != expected {
+			t.Errorf("parseCISDNSZoneFile(%q): got error %v, want %q", zone, err, expected)
+		}
+	}
+}
+
+func TestRenderCISDNSZoneFile(t *testing.T) {
+	records := []cisDNSZoneRecord{
+		{Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.10", Proxied: true},
+		{Name: "example.com", Type: "MX", TTL: 300, Content: "mail.example.com", Priority: 10},
+		{Name: "example.com", Type: "TXT", TTL: 3600, Content: `say "hi"`},
+		{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 3600, Data: map[string]interface{}{
+			"priority": float64(10), "weight": float64(5), "port": float64(5060), "target": "sip.example.com",
+		}},
+		{Name: "example.com", Type: "CAA", TTL: 3600, Data: map[string]interface{}{
+			"flags": float64(0), "tag": "issue", "value": "letsencrypt.org",
+		}},
+	}
+	expected := `$ORIGIN example.com.
+_sip._tcp.example.com.	3600	IN	SRV	10 5 5060 sip.example.com.
+example.com.	300	IN	MX	10 mail.example.com.
+example.com.	3600	IN	CAA	0 issue "letsencrypt.org"
+example.com.	3600	IN	TXT	"say \"hi\""
+www.example.com.	1	IN	A	192.168.0.10	; cf_tags=cf-proxied:true
+`
+	content := renderCISDNSZoneFile("example.com.", records)
+	if content != expected {
+		t.Errorf("renderCISDNSZoneFile:\n got %q\nwant %q", content, expected)
+	}
+
+	// The rendered zone file parses back into the same records.
+	parsed, err := parseCISDNSZoneFile("example.com", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered := renderCISDNSZoneFile("example.com", parsed); rendered != content {
+		t.Errorf("renderCISDNSZoneFile after parsing:\n got %q\nwant %q", rendered, content)
+	}
+
+	long := strings.Repeat("a", 300)
+	if rdata := (cisDNSZoneRecord{Type: "TXT", Content: long}).rdata(); rdata != `"`+long[:255]+`" "`+long[255:]+`"` {
+		t.Errorf("TXT record of 300 characters rendered as %s", rdata)
+	}
+}
+
+func TestDiffCISDNSZoneRecords(t *testing.T) {
+	desired := []cisDNSZoneRecord{
+		{Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.10"},
+		{Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.11"},
+		{Name: "mail.example.com", Type: "A", TTL: 300, Content: "192.168.0.20"},
+		{Name: "api.example.com", Type: "AAAA", TTL: 1, Content: "2001:db8::1"},
+	}
+	live := []cisDNSZoneRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.10"},
+		{ID: "2", Name: "mail.example.com", Type: "A", TTL: 1, Content: "192.168.0.20"},
+		{ID: "3", Name: "old.example.com", Type: "CNAME", TTL: 1, Content: "www.example.com"},
+		{ID: "4", Name: "www.example.com", Type: "A", TTL: 1, Content: "192.168.0.10"},
+	}
+	create, update, remove := diffCISDNSZoneRecords(desired, live)
+
+	ids := func(records []cisDNSZoneRecord) []string {
+		result := []string{}
+		for _, record := range records {
+			result = append(result, record.ID+" "+record.String())
+		}
+		return result
+	}
+	expectedCreate := []string{" api.example.com.\t1\tIN\tAAAA\t2001:db8::1"}
+	expectedUpdate := []string{
+		"2 mail.example.com.\t300\tIN\tA\t192.168.0.20",
+		"4 www.example.com.\t1\tIN\tA\t192.168.0.11",
+	}
+	expectedRemove := []string{"3 old.example.com.\t1\tIN\tCNAME\twww.example.com."}
+	if !reflect.DeepEqual(ids(create), expectedCreate) {
+		t.Errorf("create: got %q, want %q", ids(create), expectedCreate)
+	}
+	if !reflect.DeepEqual(ids(update), expectedUpdate) {
+		t.Errorf("update: got %q, want %q", ids(update), expectedUpdate)
+	}
+	if !reflect.DeepEqual(ids(remove), expectedRemove) {
+		t.Errorf("remove: got %q, want %q", ids(remove), expectedRemove)
+	}
+
+	create, update, remove = diffCISDNSZoneRecords(desired, desired)
+	if len(create)+len(update)+len(remove) != 0 {
+		t.Errorf("diff of identical records: got %d creates, %d updates and %d removes", len(create), len(update), len(remove))
+	}
+}
@@ -1,9 +1,13 @@
 package ibm
 
 import (
+	"fmt"
 	"log"
+	"reflect"
 
+	"github.com/IBM-Cloud/bluemix-go/api/cis/cisv1"
 	"github.com/IBM/go-sdk-core/v4/core"
+	cisdomainsettingsv1 "github.com/IBM/networking-go-sdk/zonessettingsv1"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -51,6 +55,10 @@ const (
 	cisDomainSettingsMobileRedirectStripURI          = "strip_uri"
 	cisDomainSettingsMaxUpload                       = "max_upload"
 	cisDomainSettingsCipher                          = "cipher"
+	cisDomainSettingsResetOnDestroy                  = "reset_on_destroy"
+	cisDomainSettingsManagedSettings                 = "managed_settings"
+	cisDomainSettingsEditable                        = "editable"
+	cisDomainSettingsModifiedOn                      = "modified_on"
 	cisDomainSettingsONOFFValidatorID                = "on_off"
 	cisDomainSettingsActiveDisableValidatorID        = "active_disable"
 	cisDomainSettingsSSLSettingValidatorID           = "ssl_setting"
@@ -114,10 +122,10 @@ func resourceIBMCISSettings() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Minimum version of TLS required",
 				Optional:    true,
+				Computed:    true,
 				ValidateFunc: InvokeValidator(
 					ibmCISDomainSettings,
 					cisDomainSettingsTLSVersionValidatorID),
-				Default: "1.1",
 			},
 			cisDomainSettingsCNAMEFlattening: {
 				Type:        schema.TypeString,
@@ -438,6 +446,31 @@ func resourceIBMCISSettings() *schema.Resource {
 					},
 				},
 			},
+			cisDomainSettingsResetOnDestroy: {
+				Type:        schema.TypeBool,
+				Description: "Restore the CIS defaults of the managed settings when the resource is destroyed",
+				Optional:    true,
+				Default:     false,
+			},
+			cisDomainSettingsManagedSettings: {
+				Type:        schema.TypeSet,
+				Description: "Settings changed by this resource",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			cisDomainSettingsEditable: {
+				Type:        schema.TypeMap,
+				Description: "Whether each setting can be changed on the plan of the domain",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+			},
+			cisDomainSettingsModifiedOn: {
+				Type:        schema.TypeMap,
+				Description: "Time each setting was last changed",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 
 		Create:   resourceCISSettingsUpdate,
@@ -579,6 +612,55 @@ var settingsList = []string{
 	cisDomainSettingsCipher,
 }
 
+// cisDomainSettingsDefaults are the values of the settings of a new domain,
+// which reset_on_destroy restores.
+var cisDomainSettingsDefaults = map[string]interface{}{
+	cisDomainSettingsDNSSEC:                  "disabled",
+	cisDomainSettingsWAF:                     "off",
+	cisDomainSettingsSSL:                     "flexible",
+	cisDomainSettingsMinTLSVersion:           "1.1",
+	cisDomainSettingsCNAMEFlattening:         "flatten_at_root",
+	cisDomainSettingsOpportunisticEncryption: "on",
+	cisDomainSettingsAutomaticHTPSRewrites:   "off",
+	cisDomainSettingsAlwaysUseHTTPS:          "off",
+	cisDomainSettingsIPv6:                    "on",
+	cisDomainSettingsBrowserCheck:            "on",
+	cisDomainSettingsHotlinkProtection:       "off",
+	cisDomainSettingsHTTP2:                   "on",
+	cisDomainSettingsImageLoadOptimization:   "off",
+	cisDomainSettingsImageSizeOptimization:   "off",
+	cisDomainSettingsIPGeoLocation:           "on",
+	cisDomainSettingsOriginErrorPagePassThru: "off",
+	cisDomainSettingsBrotli:                  "on",
+	cisDomainSettingsPseudoIPv4:              "off",
+	cisDomainSettingsPrefetchPreload:         "off",
+	cisDomainSettingsResponseBuffering:       "off",
+	cisDomainSettingsScriptLoadOptimisation:  "off",
+	cisDomainSettingsServerSideExclude:       "on",
+	cisDomainSettingsTLSClientAuth:           "off",
+	cisDomainSettingsTrueClientIPHeader:      "off",
+	cisDomainSettingsWebSockets:              "on",
+	cisDomainSettingsChallengeTTL:            1800,
+	cisDomainSettingsMaxUpload:               100,
+	cisDomainSettingsCipher:                  schema.NewSet(schema.HashString, []interface{}{}),
+	cisDomainSettingsMinify: []interface{}{map[string]interface{}{
+		cisDomainSettingsMinifyCSS:  "off",
+		cisDomainSettingsMinifyHTML: "off",
+		cisDomainSettingsMinifyJS:   "off",
+	}},
+	cisDomainSettingsSecurityHeader: []interface{}{map[string]interface{}{
+		cisDomainSettingsSecurityHeaderEnabled:           false,
+		cisDomainSettingsSecurityHeaderMaxAge:            0,
+		cisDomainSettingsSecurityHeaderIncludeSubdomains: false,
+		cisDomainSettingsSecurityHeaderNoSniff:           false,
+	}},
+	cisDomainSettingsMobileRedirect: []interface{}{map[string]interface{}{
+		cisDomainSettingsMobileRedirectStatus:          "off",
+		cisDomainSettingsMobileRedirectMobileSubdomain: "",
+		cisDomainSettingsMobileRedirectStripURI:        false,
+	}},
+}
+
 func resourceCISSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
 	cisClient, err := meta.(ClientSession).CisDomainSettingsClientSession()
 	if err != nil {
@@ -590,294 +672,18 @@ func resourceCISSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
 	cisClient.Crn = core.StringPtr(cisID)
 	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
 
+	// Only the settings set in the configuration have a change, the others
+	// are computed and left as they are on CIS.
+	managed := d.Get(cisDomainSettingsManagedSettings).(*schema.Set)
 	for _, item := range settingsList {
-		var err error
-		var resp *core.DetailedResponse
-
-		switch item {
-		case cisDomainSettingsDNSSEC:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateZoneDnssecOptions()
-					opt.SetStatus(v.(string))
-					_, resp, err = cisClient.UpdateZoneDnssec(opt)
-				}
-			}
-		case cisDomainSettingsWAF:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateWebApplicationFirewallOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateWebApplicationFirewall(opt)
-				}
-			}
-		case cisDomainSettingsSSL:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					cisClient, err := meta.(ClientSession).CisSSLClientSession()
-					if err != nil {
-						return err
-					}
-					cisClient.Crn = core.StringPtr(cisID)
-					cisClient.ZoneIdentifier = core.StringPtr(zoneID)
-					opt := cisClient.NewChangeSslSettingOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.ChangeSslSetting(opt)
-				}
-			}
-
-		case cisDomainSettingsMinTLSVersion:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateMinTlsVersionOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateMinTlsVersion(opt)
-				}
-			}
-		case cisDomainSettingsCNAMEFlattening:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateZoneCnameFlatteningOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateZoneCnameFlattening(opt)
-				}
-			}
-		case cisDomainSettingsOpportunisticEncryption:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateOpportunisticEncryptionOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateOpportunisticEncryption(opt)
-				}
-			}
-		case cisDomainSettingsAutomaticHTPSRewrites:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateAutomaticHttpsRewritesOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateAutomaticHttpsRewrites(opt)
-				}
-			}
-		case cisDomainSettingsAlwaysUseHTTPS:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateAlwaysUseHttpsOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateAlwaysUseHttps(opt)
-				}
-			}
-		case cisDomainSettingsIPv6:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateIpv6Options()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateIpv6(opt)
-				}
-			}
-		case cisDomainSettingsBrowserCheck:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateBrowserCheckOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateBrowserCheck(opt)
-				}
-			}
-		case cisDomainSettingsHotlinkProtection:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateHotlinkProtectionOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateHotlinkProtection(opt)
-				}
-			}
-		case cisDomainSettingsHTTP2:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateHttp2Options()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateHttp2(opt)
-				}
-			}
-		case cisDomainSettingsImageLoadOptimization:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateImageLoadOptimizationOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateImageLoadOptimization(opt)
-				}
-			}
-		case cisDomainSettingsImageSizeOptimization:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateImageSizeOptimizationOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateImageSizeOptimization(opt)
-				}
-			}
-		case cisDomainSettingsIPGeoLocation:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateIpGeolocationOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateIpGeolocation(opt)
-				}
-			}
-		case cisDomainSettingsOriginErrorPagePassThru:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateEnableErrorPagesOnOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateEnableErrorPagesOn(opt)
-				}
-			}
-		case cisDomainSettingsPseudoIPv4:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdatePseudoIpv4Options()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdatePseudoIpv4(opt)
-				}
-			}
-		case cisDomainSettingsPrefetchPreload:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdatePrefetchPreloadOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdatePrefetchPreload(opt)
-				}
-			}
-		case cisDomainSettingsResponseBuffering:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateResponseBufferingOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateResponseBuffering(opt)
-				}
-			}
-		case cisDomainSettingsScriptLoadOptimisation:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateScriptLoadOptimizationOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateScriptLoadOptimization(opt)
-				}
-			}
-		case cisDomainSettingsServerSideExclude:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateServerSideExcludeOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateServerSideExclude(opt)
-				}
-			}
-		case cisDomainSettingsTLSClientAuth:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateTlsClientAuthOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateTlsClientAuth(opt)
-				}
-			}
-		case cisDomainSettingsTrueClientIPHeader:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateTrueClientIpOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateTrueClientIp(opt)
-				}
-			}
-		case cisDomainSettingsWebSockets:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateWebSocketsOptions()
-					opt.SetValue(v.(string))
-					_, resp, err = cisClient.UpdateWebSockets(opt)
-				}
-			}
-		case cisDomainSettingsChallengeTTL:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateChallengeTtlOptions()
-					opt.SetValue(int64(v.(int)))
-					_, resp, err = cisClient.UpdateChallengeTTL(opt)
-				}
-			}
-		case cisDomainSettingsMaxUpload:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					opt := cisClient.NewUpdateMaxUploadOptions()
-					opt.SetValue(int64(v.(int)))
-					_, resp, err = cisClient.UpdateMaxUpload(opt)
-				}
-			}
-		case cisDomainSettingsCipher:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					cipherValue := expandStringList(v.(*schema.Set).List())
-					opt := cisClient.NewUpdateCiphersOptions()
-					opt.SetValue(cipherValue)
-					_, resp, err = cisClient.UpdateCiphers(opt)
-				}
-			}
-		case cisDomainSettingsMinify:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					dataMap := v.([]interface{})[0].(map[string]interface{})
-					css := dataMap[cisDomainSettingsMinifyCSS].(string)
-					html := dataMap[cisDomainSettingsMinifyHTML].(string)
-					js := dataMap[cisDomainSettingsMinifyJS].(string)
-					minifyVal, err := cisClient.NewMinifySettingValue(css, html, js)
-					if err != nil {
-						log.Println("Invalid minfiy setting values")
-						return err
-					}
-					opt := cisClient.NewUpdateMinifyOptions()
-					opt.SetValue(minifyVal)
-					_, resp, err = cisClient.UpdateMinify(opt)
-				}
-			}
-		case cisDomainSettingsSecurityHeader:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					dataMap := v.([]interface{})[0].(map[string]interface{})
-					enabled := dataMap[cisDomainSettingsSecurityHeaderEnabled].(bool)
-					nosniff := dataMap[cisDomainSettingsSecurityHeaderNoSniff].(bool)
-					includeSubdomain := dataMap[cisDomainSettingsSecurityHeaderIncludeSubdomains].(bool)
-					maxAge := int64(dataMap[cisDomainSettingsSecurityHeaderMaxAge].(int))
-					securityVal, err := cisClient.NewSecurityHeaderSettingValueStrictTransportSecurity(
-						enabled, maxAge, includeSubdomain, nosniff)
-					if err != nil {
-						log.Println("Invalid security header setting values")
-						return err
-					}
-					securityOpt, err := cisClient.NewSecurityHeaderSettingValue(securityVal)
-					if err != nil {
-						log.Println("Invalid security header setting options")
-						return err
-					}
-					opt := cisClient.NewUpdateSecurityHeaderOptions()
-					opt.SetValue(securityOpt)
-					_, resp, err = cisClient.UpdateSecurityHeader(opt)
-				}
-			}
-		case cisDomainSettingsMobileRedirect:
-			if d.HasChange(item) {
-				if v, ok := d.GetOk(item); ok {
-					dataMap := v.([]interface{})[0].(map[string]interface{})
-					status := dataMap[cisDomainSettingsMobileRedirectStatus].(string)
-					mobileSubdomain := dataMap[cisDomainSettingsMobileRedirectMobileSubdomain].(string)
-					stripURI := dataMap[cisDomainSettingsMobileRedirectStripURI].(bool)
-					mobileOpt, err := cisClient.NewMobileRedirecSettingValue(status, mobileSubdomain, stripURI)
-					if err != nil {
-						log.Println("Invalid mobile redirect options")
-						return err
-					}
-					opt := cisClient.NewUpdateMobileRedirectOptions()
-					opt.SetValue(mobileOpt)
-					_, resp, err = cisClient.UpdateMobileRedirect(opt)
-				}
-			}
+		if !d.HasChange(item) {
+			continue
+		}
+		value, ok := d.GetOk(item)
+		if !ok {
+			continue
 		}
+		resp, err := updateCISDomainSetting(meta, cisClient, item, value)
 		if err != nil {
 			if resp != nil && resp.StatusCode == 405 {
 				log.Printf("[WARN] Update %s : %s", item, err)
@@ -886,11 +692,238 @@ func resourceCISSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Printf("Update settings Failed on %s, %v\n", item, resp)
 			return err
 		}
+		managed.Add(item)
 	}
 	d.SetId(convertCisToTfTwoVar(zoneID, cisID))
+	d.Set(cisDomainSettingsManagedSettings, managed)
 	return resourceCISSettingsRead(d, meta)
 }
 
+// updateCISDomainSetting changes one setting of the domain of cisClient. value
+// has the type that d.Get returns for the setting.
+func updateCISDomainSetting(meta interface{}, cisClient *cisdomainsettingsv1.ZonesSettingsV1, item string, value interface{}) (*core.DetailedResponse, error) {
+	switch item {
+	case cisDomainSettingsDNSSEC:
+		opt := cisClient.NewUpdateZoneDnssecOptions()
+		opt.SetStatus(value.(string))
+		_, resp, err := cisClient.UpdateZoneDnssec(opt)
+		return resp, err
+	case cisDomainSettingsWAF:
+		opt := cisClient.NewUpdateWebApplicationFirewallOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateWebApplicationFirewall(opt)
+		return resp, err
+	case cisDomainSettingsSSL:
+		sslClient, err := meta.(ClientSession).CisSSLClientSession()
+		if err != nil {
+			return nil, err
+		}
+		sslClient.Crn = cisClient.Crn
+		sslClient.ZoneIdentifier = cisClient.ZoneIdentifier
+		opt := sslClient.NewChangeSslSettingOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := sslClient.ChangeSslSetting(opt)
+		return resp, err
+	case cisDomainSettingsMinTLSVersion:
+		opt := cisClient.NewUpdateMinTlsVersionOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateMinTlsVersion(opt)
+		return resp, err
+	case cisDomainSettingsCNAMEFlattening:
+		opt := cisClient.NewUpdateZoneCnameFlatteningOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateZoneCnameFlattening(opt)
+		return resp, err
+	case cisDomainSettingsOpportunisticEncryption:
+		opt := cisClient.NewUpdateOpportunisticEncryptionOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateOpportunisticEncryption(opt)
+		return resp, err
+	case cisDomainSettingsAutomaticHTPSRewrites:
+		opt := cisClient.NewUpdateAutomaticHttpsRewritesOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateAutomaticHttpsRewrites(opt)
+		return resp, err
+	case cisDomainSettingsAlwaysUseHTTPS:
+		opt := cisClient.NewUpdateAlwaysUseHttpsOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateAlwaysUseHttps(opt)
+		return resp, err
+	case cisDomainSettingsIPv6:
+		opt := cisClient.NewUpdateIpv6Options()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateIpv6(opt)
+		return resp, err
+	case cisDomainSettingsBrowserCheck:
+		opt := cisClient.NewUpdateBrowserCheckOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateBrowserCheck(opt)
+		return resp, err
+	case cisDomainSettingsHotlinkProtection:
+		opt := cisClient.NewUpdateHotlinkProtectionOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateHotlinkProtection(opt)
+		return resp, err
+	case cisDomainSettingsHTTP2:
+		opt := cisClient.NewUpdateHttp2Options()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateHttp2(opt)
+		return resp, err
+	case cisDomainSettingsImageLoadOptimization:
+		opt := cisClient.NewUpdateImageLoadOptimizationOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateImageLoadOptimization(opt)
+		return resp, err
+	case cisDomainSettingsImageSizeOptimization:
+		opt := cisClient.NewUpdateImageSizeOptimizationOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateImageSizeOptimization(opt)
+		return resp, err
+	case cisDomainSettingsIPGeoLocation:
+		opt := cisClient.NewUpdateIpGeolocationOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateIpGeolocation(opt)
+		return resp, err
+	case cisDomainSettingsOriginErrorPagePassThru:
+		opt := cisClient.NewUpdateEnableErrorPagesOnOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateEnableErrorPagesOn(opt)
+		return resp, err
+	case cisDomainSettingsBrotli:
+		// brotli isn't part of networking-go-sdk, it goes through the legacy CIS API
+		cisAPI, err := meta.(ClientSession).CisAPI()
+		if err != nil {
+			return nil, err
+		}
+		_, err = cisAPI.Settings().UpdateSetting(*cisClient.Crn, *cisClient.ZoneIdentifier, item, cisv1.SettingsBody{Value: value.(string)})
+		return nil, err
+	case cisDomainSettingsPseudoIPv4:
+		opt := cisClient.NewUpdatePseudoIpv4Options()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdatePseudoIpv4(opt)
+		return resp, err
+	case cisDomainSettingsPrefetchPreload:
+		opt := cisClient.NewUpdatePrefetchPreloadOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdatePrefetchPreload(opt)
+		return resp, err
+	case cisDomainSettingsResponseBuffering:
+		opt := cisClient.NewUpdateResponseBufferingOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateResponseBuffering(opt)
+		return resp, err
+	case cisDomainSettingsScriptLoadOptimisation:
+		opt := cisClient.NewUpdateScriptLoadOptimizationOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateScriptLoadOptimization(opt)
+		return resp, err
+	case cisDomainSettingsServerSideExclude:
+		opt := cisClient.NewUpdateServerSideExcludeOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateServerSideExclude(opt)
+		return resp, err
+	case cisDomainSettingsTLSClientAuth:
+		opt := cisClient.NewUpdateTlsClientAuthOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateTlsClientAuth(opt)
+		return resp, err
+	case cisDomainSettingsTrueClientIPHeader:
+		opt := cisClient.NewUpdateTrueClientIpOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateTrueClientIp(opt)
+		return resp, err
+	case cisDomainSettingsWebSockets:
+		opt := cisClient.NewUpdateWebSocketsOptions()
+		opt.SetValue(value.(string))
+		_, resp, err := cisClient.UpdateWebSockets(opt)
+		return resp, err
+	case cisDomainSettingsChallengeTTL:
+		opt := cisClient.NewUpdateChallengeTtlOptions()
+		opt.SetValue(int64(value.(int)))
+		_, resp, err := cisClient.UpdateChallengeTTL(opt)
+		return resp, err
+	case cisDomainSettingsMaxUpload:
+		opt := cisClient.NewUpdateMaxUploadOptions()
+		opt.SetValue(int64(value.(int)))
+		_, resp, err := cisClient.UpdateMaxUpload(opt)
+		return resp, err
+	case cisDomainSettingsCipher:
+		cipherValue := expandStringList(value.(*schema.Set).List())
+		opt := cisClient.NewUpdateCiphersOptions()
+		opt.SetValue(cipherValue)
+		_, resp, err := cisClient.UpdateCiphers(opt)
+		return resp, err
+	case cisDomainSettingsMinify:
+		dataMap := value.([]interface{})[0].(map[string]interface{})
+		css := dataMap[cisDomainSettingsMinifyCSS].(string)
+		html := dataMap[cisDomainSettingsMinifyHTML].(string)
+		js := dataMap[cisDomainSettingsMinifyJS].(string)
+		minifyVal, err := cisClient.NewMinifySettingValue(css, html, js)
+		if err != nil {
+			log.Println("Invalid minfiy setting values")
+			return nil, err
+		}
+		opt := cisClient.NewUpdateMinifyOptions()
+		opt.SetValue(minifyVal)
+		_, resp, err := cisClient.UpdateMinify(opt)
+		return resp, err
+	case cisDomainSettingsSecurityHeader:
+		dataMap := value.([]interface{})[0].(map[string]interface{})
+		enabled := dataMap[cisDomainSettingsSecurityHeaderEnabled].(bool)
+		nosniff := dataMap[cisDomainSettingsSecurityHeaderNoSniff].(bool)
+		includeSubdomain := dataMap[cisDomainSettingsSecurityHeaderIncludeSubdomains].(bool)
+		maxAge := int64(dataMap[cisDomainSettingsSecurityHeaderMaxAge].(int))
+		securityVal, err := cisClient.NewSecurityHeaderSettingValueStrictTransportSecurity(
+			enabled, maxAge, includeSubdomain, nosniff)
+		if err != nil {
+			log.Println("Invalid security header setting values")
+			return nil, err
+		}
+		securityOpt, err := cisClient.NewSecurityHeaderSettingValue(securityVal)
+		if err != nil {
+			log.Println("Invalid security header setting options")
+			return nil, err
+		}
+		opt := cisClient.NewUpdateSecurityHeaderOptions()
+		opt.SetValue(securityOpt)
+		_, resp, err := cisClient.UpdateSecurityHeader(opt)
+		return resp, err
+	case cisDomainSettingsMobileRedirect:
+		dataMap := value.([]interface{})[0].(map[string]interface{})
+		status := dataMap[cisDomainSettingsMobileRedirectStatus].(string)
+		mobileSubdomain := dataMap[cisDomainSettingsMobileRedirectMobileSubdomain].(string)
+		stripURI := dataMap[cisDomainSettingsMobileRedirectStripURI].(bool)
+		mobileOpt, err := cisClient.NewMobileRedirecSettingValue(status, mobileSubdomain, stripURI)
+		if err != nil {
+			log.Println("Invalid mobile redirect options")
+			return nil, err
+		}
+		opt := cisClient.NewUpdateMobileRedirectOptions()
+		opt.SetValue(mobileOpt)
+		_, resp, err := cisClient.UpdateMobileRedirect(opt)
+		return resp, err
+	}
+	return nil, fmt.Errorf("Setting %s is not supported", item)
+}
+
+// setCISDomainSettingMetadata records whether the setting item is editable and
+// when it was last modified. modified is a *string or a date.
+func setCISDomainSettingMetadata(editable, modifiedOn map[string]interface{}, item string, isEditable *bool, modified interface{}) {
+	if isEditable != nil {
+		editable[item] = *isEditable
+	}
+	switch v := modified.(type) {
+	case *string:
+		if v != nil {
+			modifiedOn[item] = *v
+		}
+	case fmt.Stringer:
+		if !reflect.ValueOf(v).IsNil() {
+			modifiedOn[item] = v.String()
+		}
+	}
+}
+
 func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	cisClient, err := meta.(ClientSession).CisDomainSettingsClientSession()
 	if err != nil {
@@ -901,6 +934,8 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	cisClient.Crn = core.StringPtr(crn)
 	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
 
+	editable := map[string]interface{}{}
+	modifiedOn := map[string]interface{}{}
 	for _, item := range settingsList {
 		var settingErr error
 		var settingResponse *core.DetailedResponse
@@ -918,6 +953,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetWebApplicationFirewallOptions()
 			result, resp, err := cisClient.GetWebApplicationFirewall(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsWAF, result.Result.Value)
 			}
 			settingResponse = resp
@@ -933,6 +969,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetSslSettingOptions()
 			result, resp, err := cisClient.GetSslSetting(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsSSL, result.Result.Value)
 			}
 			settingResponse = resp
@@ -947,6 +984,8 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			if err == nil {
 				settingsObj := *settingsResult
 				d.Set(item, settingsObj.Value)
+				editable[item] = settingsObj.Editable
+				modifiedOn[item] = settingsObj.ModifiedDate
 			}
 			settingErr = err
 
@@ -954,6 +993,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetMinTlsVersionOptions()
 			result, resp, err := cisClient.GetMinTlsVersion(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsMinTLSVersion, result.Result.Value)
 			}
 			settingResponse = resp
@@ -963,6 +1003,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetZoneCnameFlatteningOptions()
 			result, resp, err := cisClient.GetZoneCnameFlattening(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsCNAMEFlattening, result.Result.Value)
 			}
 			settingResponse = resp
@@ -972,6 +1013,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetOpportunisticEncryptionOptions()
 			result, resp, err := cisClient.GetOpportunisticEncryption(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsOpportunisticEncryption, result.Result.Value)
 			}
 			settingResponse = resp
@@ -981,6 +1023,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetAutomaticHttpsRewritesOptions()
 			result, resp, err := cisClient.GetAutomaticHttpsRewrites(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsAutomaticHTPSRewrites, result.Result.Value)
 			}
 			settingResponse = resp
@@ -990,6 +1033,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetAlwaysUseHttpsOptions()
 			result, resp, err := cisClient.GetAlwaysUseHttps(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsAlwaysUseHTTPS, result.Result.Value)
 			}
 			settingResponse = resp
@@ -999,6 +1043,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetIpv6Options()
 			result, resp, err := cisClient.GetIpv6(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsIPv6, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1008,6 +1053,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetBrowserCheckOptions()
 			result, resp, err := cisClient.GetBrowserCheck(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsBrowserCheck, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1017,6 +1063,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetHotlinkProtectionOptions()
 			result, resp, err := cisClient.GetHotlinkProtection(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsHotlinkProtection, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1026,6 +1073,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetHttp2Options()
 			result, resp, err := cisClient.GetHttp2(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsHTTP2, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1035,6 +1083,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetImageLoadOptimizationOptions()
 			result, resp, err := cisClient.GetImageLoadOptimization(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsImageLoadOptimization, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1044,6 +1093,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetImageSizeOptimizationOptions()
 			result, resp, err := cisClient.GetImageSizeOptimization(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsImageSizeOptimization, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1053,6 +1103,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetIpGeolocationOptions()
 			result, resp, err := cisClient.GetIpGeolocation(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsIPGeoLocation, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1062,6 +1113,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetEnableErrorPagesOnOptions()
 			result, resp, err := cisClient.GetEnableErrorPagesOn(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsOriginErrorPagePassThru, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1071,6 +1123,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetPseudoIpv4Options()
 			result, resp, err := cisClient.GetPseudoIpv4(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsPseudoIPv4, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1080,6 +1133,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetPrefetchPreloadOptions()
 			result, resp, err := cisClient.GetPrefetchPreload(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsPrefetchPreload, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1089,6 +1143,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetResponseBufferingOptions()
 			result, resp, err := cisClient.GetResponseBuffering(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsResponseBuffering, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1098,6 +1153,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetScriptLoadOptimizationOptions()
 			result, resp, err := cisClient.GetScriptLoadOptimization(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsScriptLoadOptimisation, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1107,6 +1163,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetServerSideExcludeOptions()
 			result, resp, err := cisClient.GetServerSideExclude(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsServerSideExclude, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1116,6 +1173,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetTlsClientAuthOptions()
 			result, resp, err := cisClient.GetTlsClientAuth(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsTLSClientAuth, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1125,6 +1183,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetTrueClientIpOptions()
 			result, resp, err := cisClient.GetTrueClientIp(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsTrueClientIPHeader, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1134,6 +1193,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetWebSocketsOptions()
 			result, resp, err := cisClient.GetWebSockets(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsWebSockets, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1143,6 +1203,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetChallengeTtlOptions()
 			result, resp, err := cisClient.GetChallengeTTL(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsChallengeTTL, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1152,6 +1213,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetMaxUploadOptions()
 			result, resp, err := cisClient.GetMaxUpload(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsMaxUpload, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1161,6 +1223,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetCiphersOptions()
 			result, resp, err := cisClient.GetCiphers(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				d.Set(cisDomainSettingsCipher, result.Result.Value)
 			}
 			settingResponse = resp
@@ -1170,6 +1233,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetMinifyOptions()
 			result, resp, err := cisClient.GetMinify(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				minify := result.Result.Value
 				value := map[string]string{
 					cisDomainSettingsMinifyCSS:  *minify.Css,
@@ -1185,6 +1249,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetSecurityHeaderOptions()
 			result, resp, err := cisClient.GetSecurityHeader(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 
 				if result.Result.Value != nil && result.Result.Value.StrictTransportSecurity != nil {
 
@@ -1212,6 +1277,7 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 			opt := cisClient.NewGetMobileRedirectOptions()
 			result, resp, err := cisClient.GetMobileRedirect(opt)
 			if err == nil {
+				setCISDomainSettingMetadata(editable, modifiedOn, item, result.Result.Editable, result.Result.ModifiedOn)
 				if result.Result.Value != nil {
 
 					value := result.Result.Value
@@ -1244,11 +1310,41 @@ func resourceCISSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.Set(cisID, crn)
 	d.Set(cisDomainID, zoneID)
+	d.Set(cisDomainSettingsEditable, editable)
+	d.Set(cisDomainSettingsModifiedOn, modifiedOn)
 	return nil
 }
 
 func resourceCISSettingsDelete(d *schema.ResourceData, meta interface{}) error {
-	// Nothing to delete on CIS resource
+	// The settings can't be deleted, they are left as they are unless they
+	// are reset to the CIS defaults.
+	if !d.Get(cisDomainSettingsResetOnDestroy).(bool) {
+		d.SetId("")
+		return nil
+	}
+	cisClient, err := meta.(ClientSession).CisDomainSettingsClientSession()
+	if err != nil {
+		return err
+	}
+
+	zoneID, crn, _ := convertTftoCisTwoVar(d.Id())
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	managed := d.Get(cisDomainSettingsManagedSettings).(*schema.Set)
+	for _, item := range settingsList {
+		if !managed.Contains(item) {
+			continue
+		}
+		resp, err := updateCISDomainSetting(meta, cisClient, item, cisDomainSettingsDefaults[item])
+		if err != nil {
+			if resp != nil && resp.StatusCode == 405 {
+				log.Printf("[WARN] Reset %s : %s", item, err)
+				continue
+			}
+			return fmt.Errorf("Error resetting setting %s: %s\n%s", item, err, resp)
+		}
+	}
 	d.SetId("")
 	return nil
 }
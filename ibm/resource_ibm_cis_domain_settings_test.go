@@ -2,9 +2,13 @@ package ibm
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/IBM/go-sdk-core/v4/core"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
 func TestAccIBMCisSettings_Basic(t *testing.T) {
@@ -19,6 +23,8 @@ func TestAccIBMCisSettings_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "waf", "off"),
 					resource.TestCheckResourceAttr(name, "min_tls_version", "1.1"),
+					resource.TestCheckResourceAttrSet(name, "editable.waf"),
+					resource.TestCheckResourceAttrSet(name, "modified_on.min_tls_version"),
 				),
 			},
 			{
@@ -63,14 +69,104 @@ func TestAccIBMCisSettings_Import(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      name,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reset_on_destroy", "managed_settings"},
 			},
 		},
 	})
 }
 
+func TestAccIBMCisSettings_ResetOnDestroy(t *testing.T) {
+	name := "ibm_cis_domain_settings." + "test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCis(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCisSettingsReset,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCisSettingsConfigResetOnDestroy("test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "waf", "on"),
+					resource.TestCheckResourceAttr(name, "managed_settings.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCisSettingsReset(s *terraform.State) error {
+	cisClient, err := testAccProvider.Meta().(ClientSession).CisDomainSettingsClientSession()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_cis_domain_settings" {
+			continue
+		}
+		zoneID, crn, _ := convertTftoCisTwoVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+		result, _, err := cisClient.GetWebApplicationFirewall(cisClient.NewGetWebApplicationFirewallOptions())
+		if err != nil {
+			return err
+		}
+		if *result.Result.Value != cisDomainSettingsDefaults[cisDomainSettingsWAF] {
+			return fmt.Errorf("waf was not reset on destroy, it is %s", *result.Result.Value)
+		}
+	}
+	return nil
+}
+
+func TestSetCISDomainSettingMetadata(t *testing.T) {
+	editable := map[string]interface{}{}
+	modifiedOn := map[string]interface{}{}
+	modified := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	sslModified := "2020-10-01T12:00:00Z"
+	var missing *time.Time
+
+	setCISDomainSettingMetadata(editable, modifiedOn, "waf", core.BoolPtr(true), &modified)
+	setCISDomainSettingMetadata(editable, modifiedOn, "ssl", core.BoolPtr(false), &sslModified)
+	setCISDomainSettingMetadata(editable, modifiedOn, "ipv6", nil, missing)
+
+	if expected := map[string]interface{}{"waf": true, "ssl": false}; !reflect.DeepEqual(editable, expected) {
+		t.Errorf("expected editable %v, got %v", expected, editable)
+	}
+	if expected := map[string]interface{}{"waf": modified.String(), "ssl": sslModified}; !reflect.DeepEqual(modifiedOn, expected) {
+		t.Errorf("expected modified_on %v, got %v", expected, modifiedOn)
+	}
+}
+
+func TestCISDomainSettingsDefaults(t *testing.T) {
+	settings := resourceIBMCISSettings().Schema
+	for _, item := range settingsList {
+		value, ok := cisDomainSettingsDefaults[item]
+		if !ok {
+			t.Errorf("setting %s has no default", item)
+			continue
+		}
+		if settings[item].ValidateFunc == nil {
+			continue
+		}
+		if _, errs := settings[item].ValidateFunc(value, item); len(errs) > 0 {
+			t.Errorf("default of setting %s is not valid: %v", item, errs)
+		}
+	}
+}
+
+func testAccCheckCisSettingsConfigResetOnDestroy(id string) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	resource "ibm_cis_domain_settings" "%[1]s" {
+		cis_id           = data.ibm_cis.cis.id
+		domain_id        = data.ibm_cis_domain.cis_domain.id
+		waf              = "on"
+		reset_on_destroy = true
+	  }
+`, id)
+}
+
 func testAccCheckCisSettingsConfigBasic3(id string, cisDomainStatic string) string {
 	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
 	resource "ibm_cis_domain_settings" "%[1]s" {
@@ -2,28 +2,35 @@ package ibm
 
 import (
 	"fmt"
-	"io"
-	"io/ioutil"
 	"log"
-	"strings"
 
 	"github.com/IBM/go-sdk-core/v4/core"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 const (
-	cisEdgeFunctionsActionActionName = "action_name"
-	cisEdgeFunctionsActionScript     = "script"
+	ibmCISEdgeFunctionsAction          = "ibm_cis_edge_functions_action"
+	cisEdgeFunctionsActionActionName   = "action_name"
+	cisEdgeFunctionsActionScript       = "script"
+	cisEdgeFunctionsActionScriptFile   = "script_file"
+	cisEdgeFunctionsActionScriptDir    = "script_dir"
+	cisEdgeFunctionsActionScriptSHA256 = "script_sha256"
+	cisEdgeFunctionsActionBindings     = "bindings"
+	cisEdgeFunctionsActionBindingName  = "name"
+	cisEdgeFunctionsActionBindingType  = "type"
+	cisEdgeFunctionsActionBindingText  = "text"
+	cisEdgeFunctionsActionBindingTypes = "plain_text, secret_text"
 )
 
 func resourceIBMCISEdgeFunctionsAction() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMCISEdgeFunctionsActionCreate,
-		Read:     resourceIBMCISEdgeFunctionsActionRead,
-		Update:   resourceIBMCISEdgeFunctionsActionUpdate,
-		Delete:   resourceIBMCISEdgeFunctionsActionDelete,
-		Exists:   resourceIBMCISEdgeFunctionsActionExists,
-		Importer: &schema.ResourceImporter{},
+		Create:        resourceIBMCISEdgeFunctionsActionCreate,
+		Read:          resourceIBMCISEdgeFunctionsActionRead,
+		Update:        resourceIBMCISEdgeFunctionsActionUpdate,
+		Delete:        resourceIBMCISEdgeFunctionsActionDelete,
+		Exists:        resourceIBMCISEdgeFunctionsActionExists,
+		CustomizeDiff: resourceIBMCISEdgeFunctionsActionCustomizeDiff,
+		Importer:      &schema.ResourceImporter{},
 		Schema: map[string]*schema.Schema{
 			cisID: {
 				Type:        schema.TypeString,
@@ -43,14 +50,118 @@ func resourceIBMCISEdgeFunctionsAction() *schema.Resource {
 				Description: "Edge function action script name",
 			},
 			cisEdgeFunctionsActionScript: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{cisEdgeFunctionsActionScript, cisEdgeFunctionsActionScriptFile, cisEdgeFunctionsActionScriptDir},
+				Description:  "Edge function action script",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeCISEdgeFunctionsScript(old) == normalizeCISEdgeFunctionsScript(new)
+				},
+			},
+			cisEdgeFunctionsActionScriptFile: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Edge function action script",
+				Optional:    true,
+				Description: "Path of the file of the edge function action script",
+			},
+			cisEdgeFunctionsActionScriptDir: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of the directory of the .js files bundled into the edge function action script",
+			},
+			cisEdgeFunctionsActionScriptSHA256: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the edge function action script",
+			},
+			cisEdgeFunctionsActionBindings: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Variables available to the edge function action script",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						cisEdgeFunctionsActionBindingName: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the variable",
+						},
+						cisEdgeFunctionsActionBindingType: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "plain_text",
+							Description:  "Type of the variable. Allowable values are " + cisEdgeFunctionsActionBindingTypes,
+							ValidateFunc: InvokeValidator(ibmCISEdgeFunctionsAction, cisEdgeFunctionsActionBindingType),
+						},
+						cisEdgeFunctionsActionBindingText: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Value of the variable",
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func resourceIBMCISEdgeFunctionsActionValidator() *ResourceValidator {
+	validateSchema := make([]ValidateSchema, 1)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisEdgeFunctionsActionBindingType,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Required:                   true,
+			AllowedValues:              cisEdgeFunctionsActionBindingTypes})
+	cisEdgeFunctionsActionValidator := ResourceValidator{ResourceName: ibmCISEdgeFunctionsAction, Schema: validateSchema}
+	return &cisEdgeFunctionsActionValidator
+}
+
+// cisEdgeFunctionsActionContent returns the script given inline, by
+// script_file or by script_dir. get is the Get of the resource data or diff.
+func cisEdgeFunctionsActionContent(get func(string) interface{}) (string, error) {
+	if file := get(cisEdgeFunctionsActionScriptFile).(string); file != "" {
+		return readCISEdgeFunctionsScriptFile(file)
+	}
+	if dir := get(cisEdgeFunctionsActionScriptDir).(string); dir != "" {
+		return bundleCISEdgeFunctionsScriptDir(dir)
+	}
+	return get(cisEdgeFunctionsActionScript).(string), nil
+}
+
+// resourceIBMCISEdgeFunctionsActionCustomizeDiff plans an update when the hash
+// of the script differs from the hash of the deployed script, which is how
+// changes to script_file and script_dir are detected.
+func resourceIBMCISEdgeFunctionsActionCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{cisEdgeFunctionsActionScript, cisEdgeFunctionsActionScriptFile, cisEdgeFunctionsActionScriptDir} {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed(cisEdgeFunctionsActionScriptSHA256)
+		}
+	}
+	script, err := cisEdgeFunctionsActionContent(diff.Get)
+	if err != nil {
+		return err
+	}
+	if sha := cisEdgeFunctionsScriptSHA256(script); sha != diff.Get(cisEdgeFunctionsActionScriptSHA256).(string) {
+		return diff.SetNew(cisEdgeFunctionsActionScriptSHA256, sha)
+	}
+	return nil
+}
+
+func expandCISEdgeFunctionsBindings(d *schema.ResourceData) []cisEdgeFunctionsBinding {
+	bindings := []cisEdgeFunctionsBinding{}
+	for _, v := range d.Get(cisEdgeFunctionsActionBindings).(*schema.Set).List() {
+		binding := v.(map[string]interface{})
+		bindings = append(bindings, cisEdgeFunctionsBinding{
+			Name: binding[cisEdgeFunctionsActionBindingName].(string),
+			Type: binding[cisEdgeFunctionsActionBindingType].(string),
+			Text: binding[cisEdgeFunctionsActionBindingText].(string),
+		})
+	}
+	return bindings
+}
+
 func resourceIBMCISEdgeFunctionsActionCreate(d *schema.ResourceData, meta interface{}) error {
 	cisClient, err := meta.(ClientSession).CisEdgeFunctionClientSession()
 	if err != nil {
@@ -63,12 +174,16 @@ func resourceIBMCISEdgeFunctionsActionCreate(d *schema.ResourceData, meta interf
 	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
 
 	scriptName := d.Get(cisEdgeFunctionsActionActionName).(string)
-	script := d.Get(cisEdgeFunctionsActionScript).(string)
-	r := ioutil.NopCloser(strings.NewReader(script))
-	opt := cisClient.NewUpdateEdgeFunctionsActionOptions(scriptName)
-	opt.SetEdgeFunctionsAction(r)
+	script, err := cisEdgeFunctionsActionContent(d.Get)
+	if err != nil {
+		return err
+	}
+	// The files of script_file and script_dir are read again on apply
+	if planned := d.Get(cisEdgeFunctionsActionScriptSHA256).(string); planned != "" && planned != cisEdgeFunctionsScriptSHA256(script) {
+		return fmt.Errorf("Edge function action script %s changed after the plan was made", scriptName)
+	}
 
-	_, _, err = cisClient.UpdateEdgeFunctionsAction(opt)
+	_, err = uploadCISEdgeFunctionsAction(cisClient, scriptName, script, expandCISEdgeFunctionsBindings(d))
 	if err != nil {
 		return fmt.Errorf("Error: %v", err)
 	}
@@ -77,7 +192,8 @@ func resourceIBMCISEdgeFunctionsActionCreate(d *schema.ResourceData, meta interf
 }
 
 func resourceIBMCISEdgeFunctionsActionUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange(cisEdgeFunctionsActionScript) {
+	if d.HasChange(cisEdgeFunctionsActionScript) || d.HasChange(cisEdgeFunctionsActionScriptSHA256) ||
+		d.HasChange(cisEdgeFunctionsActionBindings) {
 		return resourceIBMCISEdgeFunctionsActionCreate(d, meta)
 	}
 
@@ -94,31 +210,16 @@ func resourceIBMCISEdgeFunctionsActionRead(d *schema.ResourceData, meta interfac
 	cisClient.Crn = core.StringPtr(crn)
 	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
 
-	opt := cisClient.NewGetEdgeFunctionsActionOptions(scriptName)
-	result, resp, err := cisClient.GetEdgeFunctionsAction(opt)
+	content, resp, err := getCISEdgeFunctionsActionScript(cisClient, scriptName)
 	if err != nil {
 		return fmt.Errorf("Error: %v", resp)
 	}
 
-	// read script content
-	content := []byte{}
-	p := make([]byte, 8)
-	for {
-		n, err := result.Read(p)
-		content = append(content, p[:n]...)
-		if err == io.EOF || n < 1 {
-			break
-		}
-	}
-	err = result.Close()
-	if err != nil {
-		return fmt.Errorf("Error in closing reader")
-	}
-
 	d.Set(cisID, crn)
 	d.Set(cisDomainID, zoneID)
 	d.Set(cisEdgeFunctionsActionActionName, scriptName)
-	d.Set(cisEdgeFunctionsActionScript, string(content))
+	d.Set(cisEdgeFunctionsActionScript, content)
+	d.Set(cisEdgeFunctionsActionScriptSHA256, cisEdgeFunctionsScriptSHA256(content))
 	return nil
 }
 
@@ -2,6 +2,9 @@ package ibm
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/IBM/go-sdk-core/v4/core"
@@ -46,6 +49,57 @@ func TestAccIBMCisEdgeFunctionsAction_Basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMCisEdgeFunctionsAction_ScriptDir(t *testing.T) {
+	name := "ibm_cis_edge_functions_action.test"
+	actionName := "sample_bundle"
+	bundle, err := bundleCISEdgeFunctionsScriptDir("test-fixtures/edge_functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := readCISEdgeFunctionsScriptFile("test-fixtures/edge_functions/index.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCis(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMCisEdgeFunctionsActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisEdgeFunctionsActionScriptDir(actionName, "Hello"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "action_name", actionName),
+					resource.TestCheckResourceAttr(name, "script", bundle),
+					resource.TestCheckResourceAttr(name, "script_sha256", cisEdgeFunctionsScriptSHA256(bundle)),
+					resource.TestCheckResourceAttr(name, "bindings.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisEdgeFunctionsActionScriptDir(actionName, "Hi"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "script_sha256", cisEdgeFunctionsScriptSHA256(bundle)),
+					resource.TestCheckResourceAttr(name, "bindings.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisEdgeFunctionsActionScriptFile(actionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "script", index),
+					resource.TestCheckResourceAttr(name, "script_sha256", cisEdgeFunctionsScriptSHA256(index)),
+					resource.TestCheckResourceAttr(name, "bindings.#", "0"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"script_file"},
+			},
+		},
+	})
+}
+
 func TestAccIBMCisEdgeFunctionsAction_import(t *testing.T) {
 	name := "ibm_cis_edge_functions_action.test"
 	actionName := "sample_script"
@@ -202,3 +256,85 @@ func testAccCheckIBMCisEdgeFunctionsActionUpdate(testName, actionName string) st
 	  }
 	  `, testName, actionName, content)
 }
+
+func testAccCheckIBMCisEdgeFunctionsActionScriptDir(actionName, greeting string) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	resource "ibm_cis_edge_functions_action" "test" {
+		cis_id      = data.ibm_cis.cis.id
+		domain_id   = data.ibm_cis_domain.cis_domain.domain_id
+		action_name = "%[1]s"
+		script_dir  = "test-fixtures/edge_functions"
+		bindings {
+			name = "GREETING"
+			text = "%[2]s"
+		}
+	  }
+	  `, actionName, greeting)
+}
+
+func testAccCheckIBMCisEdgeFunctionsActionScriptFile(actionName string) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	resource "ibm_cis_edge_functions_action" "test" {
+		cis_id      = data.ibm_cis.cis.id
+		domain_id   = data.ibm_cis_domain.cis_domain.domain_id
+		action_name = "%[1]s"
+		script_file = "test-fixtures/edge_functions/index.js"
+	  }
+	  `, actionName)
+}
+
+func TestBundleCISEdgeFunctionsScriptDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "edge_functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"index.js":                  "addEventListener('fetch', handle)\r\n",
+		"lib/handler.js":            "function handle(event) {}",
+		"a.js":                      "const a = 1\n",
+		"README.md":                 "not a script",
+		".hidden.js":                "hidden",
+		".cache/cached.js":          "cached",
+		"node_modules/dep/index.js": "dependency",
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bundle, err := bundleCISEdgeFunctionsScriptDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "// a.js\nconst a = 1\n\n// index.js\naddEventListener('fetch', handle)\n\n// lib/handler.js\nfunction handle(event) {}\n"
+	if bundle != expected {
+		t.Errorf("bundleCISEdgeFunctionsScriptDir: got %q, want %q", bundle, expected)
+	}
+
+	empty, err := ioutil.TempDir("", "edge_functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(empty)
+	if _, err := bundleCISEdgeFunctionsScriptDir(empty); err == nil {
+		t.Errorf("bundleCISEdgeFunctionsScriptDir(%s): expected an error for a directory without scripts", empty)
+	}
+}
+
+func TestCISEdgeFunctionsScriptSHA256(t *testing.T) {
+	unix := cisEdgeFunctionsScriptSHA256("addEventListener('fetch', handle)\n")
+	windows := cisEdgeFunctionsScriptSHA256("addEventListener('fetch', handle)\r\n")
+	if unix != windows {
+		t.Errorf("cisEdgeFunctionsScriptSHA256: got %s and %s for the same script with different line endings", unix, windows)
+	}
+	if len(unix) != 64 {
+		t.Errorf("cisEdgeFunctionsScriptSHA256: got %s, want a hex encoded SHA-256", unix)
+	}
+}
@@ -0,0 +1,194 @@
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	cisFilterID          = "filter_id"
+	cisFilterExpression  = "expression"
+	cisFilterPaused      = "paused"
+	cisFilterDescription = "description"
+)
+
+func resourceIBMCISFilter() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMCISFilterCreate,
+		Read:     resourceIBMCISFilterRead,
+		Update:   resourceIBMCISFilterUpdate,
+		Delete:   resourceIBMCISFilterDelete,
+		Exists:   resourceIBMCISFilterExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+				ForceNew:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisFilterExpression: {
+				Type:         schema.TypeString,
+				Description:  "Filter expression, e.g. http.request.uri.path contains \"/admin\"",
+				Required:     true,
+				ValidateFunc: validateCISFilterExpressionFunc,
+				StateFunc: func(v interface{}) string {
+					return strings.TrimSpace(v.(string))
+				},
+			},
+			cisFilterPaused: {
+				Type:        schema.TypeBool,
+				Description: "Filter is paused",
+				Optional:    true,
+				Default:     false,
+			},
+			cisFilterDescription: {
+				Type:        schema.TypeString,
+				Description: "Filter description",
+				Optional:    true,
+			},
+			cisFilterID: {
+				Type:        schema.TypeString,
+				Description: "Filter identifier",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMCISFilterCreate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	filter := cisFilter{
+		Expression: core.StringPtr(strings.TrimSpace(d.Get(cisFilterExpression).(string))),
+		Paused:     core.BoolPtr(d.Get(cisFilterPaused).(bool)),
+	}
+	if v, ok := d.GetOk(cisFilterDescription); ok {
+		filter.Description = core.StringPtr(v.(string))
+	}
+	result, response, err := createCISFilter(cisClient, filter)
+	if err != nil {
+		return fmt.Errorf("Error creating filter: %s\n%s", err, response)
+	}
+	d.SetId(convertCisToTfThreeVar(*result.ID, zoneID, crn))
+	return resourceIBMCISFilterRead(d, meta)
+}
+
+func resourceIBMCISFilterRead(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	filterID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	filter, response, err := getCISFilter(cisClient, filterID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Filter %s not found", filterID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting filter %s: %s\n%s", filterID, err, response)
+	}
+	d.Set(cisID, crn)
+	d.Set(cisDomainID, zoneID)
+	d.Set(cisFilterID, filter.ID)
+	d.Set(cisFilterExpression, filter.Expression)
+	d.Set(cisFilterPaused, filter.Paused)
+	d.Set(cisFilterDescription, filter.Description)
+	return nil
+}
+
+func resourceIBMCISFilterUpdate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	filterID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	if d.HasChange(cisFilterExpression) || d.HasChange(cisFilterPaused) || d.HasChange(cisFilterDescription) {
+		filter := cisFilter{
+			ID:          core.StringPtr(filterID),
+			Expression:  core.StringPtr(strings.TrimSpace(d.Get(cisFilterExpression).(string))),
+			Paused:      core.BoolPtr(d.Get(cisFilterPaused).(bool)),
+			Description: core.StringPtr(d.Get(cisFilterDescription).(string)),
+		}
+		_, response, err := updateCISFilter(cisClient, filter)
+		if err != nil {
+			return fmt.Errorf("Error updating filter %s: %s\n%s", filterID, err, response)
+		}
+	}
+	return resourceIBMCISFilterRead(d, meta)
+}
+
+func resourceIBMCISFilterDelete(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	filterID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	response, err := deleteCISFilter(cisClient, filterID)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		return fmt.Errorf("Error deleting filter %s: %s\n%s", filterID, err, response)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMCISFilterExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return false, err
+	}
+	filterID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return false, err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	_, response, err := getCISFilter(cisClient, filterID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Filter %s not found", filterID)
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting filter %s: %s\n%s", filterID, err, response)
+	}
+	return true, nil
+}
@@ -0,0 +1,171 @@
+package ibm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMCisFilter_Basic(t *testing.T) {
+	name := "ibm_cis_filter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCis(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMCisFilterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisFilterConfigBasic(`http.request.uri.path contains \"/admin\"`, "admin pages"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMCisFilterExists(name),
+					resource.TestCheckResourceAttr(name, "expression", `http.request.uri.path contains "/admin"`),
+					resource.TestCheckResourceAttr(name, "description", "admin pages"),
+					resource.TestCheckResourceAttr(name, "paused", "false"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisFilterConfigBasic(`http.request.uri.path contains \"/admin\" and not ip.src in {10.0.0.0/8}`, "admin pages from outside"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMCisFilterExists(name),
+					resource.TestCheckResourceAttr(name, "expression", `http.request.uri.path contains "/admin" and not ip.src in {10.0.0.0/8}`),
+					resource.TestCheckResourceAttr(name, "description", "admin pages from outside"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisFilterDestroy(s *terraform.State) error {
+	cisClient, err := testAccProvider.Meta().(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_cis_filter" {
+			continue
+		}
+		filterID, zoneID, crn, _ := convertTfToCisThreeVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+		_, _, err := getCISFilter(cisClient, filterID)
+		if err == nil {
+			return fmt.Errorf("Filter still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMCisFilterExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Filter ID is set")
+		}
+		cisClient, err := testAccProvider.Meta().(ClientSession).CisLockdownClientSession()
+		if err != nil {
+			return err
+		}
+		filterID, zoneID, crn, _ := convertTfToCisThreeVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+		_, _, err = getCISFilter(cisClient, filterID)
+		return err
+	}
+}
+
+func testAccCheckIBMCisFilterConfigBasic(expression, description string) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	resource "ibm_cis_filter" "test" {
+		cis_id      = data.ibm_cis.cis.id
+		domain_id   = data.ibm_cis_domain.cis_domain.domain_id
+		expression  = "%[1]s"
+		description = "%[2]s"
+	}`, expression, description)
+}
+
+func TestValidateCISFilterExpression(t *testing.T) {
+	valid := []string{
+		`http.request.uri.path contains "/admin"`,
+		`http.request.uri.path eq "/login" and http.request.method eq "POST"`,
+		`(http.host eq "example.com" or http.host == "www.example.com") && !ssl`,
+		`ip.src in {192.168.0.1 10.0.0.0/8 2001:db8::/32} xor cf.client.bot`,
+		`ip.src ne 192.168.0.1`,
+		`ip.geoip.country in {"CN" "RU"} and cf.threat_score gt 10`,
+		`cf.edge.server_port in {80 443 8000..8999}`,
+		`lower(http.user_agent) matches "(curl|wget)/[0-9.]+"`,
+		`len(http.request.uri.query) >= 1024`,
+		`http.user_agent ~ "^Mozilla" and not http.cookie contains "session=\"x\""`,
+		`ssl`,
+		`not not ssl`,
+		`cf.bot_management.score lt 30 and ip.src.asnum in {13335 15169}`,
+		`http.request.timestamp.sec ge 1600000000`,
+	}
+	for _, expression := range valid {
+		if unknown, err := validateCISFilterExpression(expression); err != nil || len(unknown) != 0 {
+			t.Errorf("validateCISFilterExpression(%q): unexpected error %v or unknown fields %v", expression, err, unknown)
+		}
+	}
+
+	// Fields the provider doesn't know are accepted with any value
+	unknownFields := map[string][]string{
+		`http.request.uri.args["id"][0] eq "1"`:                                     {"http.request.uri.args"},
+		`lower(http.request.headers["x-api-key"][0]) contains "x" and ssl`:          {"http.request.headers"},
+		`cf.tls_client_auth.cert_verified`:                                          {"cf.tls_client_auth.cert_verified"},
+		`http.request.headers.names[*] eq "x-api-key"`:                              {"http.request.headers.names"},
+		`cf.edge.server_port eq 443 or cf.waf.score le 20`:                          {"cf.waf.score"},
+		`http.request.uri.pth contains "/admin" and ip.dst in {10.0.0.0/8 1.2.3.4}`: {"http.request.uri.pth", "ip.dst"},
+	}
+	for expression, expected := range unknownFields {
+		unknown, err := validateCISFilterExpression(expression)
+		if err != nil || !reflect.DeepEqual(unknown, expected) {
+			t.Errorf("validateCISFilterExpression(%q): got %v, %v, want unknown fields %v", expression, unknown, err, expected)
+		}
+	}
+	if ws, errs := validateCISFilterExpressionFunc(`cf.waf.score le 20`, "expression"); len(errs) != 0 || len(ws) != 1 || !strings.Contains(ws[0], "cf.waf.score") {
+		t.Errorf("expected a warning for cf.waf.score, got %v, %v", ws, errs)
+	}
+
+	invalid := map[string]string{
+		``:                                         "expression is empty",
+		`request_uri contains "/admin"`:            `unknown field "request_uri" at position 1`,
+		`http.request.uri.args[ eq "1"`:            `expected an index of http.request.uri.args, found "eq" at position 24`,
+		`http.request.uri.args["id" eq "1"`:        `expected "]", found "eq" at position 28`,
+		`http.request.uri.path contains /admin`:    "unexpected character '/' at position 32",
+		`http.request.uri.path contains "/admin`:   "unterminated string at position 32",
+		`http.host`:                                "http.host is a string and needs a comparison at position 1",
+		`ssl eq "on"`:                              `ssl is a boolean and can't be compared at position 5`,
+		`ip.src contains "10."`:                    "contains only applies to strings, but ip.src is an IP address at position 8",
+		`ip.src gt 10.0.0.1`:                       "gt doesn't apply to IP addresses at position 8",
+		`ip.src eq 10.0.0.0/8`:                     `ip.src is an IP address and can't be compared with "10.0.0.0/8" at position 11`,
+		`ip.src in {}`:                             "the set of values of ip.src is empty at position 12",
+		`ip.src in {10.0.0.1`:                      `expected "}", found end of expression at position 20`,
+		`cf.threat_score gt "10"`:                  `cf.threat_score is a number and can't be compared with "10" at position 20`,
+		`cf.threat_score eq 1..10`:                 `cf.threat_score is a number and can't be compared with "1..10" at position 20`,
+		`http.user_agent matches "(curl"`:          "invalid regular expression \"(curl\": error parsing regexp: missing closing ): `(curl` at position 25",
+		`len(ip.src) gt 1`:                         "len() takes a string, but ip.src is an IP address at position 1",
+		`(ssl or cf.client.bot`:                    `expected ")", found end of expression at position 22`,
+		`ssl cf.client.bot`:                        `expected "and", "or" or "xor", found "cf.client.bot" at position 5`,
+		`ssl and`:                                  "expected a field, found end of expression at position 8",
+		`http.host eq "example.com" ; ssl`:         "unexpected character ';' at position 28",
+		`http.host eq "a" and http.host eq "b" or`: "expected a field, found end of expression at position 41",
+	}
+	for expression, expected := range invalid {
+		_, err := validateCISFilterExpression(expression)
+		if err == nil || err.Error() != expected {
+			t.Errorf("validateCISFilterExpression(%q): got error %v, want %q", expression, err, expected)
+		}
+	}
+}
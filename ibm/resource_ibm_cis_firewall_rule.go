@@ -0,0 +1,282 @@
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	ibmCISFirewallRule             = "ibm_cis_firewall_rule"
+	cisFirewallRuleID              = "firewall_rule_id"
+	cisFirewallRuleFilterID        = "filter_id"
+	cisFirewallRuleAction          = "action"
+	cisFirewallRuleActionBypass    = "bypass"
+	cisFirewallRulePriority        = "priority"
+	cisFirewallRulePaused          = "paused"
+	cisFirewallRuleDescription     = "description"
+	cisFirewallRuleProducts        = "products"
+	cisFirewallRuleMaxPriority     = "2147483647"
+	cisFirewallRuleAllowedActions  = "log, allow, challenge, js_challenge, block, bypass"
+	cisFirewallRuleAllowedProducts = "zoneLockdown, uaBlock, bic, hot, securityLevel, rateLimit, waf"
+)
+
+func resourceIBMCISFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceIBMCISFirewallRuleCreate,
+		Read:          resourceIBMCISFirewallRuleRead,
+		Update:        resourceIBMCISFirewallRuleUpdate,
+		Delete:        resourceIBMCISFirewallRuleDelete,
+		Exists:        resourceIBMCISFirewallRuleExists,
+		CustomizeDiff: resourceIBMCISFirewallRuleCustomizeDiff,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+				ForceNew:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisFirewallRuleFilterID: {
+				Type:             schema.TypeString,
+				Description:      "Filter that selects the requests the rule applies to",
+				Required:         true,
+				DiffSuppressFunc: suppressCISFilterIDDiff,
+			},
+			cisFirewallRuleAction: {
+				Type:         schema.TypeString,
+				Description:  "Action applied to the requests matched by the filter. Allowable values are " + cisFirewallRuleAllowedActions,
+				Required:     true,
+				ValidateFunc: InvokeValidator(ibmCISFirewallRule, cisFirewallRuleAction),
+			},
+			cisFirewallRulePriority: {
+				Type:         schema.TypeInt,
+				Description:  "Priority of the rule, lower numbers are evaluated first. Rules without priority are evaluated last",
+				Optional:     true,
+				ValidateFunc: InvokeValidator(ibmCISFirewallRule, cisFirewallRulePriority),
+			},
+			cisFirewallRulePaused: {
+				Type:        schema.TypeBool,
+				Description: "Firewall rule is paused",
+				Optional:    true,
+				Default:     false,
+			},
+			cisFirewallRuleDescription: {
+				Type:        schema.TypeString,
+				Description: "Firewall rule description",
+				Optional:    true,
+			},
+			cisFirewallRuleProducts: {
+				Type:        schema.TypeSet,
+				Description: "Security features that a bypass rule skips. Allowable values are " + cisFirewallRuleAllowedProducts,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: InvokeValidator(ibmCISFirewallRule, cisFirewallRuleProducts),
+				},
+				Set: schema.HashString,
+			},
+			cisFirewallRuleID: {
+				Type:        schema.TypeString,
+				Description: "Firewall rule identifier",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMCISFirewallRuleValidator() *ResourceValidator {
+	validateSchema := make([]ValidateSchema, 1)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisFirewallRuleAction,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Required:                   true,
+			AllowedValues:              cisFirewallRuleAllowedActions})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisFirewallRulePriority,
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			Optional:                   true,
+			MinValue:                   "1",
+			MaxValue:                   cisFirewallRuleMaxPriority})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisFirewallRuleProducts,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Optional:                   true,
+			AllowedValues:              cisFirewallRuleAllowedProducts})
+	cisFirewallRuleValidator := ResourceValidator{ResourceName: ibmCISFirewallRule, Schema: validateSchema}
+	return &cisFirewallRuleValidator
+}
+
+// suppressCISFilterIDDiff lets filter_id be given either as the id of the
+// filter or as the id of the ibm_cis_filter resource.
+func suppressCISFilterIDDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.SplitN(old, ":", 2)[0] == strings.SplitN(new, ":", 2)[0]
+}
+
+func resourceIBMCISFirewallRuleCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown(cisFirewallRuleAction) || !diff.NewValueKnown(cisFirewallRuleProducts) {
+		return nil
+	}
+	bypass := diff.Get(cisFirewallRuleAction).(string) == cisFirewallRuleActionBypass
+	products := diff.Get(cisFirewallRuleProducts).(*schema.Set).Len() > 0
+	if bypass && !products {
+		return fmt.Errorf("%s must be set when %s is %s", cisFirewallRuleProducts, cisFirewallRuleAction, cisFirewallRuleActionBypass)
+	}
+	if !bypass && products {
+		return fmt.Errorf("%s can only be set when %s is %s", cisFirewallRuleProducts, cisFirewallRuleAction, cisFirewallRuleActionBypass)
+	}
+	return nil
+}
+
+func expandCISFirewallRule(d *schema.ResourceData) cisFirewallRule {
+	filterID := strings.SplitN(d.Get(cisFirewallRuleFilterID).(string), ":", 2)[0]
+	rule := cisFirewallRule{
+		Filter:      &cisFilter{ID: core.StringPtr(filterID)},
+		Action:      core.StringPtr(d.Get(cisFirewallRuleAction).(string)),
+		Paused:      core.BoolPtr(d.Get(cisFirewallRulePaused).(bool)),
+		Description: core.StringPtr(d.Get(cisFirewallRuleDescription).(string)),
+		Products:    expandStringList(d.Get(cisFirewallRuleProducts).(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk(cisFirewallRulePriority); ok {
+		rule.Priority = core.Int64Ptr(int64(v.(int)))
+	}
+	return rule
+}
+
+func resourceIBMCISFirewallRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	result, response, err := createCISFirewallRule(cisClient, expandCISFirewallRule(d))
+	if err != nil {
+		return fmt.Errorf("Error creating firewall rule: %s\n%s", err, response)
+	}
+	d.SetId(convertCisToTfThreeVar(*result.ID, zoneID, crn))
+	return resourceIBMCISFirewallRuleRead(d, meta)
+}
+
+func resourceIBMCISFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	ruleID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	rule, response, err := getCISFirewallRule(cisClient, ruleID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Firewall rule %s not found", ruleID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting firewall rule %s: %s\n%s", ruleID, err, response)
+	}
+	d.Set(cisID, crn)
+	d.Set(cisDomainID, zoneID)
+	d.Set(cisFirewallRuleID, rule.ID)
+	if rule.Filter != nil {
+		d.Set(cisFirewallRuleFilterID, rule.Filter.ID)
+	}
+	d.Set(cisFirewallRuleAction, rule.Action)
+	d.Set(cisFirewallRulePriority, rule.Priority)
+	d.Set(cisFirewallRulePaused, rule.Paused)
+	d.Set(cisFirewallRuleDescription, rule.Description)
+	d.Set(cisFirewallRuleProducts, rule.Products)
+	return nil
+}
+
+func resourceIBMCISFirewallRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	ruleID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	if d.HasChange(cisFirewallRuleFilterID) || d.HasChange(cisFirewallRuleAction) ||
+		d.HasChange(cisFirewallRulePriority) || d.HasChange(cisFirewallRulePaused) ||
+		d.HasChange(cisFirewallRuleDescription) || d.HasChange(cisFirewallRuleProducts) {
+		rule := expandCISFirewallRule(d)
+		rule.ID = core.StringPtr(ruleID)
+		_, response, err := updateCISFirewallRule(cisClient, rule)
+		if err != nil {
+			return fmt.Errorf("Error updating firewall rule %s: %s\n%s", ruleID, err, response)
+		}
+	}
+	return resourceIBMCISFirewallRuleRead(d, meta)
+}
+
+func resourceIBMCISFirewallRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	ruleID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	response, err := deleteCISFirewallRule(cisClient, ruleID)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		return fmt.Errorf("Error deleting firewall rule %s: %s\n%s", ruleID, err, response)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMCISFirewallRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	cisClient, err := meta.(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return false, err
+	}
+	ruleID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return false, err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+
+	_, response, err := getCISFirewallRule(cisClient, ruleID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Firewall rule %s not found", ruleID)
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting firewall rule %s: %s\n%s", ruleID, err, response)
+	}
+	return true, nil
+}
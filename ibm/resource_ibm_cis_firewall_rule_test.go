@@ -0,0 +1,113 @@
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMCisFirewallRule_Basic(t *testing.T) {
+	name := "ibm_cis_firewall_rule.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCis(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMCisFirewallRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisFirewallRuleConfigBasic("block", 10, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMCisFirewallRuleExists(name),
+					resource.TestCheckResourceAttr(name, "action", "block"),
+					resource.TestCheckResourceAttr(name, "priority", "10"),
+					resource.TestCheckResourceAttrPair(name, "filter_id", "ibm_cis_filter.test", "filter_id"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisFirewallRuleConfigBasic("bypass", 5, `products = ["waf", "rateLimit"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMCisFirewallRuleExists(name),
+					resource.TestCheckResourceAttr(name, "action", "bypass"),
+					resource.TestCheckResourceAttr(name, "priority", "5"),
+					resource.TestCheckResourceAttr(name, "products.#", "2"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIBMCisFirewallRule_BypassWithoutProducts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckIBMCisFirewallRuleConfigBasic("bypass", 5, ""),
+				ExpectError: regexp.MustCompile("products must be set when action is bypass"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisFirewallRuleDestroy(s *terraform.State) error {
+	cisClient, err := testAccProvider.Meta().(ClientSession).CisLockdownClientSession()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_cis_firewall_rule" {
+			continue
+		}
+		ruleID, zoneID, crn, _ := convertTfToCisThreeVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+		_, _, err := getCISFirewallRule(cisClient, ruleID)
+		if err == nil {
+			return fmt.Errorf("Firewall rule still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMCisFirewallRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Firewall Rule ID is set")
+		}
+		cisClient, err := testAccProvider.Meta().(ClientSession).CisLockdownClientSession()
+		if err != nil {
+			return err
+		}
+		ruleID, zoneID, crn, _ := convertTfToCisThreeVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+		_, _, err = getCISFirewallRule(cisClient, ruleID)
+		return err
+	}
+}
+
+func testAccCheckIBMCisFirewallRuleConfigBasic(action string, priority int, products string) string {
+	return testAccCheckIBMCisFilterConfigBasic(`http.request.uri.path contains \"/admin\"`, "admin pages") + fmt.Sprintf(`
+	resource "ibm_cis_firewall_rule" "test" {
+		cis_id      = data.ibm_cis.cis.id
+		domain_id   = data.ibm_cis_domain.cis_domain.domain_id
+		filter_id   = ibm_cis_filter.test.id
+		action      = "%[1]s"
+		priority    = %[2]d
+		description = "firewall rule for admin pages"
+		%[3]s
+	}`, action, priority, products)
+}
@@ -0,0 +1,383 @@
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	ibmCISLogpushJob                = "ibm_cis_logpush_job"
+	cisLogpushJobID                 = "job_id"
+	cisLogpushJobName               = "name"
+	cisLogpushJobDataset            = "dataset"
+	cisLogpushJobFields             = "fields"
+	cisLogpushJobTimestamps         = "timestamps"
+	cisLogpushJobEnabled            = "enabled"
+	cisLogpushJobFrequency          = "frequency"
+	cisLogpushJobOwnershipChallenge = "ownership_challenge"
+	cisLogpushJobDestination        = "destination"
+	cisLogpushJobDestinationType    = "type"
+	cisLogpushJobBucketName         = "bucket_name"
+	cisLogpushJobRegion             = "region"
+	cisLogpushJobInstanceID         = "instance_id"
+	cisLogpushJobHostname           = "hostname"
+	cisLogpushJobIngressKey         = "ingress_key"
+	cisLogpushJobDestinationConf    = "destination_conf"
+	cisLogpushJobLastComplete       = "last_complete"
+	cisLogpushJobLastError          = "last_error"
+	cisLogpushJobErrorMessage       = "error_message"
+)
+
+func resourceIBMCISLogpushJob() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceIBMCISLogpushJobCreate,
+		Read:          resourceIBMCISLogpushJobRead,
+		Update:        resourceIBMCISLogpushJobUpdate,
+		Delete:        resourceIBMCISLogpushJobDelete,
+		Exists:        resourceIBMCISLogpushJobExists,
+		CustomizeDiff: resourceIBMCISLogpushJobCustomizeDiff,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			cisID: {
+				Type:        schema.TypeString,
+				Description: "CIS instance crn",
+				Required:    true,
+				ForceNew:    true,
+			},
+			cisDomainID: {
+				Type:             schema.TypeString,
+				Description:      "Associated CIS domain",
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDomainIDDiff,
+			},
+			cisLogpushJobName: {
+				Type:        schema.TypeString,
+				Description: "Logpush job name",
+				Optional:    true,
+			},
+			cisLogpushJobDataset: {
+				Type:         schema.TypeString,
+				Description:  "Dataset of the logs pushed by the job",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: InvokeValidator(ibmCISLogpushJob, cisLogpushJobDataset),
+			},
+			cisLogpushJobFields: {
+				Type:        schema.TypeList,
+				Description: "Fields of the dataset included in the logs",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			cisLogpushJobTimestamps: {
+				Type:         schema.TypeString,
+				Description:  "Format of the timestamps in the logs",
+				Optional:     true,
+				Default:      "rfc3339",
+				ValidateFunc: InvokeValidator(ibmCISLogpushJob, cisLogpushJobTimestamps),
+			},
+			cisLogpushJobDestination: {
+				Type:        schema.TypeList,
+				Description: "Destination the logs are pushed to",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						cisLogpushJobDestinationType: {
+							Type:         schema.TypeString,
+							Description:  "Destination type, cos or logdna",
+							Required:     true,
+							ValidateFunc: InvokeValidator(ibmCISLogpushJob, cisLogpushJobDestinationType),
+						},
+						cisLogpushJobBucketName: {
+							Type:        schema.TypeString,
+							Description: "COS bucket the logs are written to",
+							Optional:    true,
+						},
+						cisLogpushJobRegion: {
+							Type:        schema.TypeString,
+							Description: "Region of the COS bucket or of the LogDNA instance",
+							Required:    true,
+						},
+						cisLogpushJobInstanceID: {
+							Type:        schema.TypeString,
+							Description: "GUID of the COS instance",
+							Optional:    true,
+						},
+						cisLogpushJobHostname: {
+							Type:        schema.TypeString,
+							Description: "Hostname the logs are attached to in LogDNA",
+							Optional:    true,
+						},
+						cisLogpushJobIngressKey: {
+							Type:        schema.TypeString,
+							Description: "Ingestion key of the LogDNA instance",
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			cisLogpushJobEnabled: {
+				Type:        schema.TypeBool,
+				Description: "Logpush job is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			cisLogpushJobFrequency: {
+				Type:         schema.TypeString,
+				Description:  "How often the logs are pushed, high or low",
+				Optional:     true,
+				Default:      "high",
+				ValidateFunc: InvokeValidator(ibmCISLogpushJob, cisLogpushJobFrequency),
+			},
+			cisLogpushJobOwnershipChallenge: {
+				Type:        schema.TypeString,
+				Description: "Token proving the ownership of the destination",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			cisLogpushJobID: {
+				Type:        schema.TypeInt,
+				Description: "Logpush job identifier",
+				Computed:    true,
+			},
+			cisLogpushJobDestinationConf: {
+				Type:        schema.TypeString,
+				Description: "Destination of the job as reported by CIS",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			cisLogpushJobLastComplete: {
+				Type:        schema.TypeString,
+				Description: "Time the job last pushed logs successfully",
+				Computed:    true,
+			},
+			cisLogpushJobLastError: {
+				Type:        schema.TypeString,
+				Description: "Time the job last failed to push logs",
+				Computed:    true,
+			},
+			cisLogpushJobErrorMessage: {
+				Type:        schema.TypeString,
+				Description: "Reason of the last failure of the job",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMCISLogpushJobValidator() *ResourceValidator {
+	validateSchema := make([]ValidateSchema, 1)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisLogpushJobDataset,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Required:                   true,
+			AllowedValues:              "http_requests, range_events, firewall_events"})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisLogpushJobTimestamps,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Optional:                   true,
+			AllowedValues:              "rfc3339, unix, unixnano"})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisLogpushJobDestinationType,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Required:                   true,
+			AllowedValues:              cisLogpushDestinationCOS + ", " + cisLogpushDestinationLogDNA})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 cisLogpushJobFrequency,
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Optional:                   true,
+			AllowedValues:              "high, low"})
+	cisLogpushJobValidator := ResourceValidator{ResourceName: ibmCISLogpushJob, Schema: validateSchema}
+	return &cisLogpushJobValidator
+}
+
+func resourceIBMCISLogpushJobCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	destinations := diff.Get(cisLogpushJobDestination).([]interface{})
+	if len(destinations) == 0 || destinations[0] == nil {
+		return nil
+	}
+	destination := destinations[0].(map[string]interface{})
+	// Values that are only known after apply, e.g. the guid of a COS
+	// instance created in the same plan, are validated on the next plan.
+	for key := range destination {
+		if !diff.NewValueKnown(fmt.Sprintf("%s.0.%s", cisLogpushJobDestination, key)) {
+			return nil
+		}
+	}
+	return validateCISLogpushDestination(destination)
+}
+
+func expandCISLogpushJob(d *schema.ResourceData) cisLogpushJobRequest {
+	job := cisLogpushJobRequest{
+		Enabled:        core.BoolPtr(d.Get(cisLogpushJobEnabled).(bool)),
+		Frequency:      core.StringPtr(d.Get(cisLogpushJobFrequency).(string)),
+		LogpullOptions: core.StringPtr(cisLogpullOptions(expandStringList(d.Get(cisLogpushJobFields).([]interface{})), d.Get(cisLogpushJobTimestamps).(string))),
+	}
+	if v, ok := d.GetOk(cisLogpushJobName); ok {
+		job.Name = core.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk(cisLogpushJobOwnershipChallenge); ok {
+		job.OwnershipChallenge = core.StringPtr(v.(string))
+	}
+	destination := d.Get(cisLogpushJobDestination).([]interface{})[0].(map[string]interface{})
+	if destination[cisLogpushJobDestinationType].(string) == cisLogpushDestinationCOS {
+		job.Cos = map[string]string{
+			"bucket_name": destination[cisLogpushJobBucketName].(string),
+			"region":      destination[cisLogpushJobRegion].(string),
+			"id":          destination[cisLogpushJobInstanceID].(string),
+		}
+	} else {
+		job.Logdna = map[string]string{
+			"hostname":    destination[cisLogpushJobHostname].(string),
+			"ingress_key": destination[cisLogpushJobIngressKey].(string),
+			"region":      destination[cisLogpushJobRegion].(string),
+		}
+	}
+	return job
+}
+
+func resourceIBMCISLogpushJobCreate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return err
+	}
+	crn := d.Get(cisID).(string)
+	zoneID, _, _ := convertTftoCisTwoVar(d.Get(cisDomainID).(string))
+	cisClient.Crn = core.StringPtr(crn)
+
+	job := expandCISLogpushJob(d)
+	job.Dataset = core.StringPtr(d.Get(cisLogpushJobDataset).(string))
+	result, response, err := createCISLogpushJob(cisClient, zoneID, job)
+	if err != nil {
+		return fmt.Errorf("Error creating logpush job: %s\n%s", err, response)
+	}
+	d.SetId(convertCisToTfThreeVar(strconv.FormatInt(*result.ID, 10), zoneID, crn))
+	return resourceIBMCISLogpushJobRead(d, meta)
+}
+
+func resourceIBMCISLogpushJobRead(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return err
+	}
+	jobID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+
+	job, response, err := getCISLogpushJob(cisClient, zoneID, jobID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Logpush job %s not found", jobID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting logpush job %s: %s\n%s", jobID, err, response)
+	}
+	d.Set(cisID, crn)
+	d.Set(cisDomainID, zoneID)
+	d.Set(cisLogpushJobID, job.ID)
+	d.Set(cisLogpushJobName, job.Name)
+	d.Set(cisLogpushJobDataset, job.Dataset)
+	d.Set(cisLogpushJobEnabled, job.Enabled)
+	d.Set(cisLogpushJobFrequency, job.Frequency)
+	if job.LogpullOptions != nil {
+		fields, timestamps := parseCISLogpullOptions(*job.LogpullOptions)
+		d.Set(cisLogpushJobFields, fields)
+		if timestamps != "" {
+			d.Set(cisLogpushJobTimestamps, timestamps)
+		}
+	}
+	d.Set(cisLogpushJobDestinationConf, job.DestinationConf)
+	if job.DestinationConf != nil {
+		ingressKey := d.Get(fmt.Sprintf("%s.0.%s", cisLogpushJobDestination, cisLogpushJobIngressKey)).(string)
+		if destination := flattenCISLogpushDestination(*job.DestinationConf, ingressKey); destination != nil {
+			d.Set(cisLogpushJobDestination, []interface{}{destination})
+		}
+	}
+	d.Set(cisLogpushJobLastComplete, job.LastComplete)
+	d.Set(cisLogpushJobLastError, job.LastError)
+	d.Set(cisLogpushJobErrorMessage, job.ErrorMessage)
+	return nil
+}
+
+func resourceIBMCISLogpushJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return err
+	}
+	jobID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+
+	if d.HasChange(cisLogpushJobName) || d.HasChange(cisLogpushJobFields) ||
+		d.HasChange(cisLogpushJobTimestamps) || d.HasChange(cisLogpushJobDestination) ||
+		d.HasChange(cisLogpushJobEnabled) || d.HasChange(cisLogpushJobFrequency) ||
+		d.HasChange(cisLogpushJobOwnershipChallenge) {
+		_, response, err := updateCISLogpushJob(cisClient, zoneID, jobID, expandCISLogpushJob(d))
+		if err != nil {
+			return fmt.Errorf("Error updating logpush job %s: %s\n%s", jobID, err, response)
+		}
+	}
+	return resourceIBMCISLogpushJobRead(d, meta)
+}
+
+func resourceIBMCISLogpushJobDelete(d *schema.ResourceData, meta interface{}) error {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return err
+	}
+	jobID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+
+	response, err := deleteCISLogpushJob(cisClient, zoneID, jobID)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		return fmt.Errorf("Error deleting logpush job %s: %s\n%s", jobID, err, response)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMCISLogpushJobExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	cisClient, err := meta.(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return false, err
+	}
+	jobID, zoneID, crn, err := convertTfToCisThreeVar(d.Id())
+	if err != nil {
+		return false, err
+	}
+	cisClient.Crn = core.StringPtr(crn)
+
+	_, response, err := getCISLogpushJob(cisClient, zoneID, jobID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Logpush job %s not found", jobID)
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting logpush job %s: %s\n%s", jobID, err, response)
+	}
+	return true, nil
+}
@@ -0,0 +1,233 @@
+package ibm
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMCisLogpushJob_Basic(t *testing.T) {
+	name := "ibm_cis_logpush_job.test"
+	serviceName := fmt.Sprintf("terraform_%d", acctest.RandIntRange(10, 100))
+	bucketName := fmt.Sprintf("terraform-logpush%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckCis(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMCisLogpushJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisLogpushJobConfigBasic(serviceName, bucketName, `["ClientIP", "ClientRequestHost"]`, "high"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "dataset", "http_requests"),
+					resource.TestCheckResourceAttr(name, "fields.#", "2"),
+					resource.TestCheckResourceAttr(name, "frequency", "high"),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(name, "job_id"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCisLogpushJobConfigBasic(serviceName, bucketName, `["ClientIP", "ClientRequestHost", "EdgeResponseStatus"]`, "low"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "fields.#", "3"),
+					resource.TestCheckResourceAttr(name, "fields.2", "EdgeResponseStatus"),
+					resource.TestCheckResourceAttr(name, "frequency", "low"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ownership_challenge"},
+			},
+		},
+	})
+}
+
+func TestAccIBMCisLogpushJob_InvalidDestination(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCis(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCisDomainDataSourceConfigBasic1() + `
+				resource "ibm_cis_logpush_job" "test" {
+					cis_id    = data.ibm_cis.cis.id
+					domain_id = data.ibm_cis_domain.cis_domain.domain_id
+					dataset   = "http_requests"
+					fields    = ["ClientIP"]
+					destination {
+						type        = "cos"
+						bucket_name = "logs"
+						region      = "us-south"
+						hostname    = "www.example.com"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("cos destination requires instance_id"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCisLogpushJobDestroy(s *terraform.State) error {
+	cisClient, err := testAccProvider.Meta().(ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_cis_logpush_job" {
+			continue
+		}
+		jobID, zoneID, crn, _ := convertTfToCisThreeVar(rs.Primary.ID)
+		cisClient.Crn = core.StringPtr(crn)
+		_, _, err := getCISLogpushJob(cisClient, zoneID, jobID)
+		if err == nil {
+			return fmt.Errorf("Logpush job still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMCisLogpushJobConfigBasic(serviceName, bucketName, fields, frequency string) string {
+	return testAccCheckIBMCisDomainDataSourceConfigBasic1() + fmt.Sprintf(`
+	data "ibm_resource_group" "group" {
+		name = "default"
+	}
+
+	resource "ibm_resource_instance" "instance" {
+		name              = "%[1]s"
+		service           = "cloud-object-storage"
+		plan              = "standard"
+		location          = "global"
+		resource_group_id = data.ibm_resource_group.group.id
+	}
+
+	resource "ibm_cos_bucket" "bucket" {
+		bucket_name          = "%[2]s"
+		resource_instance_id = ibm_resource_instance.instance.id
+		region_location      = "us-south"
+		storage_class        = "standard"
+	}
+
+	resource "ibm_cis_logpush_job" "test" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = data.ibm_cis_domain.cis_domain.domain_id
+		name      = "http-requests"
+		dataset   = "http_requests"
+		fields    = %[3]s
+		frequency = "%[4]s"
+		destination {
+			type        = "cos"
+			bucket_name = ibm_cos_bucket.bucket.bucket_name
+			region      = "us-south"
+			instance_id = ibm_resource_instance.instance.guid
+		}
+	}`, serviceName, bucketName, fields, frequency)
+}
+
+func TestFlattenCISLogpushDestination(t *testing.T) {
+	cos := flattenCISLogpushDestination("cos://logs-bucket?region=us-south&instance-id=231f5467-3072-4cb9-9e39-a906fa3032ea", "")
+	expected := map[string]interface{}{
+		"type":        "cos",
+		"bucket_name": "logs-bucket",
+		"region":      "us-south",
+		"instance_id": "231f5467-3072-4cb9-9e39-a906fa3032ea",
+		"hostname":    "",
+		"ingress_key": "",
+	}
+	if !reflect.DeepEqual(cos, expected) {
+		t.Errorf("expected %v, got %v", expected, cos)
+	}
+
+	logdna := flattenCISLogpushDestination("logdna://www.example.com?region=eu-de", "key")
+	expected = map[string]interface{}{
+		"type":        "logdna",
+		"bucket_name": "",
+		"region":      "eu-de",
+		"instance_id": "",
+		"hostname":    "www.example.com",
+		"ingress_key": "key",
+	}
+	if !reflect.DeepEqual(logdna, expected) {
+		t.Errorf("expected %v, got %v", expected, logdna)
+	}
+
+	for _, conf := range []string{"", "s3://bucket?region=us-east-1", "cos://?region=us-south", "%zz"} {
+		if destination := flattenCISLogpushDestination(conf, ""); destination != nil {
+			t.Errorf("expected no destination for %q, got %v", conf, destination)
+		}
+	}
+}
+
+func TestValidateCISLogpushDestination(t *testing.T) {
+	cos := map[string]interface{}{
+		"type":        "cos",
+		"bucket_name": "my-logs.example",
+		"region":      "us-south",
+		"instance_id": "2e0a2ff1-2d6c-4c06-9a8e-6e0c7a1a9a6b",
+	}
+	logdna := map[string]interface{}{
+		"type":        "logdna",
+		"hostname":    "www.example.com",
+		"ingress_key": "secret",
+		"region":      "eu-de",
+	}
+	with := func(destination map[string]interface{}, key, value string) map[string]interface{} {
+		result := map[string]interface{}{}
+		for k, v := range destination {
+			result[k] = v
+		}
+		result[key] = value
+		return result
+	}
+
+	for _, destination := range []map[string]interface{}{cos, logdna, with(cos, "region", "us"), with(cos, "bucket_name", "abc")} {
+		if err := validateCISLogpushDestination(destination); err != nil {
+			t.Errorf("validateCISLogpushDestination(%v): unexpected error %s", destination, err)
+		}
+	}
+
+	testcases := []struct {
+		destination map[string]interface{}
+		expected    string
+	}{
+		{with(cos, "type", "syslog"), `destination type must be cos or logdna, got "syslog"`},
+		{with(cos, "instance_id", ""), "cos destination requires instance_id"},
+		{with(logdna, "ingress_key", " "), "logdna destination requires ingress_key"},
+		{with(cos, "hostname", "www.example.com"), "hostname can't be set for a cos destination"},
+		{with(logdna, "bucket_name", "logs"), "bucket_name can't be set for a logdna destination"},
+		{with(cos, "region", "US South"), `invalid region "US South", expected a region such as us-south or a cross region such as us`},
+		{with(cos, "bucket_name", "My_Logs"), `invalid bucket_name "My_Logs", bucket names are 3 to 63 lowercase letters, digits, dots and dashes`},
+		{with(cos, "bucket_name", "my..logs"), `invalid bucket_name "my..logs", bucket names are 3 to 63 lowercase letters, digits, dots and dashes`},
+		{with(cos, "bucket_name", "192.168.0.1"), `invalid bucket_name "192.168.0.1", bucket names are 3 to 63 lowercase letters, digits, dots and dashes`},
+		{with(cos, "instance_id", "crn:v1:bluemix:public:cloud-object-storage"), `invalid instance_id "crn:v1:bluemix:public:cloud-object-storage", expected the GUID of the COS instance`},
+		{with(logdna, "hostname", "-www.example.com"), `invalid hostname "-www.example.com"`},
+	}
+	for _, tc := range testcases {
+		err := validateCISLogpushDestination(tc.destination)
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("validateCISLogpushDestination(%v): got error %v, want %q", tc.destination, err, tc.expected)
+		}
+	}
+}
+
+func TestCISLogpullOptions(t *testing.T) {
+	options := cisLogpullOptions([]string{"ClientIP", "ClientRequestHost"}, "unix")
+	if options != "fields=ClientIP,ClientRequestHost&timestamps=unix" {
+		t.Errorf("cisLogpullOptions: got %q", options)
+	}
+	fields, timestamps := parseCISLogpullOptions(options)
+	if !reflect.DeepEqual(fields, []string{"ClientIP", "ClientRequestHost"}) || timestamps != "unix" {
+		t.Errorf("parseCISLogpullOptions(%q): got %q and %q", options, fields, timestamps)
+	}
+	fields, timestamps = parseCISLogpullOptions("")
+	if len(fields) != 0 || timestamps != "" {
+		t.Errorf("parseCISLogpullOptions(\"\"): got %q and %q", fields, timestamps)
+	}
+}
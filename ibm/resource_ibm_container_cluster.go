@@ -59,7 +59,7 @@ func resourceIBMContainerCluster() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -388,6 +388,8 @@ func resourceIBMContainerCluster() *schema.Resource {
 				Description: "Tags for the resource",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			"worker_pools": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -792,7 +794,7 @@ func resourceIBMContainerClusterRead(d *schema.ResourceData, meta interface{}) e
 		log.Printf(
 			"An error occured during reading of instance (%s) tags : %s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -1285,8 +1287,8 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
-		oldList, newList := d.GetChange("tags")
+	if d.HasChange("tags") || d.HasChange(tagsAll) || d.IsNewResource() || v != "" {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		cluster, err := clusterAPI.Find(clusterID, targetEnv)
 		if err != nil {
 			return fmt.Errorf("Error retrieving cluster %s: %s", clusterID, err)
@@ -41,7 +41,7 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -203,6 +203,8 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 				Description: "List of tags for the resources",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			"wait_till": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -498,8 +500,8 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 	clusterID := d.Id()
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if d.HasChange("tags") || v != "" {
-		oldList, newList := d.GetChange("tags")
+	if d.HasChange("tags") || d.HasChange(tagsAll) || d.IsNewResource() || v != "" {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		cluster, err := csClient.Clusters().GetCluster(clusterID, targetEnv)
 		if err != nil {
 			return fmt.Errorf("Error retrieving cluster %s: %s", clusterID, err)
@@ -867,7 +869,7 @@ func resourceIBMContainerVpcClusterRead(d *schema.ResourceData, meta interface{}
 		log.Printf(
 			"An error occured during reading of instance (%s) tags : %s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
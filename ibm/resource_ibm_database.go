@@ -70,7 +70,7 @@ func resourceIBMDatabaseInstance() *schema.Resource {
 		},
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -204,6 +204,7 @@ func resourceIBMDatabaseInstance() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      resourceIBMVPCHash,
 			},
+			tagsAll: tagsAllSchema(),
 			"point_in_time_recovery_deployment_id": {
 				Description:      "The CRN of source instance",
 				Type:             schema.TypeString,
@@ -794,8 +795,8 @@ func resourceIBMDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(instance.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
-		oldList, newList := d.GetChange("tags")
+	if _, ok := d.GetOk("tags"); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, instance.Crn.String())
 		if err != nil {
 			log.Printf(
@@ -963,7 +964,7 @@ func resourceIBMDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) e
 		log.Printf(
 			"Error on get of ibm database tags (%s) tags: %s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	setResourceTags(d, meta, tags)
 	d.Set("name", instance.Name)
 	d.Set("status", instance.State)
 	d.Set("resource_group_id", instance.ResourceGroupID)
@@ -1105,9 +1106,9 @@ func resourceIBMDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChange("tags") || d.HasChange(tagsAll) {
 
-		oldList, newList := d.GetChange("tags")
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, instanceID)
 		if err != nil {
 			log.Printf(
@@ -74,7 +74,7 @@ func resourceIBMDLGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -332,6 +332,7 @@ func resourceIBMDLGateway() *schema.Resource {
 				Set:         resourceIBMVPCHash,
 				Description: "Tags for the direct link gateway",
 			},
+			tagsAll: tagsAllSchema(),
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -544,8 +545,8 @@ func resourceIBMdlGatewayCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(dlTags); ok || v != "" {
-		oldList, newList := d.GetChange(dlTags)
+	if _, ok := d.GetOk(dlTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *gateway.Crn)
 		if err != nil {
 			log.Printf(
@@ -706,7 +707,7 @@ func resourceIBMdlGatewayRead(d *schema.ResourceData, meta interface{}) error {
 		log.Printf(
 			"Error on get of resource direct link gateway (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(dlTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -773,8 +774,8 @@ func resourceIBMdlGatewayUpdate(d *schema.ResourceData, meta interface{}) error
 	updateGatewayOptionsModel.ID = &ID
 	dtype := *instance.Type
 
-	if d.HasChange(dlTags) {
-		oldList, newList := d.GetChange(dlTags)
+	if d.HasChange(dlTags) || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.Crn)
 		if err != nil {
 			log.Printf(
@@ -22,7 +22,7 @@ func resourceIBMDLProviderGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{},
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -113,6 +113,7 @@ func resourceIBMDLProviderGateway() *schema.Resource {
 				Set:         resourceIBMVPCHash,
 				Description: "Tags for the direct link gateway",
 			},
+			tagsAll: tagsAllSchema(),
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -207,8 +208,8 @@ func resourceIBMdlProviderGatewayCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Created Direct Link Provider Gateway : %s", *gateway.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(dlTags); ok || v != "" {
-		oldList, newList := d.GetChange(dlTags)
+	if _, ok := d.GetOk(dlTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *gateway.Crn)
 		if err != nil {
 			log.Printf(
@@ -298,7 +299,7 @@ func resourceIBMdlProviderGatewayRead(d *schema.ResourceData, meta interface{})
 		log.Printf(
 			"Error on get of resource direct link gateway (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(dlTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -326,8 +327,8 @@ func resourceIBMdlProviderGatewayUpdate(d *schema.ResourceData, meta interface{}
 
 	updateGatewayOptionsModel := directLink.NewUpdateProviderGatewayOptions(ID)
 
-	if d.HasChange(dlTags) {
-		oldList, newList := d.GetChange(dlTags)
+	if d.HasChange(dlTags) || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.Crn)
 		if err != nil {
 			log.Printf(
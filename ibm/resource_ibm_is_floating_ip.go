@@ -29,7 +29,7 @@ const (
 )
 
 func resourceIBMISFloatingIP() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_floating_ip", &schema.Resource{
 		Create:   resourceIBMISFloatingIPCreate,
 		Read:     resourceIBMISFloatingIPRead,
 		Update:   resourceIBMISFloatingIPUpdate,
@@ -44,7 +44,7 @@ func resourceIBMISFloatingIP() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -104,6 +104,8 @@ func resourceIBMISFloatingIP() *schema.Resource {
 				Description: "Floating IP tags",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -134,7 +136,7 @@ func resourceIBMISFloatingIP() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func classicVpcClient(meta interface{}) (*vpcclassicv1.VpcClassicV1, error) {
@@ -229,8 +231,8 @@ func classicFipCreate(d *schema.ResourceData, meta interface{}, name string) err
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" {
-		oldList, newList := d.GetChange(isFloatingIPTags)
+	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *floatingip.CRN)
 		if err != nil {
 			log.Printf(
@@ -290,8 +292,8 @@ func fipCreate(d *schema.ResourceData, meta interface{}, name string) error {
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" {
-		oldList, newList := d.GetChange(isFloatingIPTags)
+	if _, ok := d.GetOk(isFloatingIPTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *floatingip.CRN)
 		if err != nil {
 			log.Printf(
@@ -352,7 +354,7 @@ func classicFipGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc Floating IP (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isFloatingIPTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -394,7 +396,7 @@ func fipGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc Floating IP (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isFloatingIPTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -436,7 +438,7 @@ func classicFipUpdate(d *schema.ResourceData, meta interface{}, id string) error
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isFloatingIPTags) {
+	if d.HasChange(isFloatingIPTags) || d.HasChange(tagsAll) {
 		options := &vpcclassicv1.GetFloatingIPOptions{
 			ID: &id,
 		}
@@ -444,7 +446,7 @@ func classicFipUpdate(d *schema.ResourceData, meta interface{}, id string) error
 		if err != nil {
 			return fmt.Errorf("Error getting Floating IP: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isFloatingIPTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *fip.CRN)
 		if err != nil {
 			log.Printf(
@@ -494,7 +496,7 @@ func fipUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isFloatingIPTags) {
+	if d.HasChange(isFloatingIPTags) || d.HasChange(tagsAll) {
 		options := &vpcv1.GetFloatingIPOptions{
 			ID: &id,
 		}
@@ -502,7 +504,7 @@ func fipUpdate(d *schema.ResourceData, meta interface{}, id string) error {
 		if err != nil {
 			return fmt.Errorf("Error getting Floating IP: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isFloatingIPTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *fip.CRN)
 		if err != nil {
 			log.Printf(
@@ -45,7 +45,7 @@ func resourceIBMISFlowLog() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -132,6 +132,8 @@ func resourceIBMISFlowLog() *schema.Resource {
 				Description: "Tags for the VPC Flow logs",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -223,8 +225,8 @@ func resourceIBMISFlowLogCreate(d *schema.ResourceData, meta interface{}) error
 	log.Printf("Flow log collector : %s", *flowlogCollector.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isFlowLogTags); ok || v != "" {
-		oldList, newList := d.GetChange(isFlowLogTags)
+	if _, ok := d.GetOk(isFlowLogTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *flowlogCollector.CRN)
 		if err != nil {
 			log.Printf(
@@ -296,7 +298,7 @@ func resourceIBMISFlowLogRead(d *schema.ResourceData, meta interface{}) error {
 		log.Printf(
 			"Error on get of resource vpc flow log (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isFlowLogTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -332,8 +334,8 @@ func resourceIBMISFlowLogUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error Getting Flow Log Collector: %s\n%s", err, response)
 	}
 
-	if d.HasChange(isFlowLogTags) {
-		oldList, newList := d.GetChange(isFlowLogTags)
+	if d.HasChange(isFlowLogTags) || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *flowlogCollector.CRN)
 		if err != nil {
 			log.Printf(
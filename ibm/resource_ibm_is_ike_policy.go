@@ -26,7 +26,7 @@ const (
 )
 
 func resourceIBMISIKEPolicy() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_ike_policy", &schema.Resource{
 		Create:   resourceIBMISIKEPolicyCreate,
 		Read:     resourceIBMISIKEPolicyRead,
 		Update:   resourceIBMISIKEPolicyUpdate,
@@ -135,7 +135,7 @@ func resourceIBMISIKEPolicy() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISIKEValidator() *ResourceValidator {
@@ -35,7 +35,7 @@ const (
 )
 
 func resourceIBMISImage() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_image", &schema.Resource{
 		Create:   resourceIBMISImageCreate,
 		Read:     resourceIBMISImageRead,
 		Update:   resourceIBMISImageUpdate,
@@ -51,7 +51,7 @@ func resourceIBMISImage() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -92,6 +92,8 @@ func resourceIBMISImage() *schema.Resource {
 				Description: "Tags for the image",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			isImageOperatingSystem: {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -166,7 +168,7 @@ func resourceIBMISImage() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISImageValidator() *ResourceValidator {
@@ -246,8 +248,8 @@ func classicImgCreate(d *schema.ResourceData, meta interface{}, href, name, oper
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isImageTags); ok || v != "" {
-		oldList, newList := d.GetChange(isImageTags)
+	if _, ok := d.GetOk(isImageTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
 			log.Printf(
@@ -303,8 +305,8 @@ func imgCreate(d *schema.ResourceData, meta interface{}, href, name, operatingSy
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isImageTags); ok || v != "" {
-		oldList, newList := d.GetChange(isImageTags)
+	if _, ok := d.GetOk(isImageTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
 			log.Printf(
@@ -412,7 +414,7 @@ func classicImgUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isImageTags) {
+	if d.HasChange(isImageTags) || d.HasChange(tagsAll) {
 		options := &vpcclassicv1.GetImageOptions{
 			ID: &id,
 		}
@@ -420,7 +422,7 @@ func classicImgUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting Image IP: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isImageTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
 			log.Printf(
@@ -453,7 +455,7 @@ func imgUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isImageTags) {
+	if d.HasChange(isImageTags) || d.HasChange(tagsAll) {
 		options := &vpcv1.GetImageOptions{
 			ID: &id,
 		}
@@ -461,7 +463,7 @@ func imgUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		if err != nil {
 			return fmt.Errorf("Error getting Image IP: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isImageTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *image.CRN)
 		if err != nil {
 			log.Printf(
@@ -539,7 +541,7 @@ func classicImgGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc Image (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isImageTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -599,7 +601,7 @@ func imgGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc Image (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isImageTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/IBM/vpc-go-sdk/vpcclassicv1"
@@ -65,6 +66,9 @@ const (
 	isInstanceDeleteDone       = "done"
 	isInstanceFailed           = "failed"
 
+	isInstanceAction               = "action"
+	isInstanceActionForce          = "force_action"
+	isInstancePowerState           = "power_state"
 	isInstanceActionStatusStopping = "stopping"
 	isInstanceActionStatusStopped  = "stopped"
 	isInstanceStatusPending        = "pending"
@@ -85,7 +89,7 @@ const (
 )
 
 func resourceIBMISInstance() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_instance", &schema.Resource{
 		Create:   resourceIBMisInstanceCreate,
 		Read:     resourceIBMisInstanceRead,
 		Update:   resourceIBMisInstanceUpdate,
@@ -101,8 +105,28 @@ func resourceIBMISInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
+			customdiff.If(
+				func(diff *schema.ResourceDiff, v interface{}) bool {
+					return diff.Id() == "" || diff.HasChange(isInstanceProfile) || diff.HasChange(isInstanceImage) ||
+						diff.HasChange(isInstanceZone) || diff.HasChange(isInstanceVolumes)
+				},
+				func(diff *schema.ResourceDiff, v interface{}) error {
+					volumes := []string{}
+					if diff.NewValueKnown(isInstanceVolumes) {
+						for _, volume := range diff.Get(isInstanceVolumes).(*schema.Set).List() {
+							volumes = append(volumes, volume.(string))
+						}
+					}
+					portSpeed := int64(diff.Get(isInstancePrimaryNetworkInterface + ".0." + isInstanceNicPortSpeed).(int))
+					bootProfile := isInstanceBootVolumeDefaultProfile
+					if profile, ok := diff.GetOk(isInstanceBootVolume + ".0." + isInstanceBootProfile); ok && diff.NewValueKnown(isInstanceBootVolume+".0."+isInstanceBootProfile) {
+						bootProfile = profile.(string)
+					}
+					return resourceIBMISInstanceCompatibilityCustomizeDiff(diff, v, volumes, bootProfile, portSpeed)
+				},
+			),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -153,6 +177,8 @@ func resourceIBMISInstance() *schema.Resource {
 				Description: "list of tags for the instance",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			isInstanceVolumeAttachments: {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -433,8 +459,16 @@ func resourceIBMISInstance() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
+
+			isInstancePowerState: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{isInstanceStatusRunning, isInstanceActionStatusStopped}),
+				Description:  "Desired power state of the instance, running or stopped",
+			},
 		},
-	}
+	})
 }
 
 func resourceIBMISInstanceValidator() *ResourceValidator {
@@ -454,6 +488,231 @@ func resourceIBMISInstanceValidator() *ResourceValidator {
 	return &ibmISInstanceValidator
 }
 
+// isInstanceMaxDataVolumes is the number of data volumes that can be attached
+// to a virtual server instance. The instance profiles of the VPC API
+// (vpcv1.InstanceProfile) don't have a volume attachment limit, so the limit
+// that the service documents for every profile is used.
+const isInstanceMaxDataVolumes = 12
+
+// isInstanceBootVolumeDefaultProfile is the profile of the boot volume of a new
+// instance.
+const isInstanceBootVolumeDefaultProfile = "general-purpose"
+
+// resourceIBMISInstanceCompatibilityCustomizeDiff looks up the profile, image,
+// zone and volumes of an instance or instance template at plan time and rejects
+// the combinations that would otherwise fail late during apply. bootProfile and
+// the profiles of the data volumes are checked against the zone. Arguments that
+// are not known yet are not checked.
+func resourceIBMISInstanceCompatibilityCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, volumes []string, bootProfile string, portSpeed int64) error {
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return err
+	}
+	if userDetails.generation == 1 {
+		return nil
+	}
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	if diff.NewValueKnown(isInstanceProfile) {
+		profileName := diff.Get(isInstanceProfile).(string)
+		profile, response, err := sess.GetInstanceProfile(&vpcv1.GetInstanceProfileOptions{
+			Name: &profileName,
+		})
+		if err != nil {
+			if response != nil && response.StatusCode == 404 {
+				return fmt.Errorf("Instance profile %s not found", profileName)
+			}
+			return fmt.Errorf("Error Getting Instance Profile %s: %s\n%s", profileName, err, response)
+		}
+		if diff.NewValueKnown(isInstanceImage) {
+			imageID := diff.Get(isInstanceImage).(string)
+			image, response, err := sess.GetImage(&vpcv1.GetImageOptions{
+				ID: &imageID,
+			})
+			if err != nil {
+				if response != nil && response.StatusCode == 404 {
+					return fmt.Errorf("Image %s not found", imageID)
+				}
+				return fmt.Errorf("Error Getting Image %s: %s\n%s", imageID, err, response)
+			}
+			if err := validateInstanceProfileImage(profile, image); err != nil {
+				return err
+			}
+		}
+		if err := validateInstanceProfileBandwidth(profile, portSpeed); err != nil {
+			return err
+		}
+	}
+
+	if len(volumes) > isInstanceMaxDataVolumes {
+		return fmt.Errorf("At most %d data volumes can be attached to an instance, %d are configured", isInstanceMaxDataVolumes, len(volumes))
+	}
+
+	attached := make([]vpcv1.Volume, 0, len(volumes))
+	profiles := []string{}
+	if bootProfile != "" {
+		profiles = append(profiles, bootProfile)
+	}
+	for _, volumeID := range volumes {
+		id := volumeID
+		volume, response, err := sess.GetVolume(&vpcv1.GetVolumeOptions{
+			ID: &id,
+		})
+		if err != nil {
+			if response != nil && response.StatusCode == 404 {
+				return fmt.Errorf("Volume %s not found", volumeID)
+			}
+			return fmt.Errorf("Error Getting Volume %s: %s\n%s", volumeID, err, response)
+		}
+		attached = append(attached, *volume)
+		if volume.Profile != nil && volume.Profile.Name != nil {
+			profiles = append(profiles, *volume.Profile.Name)
+		}
+	}
+
+	var zone *vpcv1.Zone
+	if diff.NewValueKnown(isInstanceZone) {
+		zoneName := diff.Get(isInstanceZone).(string)
+		regionName := zoneName
+		if i := strings.LastIndex(zoneName, "-"); i > 0 {
+			regionName = zoneName[:i]
+		}
+		z, response, err := sess.GetRegionZone(&vpcv1.GetRegionZoneOptions{
+			RegionName: &regionName,
+			Name:       &zoneName,
+		})
+		if err != nil {
+			if response != nil && response.StatusCode == 404 {
+				return fmt.Errorf("Zone %s not found", zoneName)
+			}
+			return fmt.Errorf("Error Getting Zone %s: %s\n%s", zoneName, err, response)
+		}
+		zone = z
+		if err := validateInstanceZoneVolumes(zone, attached); err != nil {
+			return err
+		}
+	}
+
+	if len(profiles) == 0 {
+		return nil
+	}
+	bmxSess, err := meta.(ClientSession).BluemixSession()
+	if err != nil {
+		return err
+	}
+	start := ""
+	available := []vpcv1.VolumeProfile{}
+	for {
+		listVolumeProfilesOptions := &vpcv1.ListVolumeProfilesOptions{}
+		if start != "" {
+			listVolumeProfilesOptions.Start = &start
+		}
+		volumeProfiles, response, err := sess.ListVolumeProfiles(listVolumeProfilesOptions)
+		if err != nil {
+			return fmt.Errorf("Error Fetching Volume Profiles %s\n%s", err, response)
+		}
+		start = GetNext(volumeProfiles.Next)
+		available = append(available, volumeProfiles.Profiles...)
+		if start == "" {
+			break
+		}
+	}
+	return validateInstanceZoneVolumeProfiles(zone, bmxSess.Config.Region, available, profiles)
+}
+
+// validateInstanceProfileImage checks that the operating system architecture
+// of the image is supported by the instance profile.
+func validateInstanceProfileImage(profile *vpcv1.InstanceProfile, image *vpcv1.Image) error {
+	if image.OperatingSystem == nil || image.OperatingSystem.Architecture == nil {
+		return nil
+	}
+	architecture := *image.OperatingSystem.Architecture
+	supported := []string{}
+	if profile.OsArchitecture != nil {
+		supported = profile.OsArchitecture.Values
+	}
+	if len(supported) == 0 && profile.VcpuArchitecture != nil && profile.VcpuArchitecture.Value != nil {
+		supported = []string{*profile.VcpuArchitecture.Value}
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+	for _, a := range supported {
+		if a == architecture {
+			return nil
+		}
+	}
+	return fmt.Errorf("Image %s has architecture %s, which is not supported by instance profile %s (supported: %s)",
+		*image.Name, architecture, *profile.Name, strings.Join(supported, ", "))
+}
+
+// validateInstanceProfileBandwidth checks that the port speed requested for the
+// primary network interface, in Mbps, fits the bandwidth of the instance profile.
+func validateInstanceProfileBandwidth(profile *vpcv1.InstanceProfile, portSpeed int64) error {
+	if portSpeed <= 0 {
+		return nil
+	}
+	var limit int64
+	if ps, ok := profile.PortSpeed.(*vpcv1.InstanceProfilePortSpeed); ok && ps.Value != nil {
+		limit = *ps.Value
+	}
+	if bw, ok := profile.Bandwidth.(*vpcv1.InstanceProfileBandwidth); ok {
+		max := bw.Value
+		if bw.Max != nil {
+			max = bw.Max
+		}
+		if max != nil && (limit == 0 || *max < limit) {
+			limit = *max
+		}
+	}
+	if limit > 0 && portSpeed > limit {
+		return fmt.Errorf("Port speed %d Mbps exceeds the %d Mbps bandwidth of instance profile %s", portSpeed, limit, *profile.Name)
+	}
+	return nil
+}
+
+// validateInstanceZoneVolumes checks that the zone is available and that every
+// data volume to attach lives in that zone.
+func validateInstanceZoneVolumes(zone *vpcv1.Zone, volumes []vpcv1.Volume) error {
+	if zone.Status != nil && *zone.Status != vpcv1.ZoneStatusAvailableConst {
+		return fmt.Errorf("Zone %s is %s", *zone.Name, *zone.Status)
+	}
+	for _, volume := range volumes {
+		if volume.Zone != nil && volume.Zone.Name != nil && *volume.Zone.Name != *zone.Name {
+			return fmt.Errorf("Volume %s is in zone %s and cannot be attached to an instance in zone %s", *volume.Name, *volume.Zone.Name, *zone.Name)
+		}
+	}
+	return nil
+}
+
+// validateInstanceZoneVolumeProfiles checks that the volume profiles are
+// available in the zone. The VPC API offers volume profiles per region, so the
+// profiles available in a zone are the ones the region of the provider lists,
+// provided the zone is in that region. zone is nil when it isn't known yet.
+func validateInstanceZoneVolumeProfiles(zone *vpcv1.Zone, region string, available []vpcv1.VolumeProfile, profiles []string) error {
+	location := "region " + region
+	if zone != nil {
+		if zone.Region != nil && zone.Region.Name != nil && region != "" && *zone.Region.Name != region {
+			return fmt.Errorf("Zone %s is in region %s, not in region %s of the provider", *zone.Name, *zone.Region.Name, region)
+		}
+		location = "zone " + *zone.Name
+	}
+	names := make(map[string]bool, len(available))
+	for _, profile := range available {
+		if profile.Name != nil {
+			names[*profile.Name] = true
+		}
+	}
+	for _, profile := range profiles {
+		if !names[profile] {
+			return fmt.Errorf("Volume profile %s is not available in %s", profile, location)
+		}
+	}
+	return nil
+}
 func classicInstanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpcID, zone, image string) error {
 	sess, err := classicVpcClient(meta)
 	if err != nil {
@@ -625,8 +884,8 @@ func classicInstanceCreate(d *schema.ResourceData, meta interface{}, profile, na
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isInstanceTags); ok || v != "" {
-		oldList, newList := d.GetChange(isInstanceTags)
+	if _, ok := d.GetOk(isInstanceTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
 			log.Printf(
@@ -673,7 +932,7 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 		}
 		volcap := 100
 		volcapint64 := int64(volcap)
-		volprof := "general-purpose"
+		volprof := isInstanceBootVolumeDefaultProfile
 		volTemplate.Capacity = &volcapint64
 		volTemplate.Profile = &vpcv1.VolumeProfileIdentity{
 			Name: &volprof,
@@ -814,9 +1073,16 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 		return err
 	}
 
+	if powerState, ok := d.GetOk(isInstancePowerState); ok && powerState.(string) == isInstanceActionStatusStopped {
+		err = isInstancePerformAction(sess, d, d.Id(), "stop", false, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isInstanceTags); ok || v != "" {
-		oldList, newList := d.GetChange(isInstanceTags)
+	if _, ok := d.GetOk(isInstanceTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
 			log.Printf(
@@ -882,9 +1148,13 @@ func isWaitForInstanceAvailable(instanceC *vpcv1.VpcV1, id string, timeout time.
 		MinTimeout: 10 * time.Second,
 	}
 
-	if v, ok := d.GetOk("force_recovery_time"); ok {
-		forceTimeout := v.(int)
-		go isRestartStartAction(instanceC, id, d, forceTimeout, communicator)
+	// d is nil when the caller isn't the instance resource, e.g. a network
+	// interface of the instance, which has no force_recovery_time
+	if d != nil {
+		if v, ok := d.GetOk("force_recovery_time"); ok {
+			forceTimeout := v.(int)
+			go isRestartStartAction(instanceC, id, d, forceTimeout, communicator)
+		}
 	}
 	return stateConf.WaitForState()
 }
@@ -918,7 +1188,9 @@ func isInstanceRefreshFunc(instanceC *vpcv1.VpcV1, id string, d *schema.Resource
 		if err != nil {
 			return nil, "", fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
 		}
-		d.Set(isInstanceStatus, *instance.Status)
+		if d != nil {
+			d.Set(isInstanceStatus, *instance.Status)
+		}
 
 		select {
 		case data := <-communicator:
@@ -1157,7 +1429,7 @@ func classicInstanceGet(d *schema.ResourceData, meta interface{}, id string) err
 		log.Printf(
 			"Error on get of resource vpc Instance (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isInstanceTags, tags)
+	setResourceTags(d, meta, tags)
 
 	controller, err := getBaseController(meta)
 	if err != nil {
@@ -1190,6 +1462,18 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 		}
 		return fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
 	}
+	// Network interfaces attached with ibm_is_instance_network_interface and
+	// volumes attached with ibm_is_instance_volume_attachment are not part of
+	// network_interfaces and volumes. Once the instance has been read, only
+	// the network interfaces and volumes already in the state are kept.
+	firstRead := d.Get("primary_network_interface.0.id").(string) == ""
+	inlineNics := map[string]bool{}
+	for _, nic := range d.Get(isInstanceNetworkInterfaces).([]interface{}) {
+		if nic, ok := nic.(map[string]interface{}); ok {
+			inlineNics[nic["id"].(string)] = true
+		}
+	}
+	inlineVolumes := d.Get(isInstanceVolumes).(*schema.Set)
 	d.Set(isInstanceName, *instance.Name)
 	if instance.Profile != nil {
 		d.Set(isInstanceProfile, *instance.Profile.Name)
@@ -1248,7 +1532,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	if instance.NetworkInterfaces != nil {
 		interfacesList := make([]map[string]interface{}, 0)
 		for _, intfc := range instance.NetworkInterfaces {
-			if *intfc.ID != *instance.PrimaryNetworkInterface.ID {
+			if *intfc.ID != *instance.PrimaryNetworkInterface.ID && (firstRead || inlineNics[*intfc.ID]) {
 				currentNic := map[string]interface{}{}
 				currentNic["id"] = *intfc.ID
 				currentNic[isInstanceNicName] = *intfc.Name
@@ -1283,6 +1567,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	}
 
 	d.Set(isInstanceStatus, *instance.Status)
+	d.Set(isInstancePowerState, isInstancePowerStateOf(*instance.Status))
 	d.Set(isInstanceVPC, *instance.VPC.ID)
 	d.Set(isInstanceZone, *instance.Zone.Name)
 
@@ -1290,7 +1575,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	volumes = make([]string, 0)
 	if instance.VolumeAttachments != nil {
 		for _, volume := range instance.VolumeAttachments {
-			if volume.Volume != nil && *volume.Volume.ID != *instance.BootVolumeAttachment.Volume.ID {
+			if volume.Volume != nil && *volume.Volume.ID != *instance.BootVolumeAttachment.Volume.ID && (firstRead || inlineVolumes.Contains(*volume.Volume.ID)) {
 				volumes = append(volumes, *volume.Volume.ID)
 			}
 		}
@@ -1338,7 +1623,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc Instance (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isInstanceTags, tags)
+	setResourceTags(d, meta, tags)
 
 	controller, err := getBaseController(meta)
 	if err != nil {
@@ -1563,7 +1848,7 @@ func classicInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	if d.HasChange(isInstanceTags) {
+	if d.HasChange(isInstanceTags) || d.HasChange(tagsAll) {
 		getinsOptions := &vpcclassicv1.GetInstanceOptions{
 			ID: &id,
 		}
@@ -1571,7 +1856,7 @@ func classicInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			log.Printf("Error Getting Instance: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isInstanceTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
 			log.Printf(
@@ -1603,11 +1888,7 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 						ID: &add[i],
 					},
 				}
-				vol, _, err := instanceC.CreateInstanceVolumeAttachment(createvolattoptions)
-				if err != nil {
-					return fmt.Errorf("Error while attaching volume %q for instance %s: %q", add[i], d.Id(), err)
-				}
-				_, err = isWaitForInstanceVolumeAttached(instanceC, d, id, *vol.ID)
+				_, err := isInstanceVolumeAttach(instanceC, createvolattoptions, d.Timeout(schema.TimeoutUpdate))
 				if err != nil {
 					return err
 				}
@@ -1625,15 +1906,7 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 				}
 				for _, vol := range vols.VolumeAttachments {
 					if *vol.Volume.ID == remove[i] {
-						delvolattoptions := &vpcv1.DeleteInstanceVolumeAttachmentOptions{
-							InstanceID: &id,
-							ID:         vol.ID,
-						}
-						_, err := instanceC.DeleteInstanceVolumeAttachment(delvolattoptions)
-						if err != nil {
-							return fmt.Errorf("Error while removing volume %q for instance %s: %q", remove[i], d.Id(), err)
-						}
-						_, err = isWaitForInstanceVolumeDetached(instanceC, d, d.Id(), *vol.ID)
+						err := isInstanceVolumeDetach(instanceC, id, *vol.ID, d.Timeout(schema.TimeoutUpdate))
 						if err != nil {
 							return err
 						}
@@ -1821,6 +2094,17 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if powerState, ok := d.GetOk(isInstancePowerState); ok && d.HasChange(isInstancePowerState) {
+		action := "start"
+		if powerState.(string) == isInstanceActionStatusStopped {
+			action = "stop"
+		}
+		err := isInstancePerformAction(instanceC, d, id, action, false, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
 	getinsOptions := &vpcv1.GetInstanceOptions{
 		ID: &id,
 	}
@@ -1828,8 +2112,8 @@ func instanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
 	}
-	if d.HasChange(isInstanceTags) {
-		oldList, newList := d.GetChange(isInstanceTags)
+	if d.HasChange(isInstanceTags) || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instance.CRN)
 		if err != nil {
 			log.Printf(
@@ -1991,7 +2275,7 @@ func instanceDelete(d *schema.ResourceData, meta interface{}, id string) error {
 			if err != nil {
 				return fmt.Errorf("Error while removing volume Attachment %q for instance %s: %q", *vol.ID, d.Id(), err)
 			}
-			_, err = isWaitForInstanceVolumeDetached(instanceC, d, d.Id(), *vol.ID)
+			_, err = isWaitForInstanceVolumeDetached(instanceC, d.Id(), *vol.ID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
 				return err
 			}
@@ -2207,14 +2491,73 @@ func isWaitForInstanceActionStop(instanceC *vpcv1.VpcV1, timeout time.Duration,
 		MinTimeout: 10 * time.Second,
 	}
 
-	if v, ok := d.GetOk("force_recovery_time"); ok {
-		forceTimeout := v.(int)
-		go isRestartStopAction(instanceC, id, d, forceTimeout, communicator)
+	if d != nil {
+		if v, ok := d.GetOk("force_recovery_time"); ok {
+			forceTimeout := v.(int)
+			go isRestartStopAction(instanceC, id, d, forceTimeout, communicator)
+		}
 	}
 
 	return stateConf.WaitForState()
 }
 
+// isCreateInstanceAction creates the start, stop or reboot action of the instance.
+func isCreateInstanceAction(instanceC *vpcv1.VpcV1, id, actiontype string, force bool) error {
+	createinsactoptions := &vpcv1.CreateInstanceActionOptions{
+		InstanceID: &id,
+		Type:       &actiontype,
+		Force:      &force,
+	}
+	_, response, err := instanceC.CreateInstanceAction(createinsactoptions)
+	if err != nil {
+		return fmt.Errorf("Error creating instance action %s on instance %s: %s\n%s", actiontype, id, err, response)
+	}
+	return nil
+}
+
+// isInstanceKey is the key of ibmMutexKV that serializes the actions that
+// start or stop an instance.
+func isInstanceKey(id string) string {
+	return "instance_key_" + id
+}
+
+// isInstancePerformAction brings the instance to the power state of action
+// and waits for it. Starting a running or stopping a stopped instance does
+// nothing.
+func isInstancePerformAction(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, action string, force bool, timeout time.Duration) error {
+	ibmMutexKV.Lock(isInstanceKey(id))
+	defer ibmMutexKV.Unlock(isInstanceKey(id))
+
+	getinsOptions := &vpcv1.GetInstanceOptions{
+		ID: &id,
+	}
+	instance, response, err := instanceC.GetInstance(getinsOptions)
+	if err != nil {
+		return fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+	}
+	if (action == "start" && *instance.Status == isInstanceStatusRunning) || (action == "stop" && *instance.Status == isInstanceActionStatusStopped) {
+		return nil
+	}
+	if err := isCreateInstanceAction(instanceC, id, action, force); err != nil {
+		return err
+	}
+	if action == "stop" {
+		_, err = isWaitForInstanceActionStop(instanceC, timeout, id, d)
+	} else {
+		_, err = isWaitForInstanceAvailable(instanceC, id, timeout, d)
+	}
+	return err
+}
+
+// isInstancePowerStateOf returns the power_state of an instance with status.
+// An instance that is being stopped is stopped, any other one is running.
+func isInstancePowerStateOf(status string) string {
+	if status == isInstanceActionStatusStopped || status == isInstanceActionStatusStopping {
+		return isInstanceActionStatusStopped
+	}
+	return isInstanceStatusRunning
+}
+
 func isRestartStopAction(instanceC *vpcv1.VpcV1, id string, d *schema.ResourceData, forceTimeout int, communicator chan interface{}) {
 	subticker := time.NewTicker(time.Duration(forceTimeout) * time.Minute)
 	//subticker := time.NewTicker(time.Duration(forceTimeout) * time.Second)
@@ -2276,14 +2619,48 @@ func isClassicInstanceVolumeRefreshFunc(instanceC *vpcclassicv1.VpcClassicV1, id
 	}
 }
 
-func isWaitForInstanceVolumeAttached(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
+// isInstanceVolumeAttach creates the volume attachment of options and waits
+// for the volume to be attached.
+func isInstanceVolumeAttach(instanceC *vpcv1.VpcV1, options *vpcv1.CreateInstanceVolumeAttachmentOptions, timeout time.Duration) (*vpcv1.VolumeAttachment, error) {
+	volID := *options.Volume.(*vpcv1.VolumeIdentity).ID
+	vol, response, err := instanceC.CreateInstanceVolumeAttachment(options)
+	if err != nil {
+		return nil, fmt.Errorf("Error while attaching volume %q for instance %s: %q\n%s", volID, *options.InstanceID, err, response)
+	}
+	_, err = isWaitForInstanceVolumeAttached(instanceC, *options.InstanceID, *vol.ID, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// isInstanceVolumeDetach deletes the volume attachment attachmentID of the
+// instance and waits for the volume to be detached. An attachment that is
+// already gone is detached.
+func isInstanceVolumeDetach(instanceC *vpcv1.VpcV1, id, attachmentID string, timeout time.Duration) error {
+	delvolattoptions := &vpcv1.DeleteInstanceVolumeAttachmentOptions{
+		InstanceID: &id,
+		ID:         &attachmentID,
+	}
+	response, err := instanceC.DeleteInstanceVolumeAttachment(delvolattoptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error while removing volume attachment %q for instance %s: %q\n%s", attachmentID, id, err, response)
+	}
+	_, err = isWaitForInstanceVolumeDetached(instanceC, id, attachmentID, timeout)
+	return err
+}
+
+func isWaitForInstanceVolumeAttached(instanceC *vpcv1.VpcV1, id, volID string, timeout time.Duration) (interface{}, error) {
 	log.Printf("Waiting for instance volume (%s) to be attched.", id)
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{isInstanceVolumeAttaching},
 		Target:     []string{isInstanceVolumeAttached, ""},
 		Refresh:    isInstanceVolumeRefreshFunc(instanceC, id, volID),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
@@ -2340,7 +2717,7 @@ func isWaitForClassicInstanceVolumeDetached(instanceC *vpcclassicv1.VpcClassicV1
 	return stateConf.WaitForState()
 }
 
-func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceData, id, volID string) (interface{}, error) {
+func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, id, volID string, timeout time.Duration) (interface{}, error) {
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{isInstanceVolumeAttached, isInstanceVolumeDetaching},
@@ -2358,11 +2735,11 @@ func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceD
 				return nil, "", fmt.Errorf("Error Detaching: %s\n%s", err, response)
 			}
 			if *vol.Status == isInstanceFailed {
-				return vol, *vol.Status, fmt.Errorf("The instance %s failed to detach volume %s: %v", d.Id(), volID, err)
+				return vol, *vol.Status, fmt.Errorf("The instance %s failed to detach volume %s: %v", id, volID, err)
 			}
 			return vol, isInstanceVolumeDetaching, nil
 		},
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 10 * time.Second,
 	}
@@ -0,0 +1,138 @@
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	isInstanceActionInstance = "instance"
+)
+
+func resourceIBMISInstanceAction() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISInstanceActionCreate,
+		Read:     resourceIBMISInstanceActionRead,
+		Update:   resourceIBMISInstanceActionUpdate,
+		Delete:   resourceIBMISInstanceActionDelete,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isInstanceActionInstance: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Instance ID",
+			},
+
+			isInstanceAction: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"start", "stop", "reboot"}),
+				Description:  "Action of the instance: start keeps it running, stop keeps it stopped, reboot reboots it once",
+			},
+
+			isInstanceActionForce: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the action is forced immediately, and all queued actions deleted",
+			},
+
+			isInstanceStatus: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the instance",
+			},
+		},
+	}
+}
+
+func resourceIBMISInstanceActionCreate(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	id := d.Get(isInstanceActionInstance).(string)
+	action := d.Get(isInstanceAction).(string)
+	err = isInstancePerformAction(instanceC, d, id, action, d.Get(isInstanceActionForce).(bool), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	return resourceIBMISInstanceActionRead(d, meta)
+}
+
+func resourceIBMISInstanceActionRead(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	id := d.Id()
+	getinsOptions := &vpcv1.GetInstanceOptions{
+		ID: &id,
+	}
+	instance, response, err := instanceC.GetInstance(getinsOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error Getting Instance: %s\n%s", err, response)
+	}
+
+	d.Set(isInstanceActionInstance, id)
+	d.Set(isInstanceStatus, *instance.Status)
+	action := d.Get(isInstanceAction).(string)
+	if action == "" {
+		// Imported, the action is the one of the current power state
+		action = "stop"
+		if *instance.Status == isInstanceStatusRunning {
+			action = "start"
+		}
+	}
+	d.Set(isInstanceAction, isInstanceActionState(action, *instance.Status))
+	return nil
+}
+
+func resourceIBMISInstanceActionUpdate(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	if d.HasChange(isInstanceAction) {
+		action := d.Get(isInstanceAction).(string)
+		err = isInstancePerformAction(instanceC, d, d.Id(), action, d.Get(isInstanceActionForce).(bool), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISInstanceActionRead(d, meta)
+}
+
+func resourceIBMISInstanceActionDelete(d *schema.ResourceData, meta interface{}) error {
+	// The instance keeps its power state, there is nothing to delete
+	d.SetId("")
+	return nil
+}
+
+// isInstanceActionState returns action, or the opposite action when the
+// status of the instance no longer matches it, e.g. because the instance was
+// stopped outside of Terraform. The drift then shows up as a change of action.
+func isInstanceActionState(action, status string) string {
+	switch {
+	case action == "start" && status == isInstanceActionStatusStopped:
+		return "stop"
+	case action == "stop" && status == isInstanceStatusRunning:
+		return "start"
+	}
+	return action
+}
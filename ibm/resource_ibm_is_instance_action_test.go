@@ -0,0 +1,111 @@
+package ibm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccIBMISInstanceAction_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf-instance-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tfssh-name-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstanceActionConfig(vpcname, subnetname, sshname, publicKey, name, "stop"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "action", "stop"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "status", "stopped"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceActionConfig(vpcname, subnetname, sshname, publicKey, name, "start"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "action", "start"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "status", "running"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceActionConfig(vpcname, subnetname, sshname, publicKey, name, "reboot"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "action", "reboot"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_action.testacc_action", "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsInstanceActionState(t *testing.T) {
+	cases := []struct {
+		action, status, want string
+	}{
+		{"start", "running", "start"},
+		{"start", "stopped", "stop"},
+		{"stop", "stopped", "stop"},
+		{"stop", "running", "start"},
+		{"start", "starting", "start"},
+		{"reboot", "stopped", "reboot"},
+	}
+	for _, c := range cases {
+		if got := isInstanceActionState(c.action, c.status); got != c.want {
+			t.Errorf("isInstanceActionState(%q, %q) = %q, want %q", c.action, c.status, got, c.want)
+		}
+	}
+}
+
+func testAccCheckIBMISInstanceActionConfig(vpcname, subnetname, sshname, publicKey, name, action string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+
+	  resource "ibm_is_ssh_key" "testacc_sshkey" {
+		name       = "%s"
+		public_key = "%s"
+	  }
+
+	  resource "ibm_is_instance" "testacc_instance" {
+		name    = "%s"
+		image   = "%s"
+		profile = "%s"
+
+		primary_network_interface {
+		  subnet = ibm_is_subnet.testacc_subnet.id
+		}
+
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		zone = "%s"
+		keys = [ibm_is_ssh_key.testacc_sshkey.id]
+	  }
+
+	  resource "ibm_is_instance_action" "testacc_action" {
+		instance     = ibm_is_instance.testacc_instance.id
+		action       = "%s"
+		force_action = true
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, action)
+}
@@ -32,7 +32,7 @@ func resourceIBMISInstanceGroup() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -131,6 +131,8 @@ func resourceIBMISInstanceGroup() *schema.Resource {
 				Set:         resourceIBMVPCHash,
 				Description: "List of tags for instance group",
 			},
+
+			tagsAll: tagsAllSchema(),
 		},
 	}
 }
@@ -231,8 +233,8 @@ func resourceIBMISInstanceGroupCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
-		oldList, newList := d.GetChange("tags")
+	if _, ok := d.GetOk("tags"); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instanceGroup.CRN)
 		if err != nil {
 			log.Printf(
@@ -254,14 +256,14 @@ func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{})
 	instanceGroupUpdateOptions := vpcv1.UpdateInstanceGroupOptions{}
 	instanceGroupPatchModel := vpcv1.InstanceGroupPatch{}
 
-	if d.HasChange("tags") {
+	if d.HasChange("tags") || d.HasChange(tagsAll) {
 		instanceGroupID := d.Id()
 		getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
 		instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
 		if err != nil {
 			return fmt.Errorf("Error getting instance group: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange("tags")
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *instanceGroup.CRN)
 		if err != nil {
 			log.Printf(
@@ -380,7 +382,7 @@ func resourceIBMISInstanceGroupRead(d *schema.ResourceData, meta interface{}) er
 		log.Printf(
 			"Error on get of instance group (%s) tags: %s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	setResourceTags(d, meta, tags)
 	return nil
 }
 
@@ -0,0 +1,499 @@
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	isInstanceNicInstance          = "instance"
+	isInstanceNicID                = "network_interface"
+	isInstanceNicFloatingIP        = "floating_ip"
+	isInstanceNicFloatingIPAddress = "floating_ip_address"
+	isInstanceNicStatus            = "status"
+	isInstanceNicType              = "type"
+	isInstanceNicStatusAvailable   = "available"
+	isInstanceNicStatusPending     = "pending"
+	isInstanceNicStatusDeleting    = "deleting"
+	isInstanceNicStatusFailed      = "failed"
+	isInstanceNicDeleteDone        = "done"
+)
+
+func resourceIBMISInstanceNetworkInterface() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISInstanceNetworkInterfaceCreate,
+		Read:     resourceIBMISInstanceNetworkInterfaceRead,
+		Update:   resourceIBMISInstanceNetworkInterfaceUpdate,
+		Delete:   resourceIBMISInstanceNetworkInterfaceDelete,
+		Exists:   resourceIBMISInstanceNetworkInterfaceExists,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isInstanceNicInstance: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Instance ID the network interface is attached to",
+			},
+
+			isInstanceNicSubnet: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Subnet ID of the network interface",
+			},
+
+			isInstanceNicName: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the network interface",
+			},
+
+			isInstanceNicPrimaryIpv4Address: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Primary IPv4 address of the network interface",
+			},
+
+			isInstanceNicAllowIPSpoofing: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether IP spoofing is allowed on this interface",
+			},
+
+			isInstanceNicSecurityGroups: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Security group IDs of the network interface, the VPC default security group if not set",
+			},
+
+			isInstanceNicFloatingIP: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the floating IP to associate with the network interface",
+			},
+
+			isInstanceNicFloatingIPAddress: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address of the associated floating IP",
+			},
+
+			isInstanceNicID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the network interface",
+			},
+
+			isInstanceNicPortSpeed: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port speed of the network interface in Mbps",
+			},
+
+			isInstanceNicStatus: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the network interface",
+			},
+
+			isInstanceNicType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the network interface",
+			},
+		},
+	}
+}
+
+func resourceIBMISInstanceNetworkInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	instanceID := d.Get(isInstanceNicInstance).(string)
+	subnetID := d.Get(isInstanceNicSubnet).(string)
+
+	options := &vpcv1.CreateInstanceNetworkInterfaceOptions{
+		InstanceID: &instanceID,
+		Subnet: &vpcv1.SubnetIdentity{
+			ID: &subnetID,
+		},
+	}
+	if v, ok := d.GetOk(isInstanceNicName); ok {
+		name := v.(string)
+		options.Name = &name
+	}
+	if v, ok := d.GetOk(isInstanceNicPrimaryIpv4Address); ok {
+		address := v.(string)
+		options.PrimaryIpv4Address = &address
+	}
+	ipSpoofing := d.Get(isInstanceNicAllowIPSpoofing).(bool)
+	options.AllowIPSpoofing = &ipSpoofing
+	if v, ok := d.GetOk(isInstanceNicSecurityGroups); ok {
+		for _, sg := range expandStringList(v.(*schema.Set).List()) {
+			sgID := sg
+			options.SecurityGroups = append(options.SecurityGroups, &vpcv1.SecurityGroupIdentity{
+				ID: &sgID,
+			})
+		}
+	}
+
+	var nic *vpcv1.NetworkInterface
+	err = isWithInstanceStopped(sess, instanceID, d.Timeout(schema.TimeoutCreate), func() error {
+		created, response, err := sess.CreateInstanceNetworkInterface(options)
+		if err != nil {
+			return fmt.Errorf("Error creating network interface for instance %s: %s\n%s", instanceID, err, response)
+		}
+		nic = created
+		d.SetId(fmt.Sprintf("%s/%s", instanceID, *nic.ID))
+		_, err = isWaitForInstanceNetworkInterfaceAvailable(sess, instanceID, *nic.ID, d.Timeout(schema.TimeoutCreate))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk(isInstanceNicFloatingIP); ok {
+		fipID := v.(string)
+		addfipoptions := &vpcv1.AddInstanceNetworkInterfaceFloatingIPOptions{
+			InstanceID:         &instanceID,
+			NetworkInterfaceID: nic.ID,
+			ID:                 &fipID,
+		}
+		_, response, err := sess.AddInstanceNetworkInterfaceFloatingIP(addfipoptions)
+		if err != nil {
+			return fmt.Errorf("Error associating floating IP %s with network interface %s: %s\n%s", fipID, *nic.ID, err, response)
+		}
+	}
+
+	return resourceIBMISInstanceNetworkInterfaceRead(d, meta)
+}
+
+func resourceIBMISInstanceNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	instanceID := parts[0]
+	nicID := parts[1]
+
+	getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+		InstanceID: &instanceID,
+		ID:         &nicID,
+	}
+	nic, response, err := sess.GetInstanceNetworkInterface(getnicoptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting network interface %s of instance %s: %s\n%s", nicID, instanceID, err, response)
+	}
+
+	d.Set(isInstanceNicInstance, instanceID)
+	d.Set(isInstanceNicID, *nic.ID)
+	d.Set(isInstanceNicName, *nic.Name)
+	d.Set(isInstanceNicSubnet, *nic.Subnet.ID)
+	d.Set(isInstanceNicPrimaryIpv4Address, *nic.PrimaryIpv4Address)
+	d.Set(isInstanceNicAllowIPSpoofing, *nic.AllowIPSpoofing)
+	d.Set(isInstanceNicPortSpeed, *nic.PortSpeed)
+	d.Set(isInstanceNicStatus, *nic.Status)
+	d.Set(isInstanceNicType, *nic.Type)
+
+	secgrpList := []string{}
+	for _, sg := range nic.SecurityGroups {
+		secgrpList = append(secgrpList, *sg.ID)
+	}
+	d.Set(isInstanceNicSecurityGroups, newStringSet(schema.HashString, secgrpList))
+
+	d.Set(isInstanceNicFloatingIP, "")
+	d.Set(isInstanceNicFloatingIPAddress, "")
+	if len(nic.FloatingIps) > 0 {
+		d.Set(isInstanceNicFloatingIP, *nic.FloatingIps[0].ID)
+		d.Set(isInstanceNicFloatingIPAddress, *nic.FloatingIps[0].Address)
+	}
+	return nil
+}
+
+func resourceIBMISInstanceNetworkInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	instanceID := parts[0]
+	nicID := parts[1]
+
+	if d.HasChange(isInstanceNicName) || d.HasChange(isInstanceNicAllowIPSpoofing) {
+		name := d.Get(isInstanceNicName).(string)
+		ipSpoofing := d.Get(isInstanceNicAllowIPSpoofing).(bool)
+		nicPatchModel := &vpcv1.NetworkInterfacePatch{
+			Name:            &name,
+			AllowIPSpoofing: &ipSpoofing,
+		}
+		nicPatch, err := nicPatchModel.AsPatch()
+		if err != nil {
+			return fmt.Errorf("Error calling asPatch for NetworkInterfacePatch: %s", err)
+		}
+		updatenicoptions := &vpcv1.UpdateInstanceNetworkInterfaceOptions{
+			InstanceID:            &instanceID,
+			ID:                    &nicID,
+			NetworkInterfacePatch: nicPatch,
+		}
+		_, response, err := sess.UpdateInstanceNetworkInterface(updatenicoptions)
+		if err != nil {
+			return fmt.Errorf("Error updating network interface %s of instance %s: %s\n%s", nicID, instanceID, err, response)
+		}
+	}
+
+	if d.HasChange(isInstanceNicSecurityGroups) {
+		o, n := d.GetChange(isInstanceNicSecurityGroups)
+		ov := o.(*schema.Set)
+		nv := n.(*schema.Set)
+		for _, sg := range expandStringList(nv.Difference(ov).List()) {
+			sgID := sg
+			addsgoptions := &vpcv1.AddSecurityGroupNetworkInterfaceOptions{
+				SecurityGroupID: &sgID,
+				ID:              &nicID,
+			}
+			_, response, err := sess.AddSecurityGroupNetworkInterface(addsgoptions)
+			if err != nil {
+				return fmt.Errorf("Error adding security group %s to network interface %s: %s\n%s", sgID, nicID, err, response)
+			}
+		}
+		for _, sg := range expandStringList(ov.Difference(nv).List()) {
+			sgID := sg
+			removesgoptions := &vpcv1.RemoveSecurityGroupNetworkInterfaceOptions{
+				SecurityGroupID: &sgID,
+				ID:              &nicID,
+			}
+			response, err := sess.RemoveSecurityGroupNetworkInterface(removesgoptions)
+			if err != nil {
+				return fmt.Errorf("Error removing security group %s from network interface %s: %s\n%s", sgID, nicID, err, response)
+			}
+		}
+	}
+
+	if d.HasChange(isInstanceNicFloatingIP) {
+		o, n := d.GetChange(isInstanceNicFloatingIP)
+		if oldFip := o.(string); oldFip != "" {
+			removefipoptions := &vpcv1.RemoveInstanceNetworkInterfaceFloatingIPOptions{
+				InstanceID:         &instanceID,
+				NetworkInterfaceID: &nicID,
+				ID:                 &oldFip,
+			}
+			response, err := sess.RemoveInstanceNetworkInterfaceFloatingIP(removefipoptions)
+			if err != nil && (response == nil || response.StatusCode != 404) {
+				return fmt.Errorf("Error disassociating floating IP %s from network interface %s: %s\n%s", oldFip, nicID, err, response)
+			}
+		}
+		if newFip := n.(string); newFip != "" {
+			addfipoptions := &vpcv1.AddInstanceNetworkInterfaceFloatingIPOptions{
+				InstanceID:         &instanceID,
+				NetworkInterfaceID: &nicID,
+				ID:                 &newFip,
+			}
+			_, response, err := sess.AddInstanceNetworkInterfaceFloatingIP(addfipoptions)
+			if err != nil {
+				return fmt.Errorf("Error associating floating IP %s with network interface %s: %s\n%s", newFip, nicID, err, response)
+			}
+		}
+	}
+
+	return resourceIBMISInstanceNetworkInterfaceRead(d, meta)
+}
+
+func resourceIBMISInstanceNetworkInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	instanceID := parts[0]
+	nicID := parts[1]
+
+	err = isWithInstanceStopped(sess, instanceID, d.Timeout(schema.TimeoutDelete), func() error {
+		deletenicoptions := &vpcv1.DeleteInstanceNetworkInterfaceOptions{
+			InstanceID: &instanceID,
+			ID:         &nicID,
+		}
+		response, err := sess.DeleteInstanceNetworkInterface(deletenicoptions)
+		if err != nil {
+			if response != nil && response.StatusCode == 404 {
+				return nil
+			}
+			return fmt.Errorf("Error deleting network interface %s of instance %s: %s\n%s", nicID, instanceID, err, response)
+		}
+		_, err = isWaitForInstanceNetworkInterfaceDeleted(sess, instanceID, nicID, d.Timeout(schema.TimeoutDelete))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISInstanceNetworkInterfaceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+	if len(parts) != 2 {
+		return false, fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceID/networkInterfaceID", d.Id())
+	}
+
+	getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+		InstanceID: &parts[0],
+		ID:         &parts[1],
+	}
+	_, response, err := sess.GetInstanceNetworkInterface(getnicoptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting network interface %s: %s\n%s", d.Id(), err, response)
+	}
+	return true, nil
+}
+
+// isWithInstanceStopped runs attach, which adds or removes a network interface
+// of the instance, while the instance is stopped, as the VPC API requires. An
+// instance that was running is started again afterwards. Network interfaces
+// of the same instance are attached one at a time so that one does not start
+// the instance while another one is being attached. The waiters are given no
+// ResourceData, so that the status of the instance isn't set on the network
+// interface.
+func isWithInstanceStopped(sess *vpcv1.VpcV1, instanceID string, timeout time.Duration, attach func() error) error {
+	ibmMutexKV.Lock(isInstanceKey(instanceID))
+	defer ibmMutexKV.Unlock(isInstanceKey(instanceID))
+
+	getinsoptions := &vpcv1.GetInstanceOptions{
+		ID: &instanceID,
+	}
+	instance, response, err := sess.GetInstance(getinsoptions)
+	if err != nil {
+		return fmt.Errorf("Error getting instance %s: %s\n%s", instanceID, err, response)
+	}
+
+	running := *instance.Status == isInstanceStatusRunning
+	if running {
+		log.Printf("[DEBUG] Stopping instance %s to attach or detach a network interface", instanceID)
+		if err := isCreateInstanceAction(sess, instanceID, "stop", false); err != nil {
+			return err
+		}
+		_, err = isWaitForInstanceActionStop(sess, timeout, instanceID, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	attachErr := attach()
+
+	if running {
+		log.Printf("[DEBUG] Starting instance %s again", instanceID)
+		if err := isCreateInstanceAction(sess, instanceID, "start", false); err != nil {
+			if attachErr != nil {
+				return fmt.Errorf("%s, and then %s", attachErr, err)
+			}
+			return err
+		}
+		_, err = isWaitForInstanceAvailable(sess, instanceID, timeout, nil)
+		if err != nil && attachErr == nil {
+			return err
+		}
+	}
+	return attachErr
+}
+
+func isWaitForInstanceNetworkInterfaceAvailable(sess *vpcv1.VpcV1, instanceID, nicID string, timeout time.Duration) (interface{}, error) {
+	log.Printf("Waiting for network interface (%s) of instance (%s) to be available.", nicID, instanceID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{isInstanceNicStatusPending},
+		Target:  []string{isInstanceNicStatusAvailable, isInstanceNicStatusFailed},
+		Refresh: func() (interface{}, string, error) {
+			getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+				InstanceID: &instanceID,
+				ID:         &nicID,
+			}
+			nic, response, err := sess.GetInstanceNetworkInterface(getnicoptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("Error getting network interface %s: %s\n%s", nicID, err, response)
+			}
+			if *nic.Status == isInstanceNicStatusFailed {
+				return nic, *nic.Status, fmt.Errorf("The network interface %s of instance %s failed", nicID, instanceID)
+			}
+			return nic, *nic.Status, nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func isWaitForInstanceNetworkInterfaceDeleted(sess *vpcv1.VpcV1, instanceID, nicID string, timeout time.Duration) (interface{}, error) {
+	log.Printf("Waiting for network interface (%s) of instance (%s) to be deleted.", nicID, instanceID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{isInstanceNicStatusDeleting, isInstanceNicStatusAvailable},
+		Target:  []string{isInstanceNicDeleteDone, ""},
+		Refresh: func() (interface{}, string, error) {
+			getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+				InstanceID: &instanceID,
+				ID:         &nicID,
+			}
+			nic, response, err := sess.GetInstanceNetworkInterface(getnicoptions)
+			if err != nil {
+				if response != nil && response.StatusCode == 404 {
+					return nic, isInstanceNicDeleteDone, nil
+				}
+				return nil, "", fmt.Errorf("Error getting network interface %s: %s\n%s", nicID, err, response)
+			}
+			if *nic.Status == isInstanceNicStatusFailed {
+				return nic, *nic.Status, fmt.Errorf("The network interface %s of instance %s failed to delete", nicID, instanceID)
+			}
+			return nic, *nic.Status, nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
@@ -0,0 +1,153 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMISInstanceNetworkInterface_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf-instance-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	nicname := fmt.Sprintf("tf-nic-%d", acctest.RandIntRange(10, 100))
+	nicnameUpdate := fmt.Sprintf("tf-nic-upd-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tfssh-name-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISInstanceNetworkInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstanceNetworkInterfaceConfig(vpcname, subnetname, sshname, publicKey, name, nicname, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceNetworkInterfaceExists("ibm_is_instance_network_interface.testacc_nic"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_network_interface.testacc_nic", "name", nicname),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_network_interface.testacc_nic", "allow_ip_spoofing", "false"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_instance_network_interface.testacc_nic", "primary_ipv4_address"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "network_interfaces.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceNetworkInterfaceConfig(vpcname, subnetname, sshname, publicKey, name, nicnameUpdate, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceNetworkInterfaceExists("ibm_is_instance_network_interface.testacc_nic"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_network_interface.testacc_nic", "name", nicnameUpdate),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_network_interface.testacc_nic", "allow_ip_spoofing", "true"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_instance_network_interface.testacc_nic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISInstanceNetworkInterfaceDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_instance_network_interface" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+			InstanceID: &parts[0],
+			ID:         &parts[1],
+		}
+		_, _, err1 := sess.GetInstanceNetworkInterface(getnicoptions)
+		if err1 == nil {
+			return fmt.Errorf("instance network interface still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISInstanceNetworkInterfaceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		getnicoptions := &vpcv1.GetInstanceNetworkInterfaceOptions{
+			InstanceID: &parts[0],
+			ID:         &parts[1],
+		}
+		_, _, err = sess.GetInstanceNetworkInterface(getnicoptions)
+		return err
+	}
+}
+
+func testAccCheckIBMISInstanceNetworkInterfaceConfig(vpcname, subnetname, sshname, publicKey, name, nicname string, ipSpoofing bool) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+
+	  resource "ibm_is_ssh_key" "testacc_sshkey" {
+		name       = "%s"
+		public_key = "%s"
+	  }
+
+	  resource "ibm_is_instance" "testacc_instance" {
+		name    = "%s"
+		image   = "%s"
+		profile = "%s"
+
+		primary_network_interface {
+		  subnet = ibm_is_subnet.testacc_subnet.id
+		}
+
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		zone = "%s"
+		keys = [ibm_is_ssh_key.testacc_sshkey.id]
+	  }
+
+	  resource "ibm_is_instance_network_interface" "testacc_nic" {
+		instance          = ibm_is_instance.testacc_instance.id
+		subnet            = ibm_is_subnet.testacc_subnet.id
+		name              = "%s"
+		allow_ip_spoofing = %t
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, nicname, ipSpoofing)
+}
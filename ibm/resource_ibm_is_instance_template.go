@@ -52,6 +52,26 @@ func resourceIBMISInstanceTemplate() *schema.Resource {
 			func(diff *schema.ResourceDiff, v interface{}) error {
 				return resourceTagsCustomizeDiff(diff)
 			},
+			customdiff.If(
+				func(diff *schema.ResourceDiff, v interface{}) bool {
+					return diff.Id() == "" || diff.HasChange(isInstanceTemplateProfile) || diff.HasChange(isInstanceTemplateImage) ||
+						diff.HasChange(isInstanceTemplateZone) || diff.HasChange(isInstanceTemplateVolumeAttachments) ||
+						diff.HasChange(isInstanceTemplateBootVolume)
+				},
+				func(diff *schema.ResourceDiff, v interface{}) error {
+					volumes := []string{}
+					for i, attachment := range diff.Get(isInstanceTemplateVolumeAttachments).([]interface{}) {
+						if diff.NewValueKnown(fmt.Sprintf("%s.%d.%s", isInstanceTemplateVolumeAttachments, i, isInstanceTemplateVolAttVolume)) {
+							volumes = append(volumes, attachment.(map[string]interface{})[isInstanceTemplateVolAttVolume].(string))
+						}
+					}
+					bootProfile := ""
+					if diff.NewValueKnown(isInstanceTemplateBootVolume + ".0." + isInstanceTemplateBootProfile) {
+						bootProfile = diff.Get(isInstanceTemplateBootVolume + ".0." + isInstanceTemplateBootProfile).(string)
+					}
+					return resourceIBMISInstanceCompatibilityCustomizeDiff(diff, v, volumes, bootProfile, 0)
+				},
+			),
 		),
 
 		Schema: map[string]*schema.Schema{
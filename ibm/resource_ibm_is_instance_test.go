@@ -128,6 +128,151 @@ ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVE
 	})
 }
 
+func TestAccIBMISInstance_PowerState(t *testing.T) {
+	var instance string
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf-instnace-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tf-ssh-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstancePowerStateConfig(vpcname, subnetname, sshname, publicKey, name, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceExists("ibm_is_instance.testacc_instance", instance),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "power_state", "stopped"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "status", "stopped"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstancePowerStateConfig(vpcname, subnetname, sshname, publicKey, name, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "power_state", "running"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsInstancePowerStateOf(t *testing.T) {
+	cases := map[string]string{
+		"running":  "running",
+		"starting": "running",
+		"pending":  "running",
+		"stopping": "stopped",
+		"stopped":  "stopped",
+	}
+	for status, want := range cases {
+		if got := isInstancePowerStateOf(status); got != want {
+			t.Errorf("isInstancePowerStateOf(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestValidateInstanceProfileImage(t *testing.T) {
+	profileName := "bx2-2x8"
+	imageName := "ibm-zos-2-4-s390x"
+	amd64 := "amd64"
+	s390x := "s390x"
+	profile := &vpcv1.InstanceProfile{
+		Name: &profileName,
+		OsArchitecture: &vpcv1.InstanceProfileOsArchitecture{
+			Default: &amd64,
+			Values:  []string{amd64},
+		},
+	}
+	image := &vpcv1.Image{
+		Name:            &imageName,
+		OperatingSystem: &vpcv1.OperatingSystem{Architecture: &s390x},
+	}
+	err := validateInstanceProfileImage(profile, image)
+	if err == nil || !strings.Contains(err.Error(), "s390x") {
+		t.Fatalf("expected an architecture error for an s390x image on an amd64 profile, got %v", err)
+	}
+
+	image.OperatingSystem.Architecture = &amd64
+	if err := validateInstanceProfileImage(profile, image); err != nil {
+		t.Fatalf("expected an amd64 image to be accepted, got %v", err)
+	}
+}
+
+func TestValidateInstanceProfileBandwidth(t *testing.T) {
+	profileName := "bx2-2x8"
+	bandwidth := int64(4000)
+	profile := &vpcv1.InstanceProfile{
+		Name:      &profileName,
+		Bandwidth: &vpcv1.InstanceProfileBandwidth{Value: &bandwidth},
+	}
+	if err := validateInstanceProfileBandwidth(profile, 1000); err != nil {
+		t.Fatalf("expected a port speed of 1000 to be accepted, got %v", err)
+	}
+	if err := validateInstanceProfileBandwidth(profile, 16000); err == nil {
+		t.Fatalf("expected a port speed of 16000 to be rejected")
+	}
+}
+
+func TestValidateInstanceZoneVolumes(t *testing.T) {
+	zoneName := "us-south-1"
+	otherZoneName := "us-south-2"
+	available := vpcv1.ZoneStatusAvailableConst
+	impaired := vpcv1.ZoneStatusImpairedConst
+	volumeName := "data"
+	zone := &vpcv1.Zone{Name: &zoneName, Status: &available}
+	volumes := []vpcv1.Volume{{Name: &volumeName, Zone: &vpcv1.ZoneReference{Name: &zoneName}}}
+	if err := validateInstanceZoneVolumes(zone, volumes); err != nil {
+		t.Fatalf("expected a volume in the same zone to be accepted, got %v", err)
+	}
+
+	volumes[0].Zone.Name = &otherZoneName
+	if err := validateInstanceZoneVolumes(zone, volumes); err == nil {
+		t.Fatalf("expected a volume in zone %s to be rejected", otherZoneName)
+	}
+
+	zone.Status = &impaired
+	if err := validateInstanceZoneVolumes(zone, nil); err == nil {
+		t.Fatalf("expected an impaired zone to be rejected")
+	}
+}
+
+func TestValidateInstanceZoneVolumeProfiles(t *testing.T) {
+	zoneName := "us-south-1"
+	region := "us-south"
+	otherRegion := "eu-de"
+	generalPurpose := "general-purpose"
+	tiered := "10iops-tier"
+	zone := &vpcv1.Zone{Name: &zoneName, Region: &vpcv1.RegionReference{Name: &region}}
+	available := []vpcv1.VolumeProfile{{Name: &generalPurpose}, {Name: &tiered}}
+	if err := validateInstanceZoneVolumeProfiles(zone, region, available, []string{generalPurpose, tiered}); err != nil {
+		t.Fatalf("expected the profiles of the region to be accepted, got %v", err)
+	}
+
+	err := validateInstanceZoneVolumeProfiles(zone, region, available, []string{"5iops-tier"})
+	if err == nil || !strings.Contains(err.Error(), "not available in zone us-south-1") {
+		t.Fatalf("expected a profile that the region doesn't list to be rejected, got %v", err)
+	}
+
+	err = validateInstanceZoneVolumeProfiles(nil, region, available, []string{"5iops-tier"})
+	if err == nil || !strings.Contains(err.Error(), "not available in region us-south") {
+		t.Fatalf("expected a profile to be checked against the region when the zone isn't known, got %v", err)
+	}
+
+	if err := validateInstanceZoneVolumeProfiles(zone, otherRegion, available, []string{generalPurpose}); err == nil {
+		t.Fatalf("expected a zone outside of region %s to be rejected", otherRegion)
+	}
+}
+
 func testAccCheckIBMISInstanceDestroy(s *terraform.State) error {
 	userDetails, _ := testAccProvider.Meta().(ClientSession).BluemixUserDetails()
 
@@ -238,6 +383,38 @@ func testAccCheckIBMISInstanceConfig(vpcname, subnetname, sshname, publicKey, na
 	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName)
 }
 
+func testAccCheckIBMISInstancePowerStateConfig(vpcname, subnetname, sshname, publicKey, name, powerState string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+
+	  resource "ibm_is_ssh_key" "testacc_sshkey" {
+		name       = "%s"
+		public_key = "%s"
+	  }
+
+	  resource "ibm_is_instance" "testacc_instance" {
+		name    = "%s"
+		image   = "%s"
+		profile = "%s"
+		primary_network_interface {
+		  subnet     = ibm_is_subnet.testacc_subnet.id
+		}
+		vpc         = ibm_is_vpc.testacc_vpc.id
+		zone        = "%s"
+		keys        = [ibm_is_ssh_key.testacc_sshkey.id]
+		power_state = "%s"
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, powerState)
+}
+
 func testAccCheckIBMISInstanceConfigwithipv4(vpcname, subnetname, sshname, publicKey, name, ipv4address string) string {
 	return fmt.Sprintf(`
 	resource "ibm_is_vpc" "testacc_vpc" {
@@ -0,0 +1,264 @@
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	isInstanceVolAttInstance               = "instance"
+	isInstanceVolAttDeleteVolumeOnInstance = "delete_volume_on_instance_delete"
+	isInstanceVolAttID                     = "volume_attachment_id"
+	isInstanceVolAttDevice                 = "device"
+	isInstanceVolAttStatus                 = "status"
+	isInstanceVolAttType                   = "type"
+	isInstanceVolAttVolumeName             = "volume_name"
+	isInstanceVolAttVolumeCRN              = "volume_crn"
+)
+
+func resourceIBMISInstanceVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISInstanceVolumeAttachmentCreate,
+		Read:     resourceIBMISInstanceVolumeAttachmentRead,
+		Update:   resourceIBMISInstanceVolumeAttachmentUpdate,
+		Delete:   resourceIBMISInstanceVolumeAttachmentDelete,
+		Exists:   resourceIBMISInstanceVolumeAttachmentExists,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isInstanceVolAttInstance: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Instance ID the volume is attached to",
+			},
+
+			isInstanceVolAttVolume: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the attached volume",
+			},
+
+			isInstanceVolAttName: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the volume attachment",
+			},
+
+			isInstanceVolAttDeleteVolumeOnInstance: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the volume is deleted when the instance is deleted",
+			},
+
+			isInstanceVolAttID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the volume attachment",
+			},
+
+			isInstanceVolAttDevice: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the device the volume is attached as",
+			},
+
+			isInstanceVolAttStatus: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the volume attachment",
+			},
+
+			isInstanceVolAttType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the volume attachment, boot or data",
+			},
+
+			isInstanceVolAttVolumeName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the attached volume",
+			},
+
+			isInstanceVolAttVolumeCRN: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CRN of the attached volume",
+			},
+		},
+	}
+}
+
+func resourceIBMISInstanceVolumeAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	instanceID := d.Get(isInstanceVolAttInstance).(string)
+	volumeID := d.Get(isInstanceVolAttVolume).(string)
+	deleteVolume := d.Get(isInstanceVolAttDeleteVolumeOnInstance).(bool)
+
+	createvolattoptions := &vpcv1.CreateInstanceVolumeAttachmentOptions{
+		InstanceID: &instanceID,
+		Volume: &vpcv1.VolumeIdentity{
+			ID: &volumeID,
+		},
+		DeleteVolumeOnInstanceDelete: &deleteVolume,
+	}
+	if v, ok := d.GetOk(isInstanceVolAttName); ok {
+		name := v.(string)
+		createvolattoptions.Name = &name
+	}
+	vol, err := isInstanceVolumeAttach(instanceC, createvolattoptions, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", instanceID, *vol.ID))
+	return resourceIBMISInstanceVolumeAttachmentRead(d, meta)
+}
+
+func resourceIBMISInstanceVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceID/volumeAttachmentID", d.Id())
+	}
+	instanceID := parts[0]
+	attachmentID := parts[1]
+
+	getvolattoptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
+		InstanceID: &instanceID,
+		ID:         &attachmentID,
+	}
+	vol, response, err := instanceC.GetInstanceVolumeAttachment(getvolattoptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting volume attachment %s of instance %s: %s\n%s", attachmentID, instanceID, err, response)
+	}
+
+	d.Set(isInstanceVolAttInstance, instanceID)
+	d.Set(isInstanceVolAttID, *vol.ID)
+	d.Set(isInstanceVolAttName, *vol.Name)
+	d.Set(isInstanceVolAttStatus, *vol.Status)
+	d.Set(isInstanceVolAttType, *vol.Type)
+	if vol.DeleteVolumeOnInstanceDelete != nil {
+		d.Set(isInstanceVolAttDeleteVolumeOnInstance, *vol.DeleteVolumeOnInstanceDelete)
+	}
+	if vol.Device != nil && vol.Device.ID != nil {
+		d.Set(isInstanceVolAttDevice, *vol.Device.ID)
+	}
+	if vol.Volume != nil {
+		d.Set(isInstanceVolAttVolume, *vol.Volume.ID)
+		d.Set(isInstanceVolAttVolumeName, *vol.Volume.Name)
+		d.Set(isInstanceVolAttVolumeCRN, *vol.Volume.CRN)
+	}
+	return nil
+}
+
+func resourceIBMISInstanceVolumeAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceID/volumeAttachmentID", d.Id())
+	}
+	instanceID := parts[0]
+	attachmentID := parts[1]
+
+	if d.HasChange(isInstanceVolAttName) || d.HasChange(isInstanceVolAttDeleteVolumeOnInstance) {
+		name := d.Get(isInstanceVolAttName).(string)
+		deleteVolume := d.Get(isInstanceVolAttDeleteVolumeOnInstance).(bool)
+		volAttPatchModel := &vpcv1.VolumeAttachmentPatch{
+			Name:                         &name,
+			DeleteVolumeOnInstanceDelete: &deleteVolume,
+		}
+		volAttPatch, err := volAttPatchModel.AsPatch()
+		if err != nil {
+			return fmt.Errorf("Error calling asPatch for VolumeAttachmentPatch: %s", err)
+		}
+		updatevolattoptions := &vpcv1.UpdateInstanceVolumeAttachmentOptions{
+			InstanceID:            &instanceID,
+			ID:                    &attachmentID,
+			VolumeAttachmentPatch: volAttPatch,
+		}
+		_, response, err := instanceC.UpdateInstanceVolumeAttachment(updatevolattoptions)
+		if err != nil {
+			return fmt.Errorf("Error updating volume attachment %s of instance %s: %s\n%s", attachmentID, instanceID, err, response)
+		}
+	}
+	return resourceIBMISInstanceVolumeAttachmentRead(d, meta)
+}
+
+func resourceIBMISInstanceVolumeAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceID/volumeAttachmentID", d.Id())
+	}
+	err = isInstanceVolumeDetach(instanceC, parts[0], parts[1], d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISInstanceVolumeAttachmentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	instanceC, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+	if len(parts) != 2 {
+		return false, fmt.Errorf("Incorrect ID %s: ID should be a combination of instanceID/volumeAttachmentID", d.Id())
+	}
+
+	getvolattoptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
+		InstanceID: &parts[0],
+		ID:         &parts[1],
+	}
+	_, response, err := instanceC.GetInstanceVolumeAttachment(getvolattoptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting volume attachment %s: %s\n%s", d.Id(), err, response)
+	}
+	return true, nil
+}
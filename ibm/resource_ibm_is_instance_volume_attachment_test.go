@@ -0,0 +1,157 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMISInstanceVolumeAttachment_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf-instance-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	volname := fmt.Sprintf("tf-vol-%d", acctest.RandIntRange(10, 100))
+	attname := fmt.Sprintf("tf-volatt-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tfssh-name-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISInstanceVolumeAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstanceVolumeAttachmentConfig(vpcname, subnetname, sshname, publicKey, name, volname, attname, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceVolumeAttachmentExists("ibm_is_instance_volume_attachment.testacc_volatt"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_volume_attachment.testacc_volatt", "name", attname),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_volume_attachment.testacc_volatt", "delete_volume_on_instance_delete", "false"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_volume_attachment.testacc_volatt", "type", "data"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance.testacc_instance", "volumes.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceVolumeAttachmentConfig(vpcname, subnetname, sshname, publicKey, name, volname, attname, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceVolumeAttachmentExists("ibm_is_instance_volume_attachment.testacc_volatt"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_volume_attachment.testacc_volatt", "delete_volume_on_instance_delete", "true"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_instance_volume_attachment.testacc_volatt",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISInstanceVolumeAttachmentDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_instance_volume_attachment" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		getvolattoptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
+			InstanceID: &parts[0],
+			ID:         &parts[1],
+		}
+		_, _, err1 := sess.GetInstanceVolumeAttachment(getvolattoptions)
+		if err1 == nil {
+			return fmt.Errorf("instance volume attachment still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISInstanceVolumeAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		getvolattoptions := &vpcv1.GetInstanceVolumeAttachmentOptions{
+			InstanceID: &parts[0],
+			ID:         &parts[1],
+		}
+		_, _, err = sess.GetInstanceVolumeAttachment(getvolattoptions)
+		return err
+	}
+}
+
+func testAccCheckIBMISInstanceVolumeAttachmentConfig(vpcname, subnetname, sshname, publicKey, name, volname, attname string, deleteVolume bool) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+
+	  resource "ibm_is_ssh_key" "testacc_sshkey" {
+		name       = "%s"
+		public_key = "%s"
+	  }
+
+	  resource "ibm_is_instance" "testacc_instance" {
+		name    = "%s"
+		image   = "%s"
+		profile = "%s"
+
+		primary_network_interface {
+		  subnet = ibm_is_subnet.testacc_subnet.id
+		}
+
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		zone = "%s"
+		keys = [ibm_is_ssh_key.testacc_sshkey.id]
+	  }
+
+	  resource "ibm_is_volume" "testacc_volume" {
+		name    = "%s"
+		profile = "10iops-tier"
+		zone    = "%s"
+	  }
+
+	  resource "ibm_is_instance_volume_attachment" "testacc_volatt" {
+		instance                         = ibm_is_instance.testacc_instance.id
+		volume                           = ibm_is_volume.testacc_volume.id
+		name                             = "%s"
+		delete_volume_on_instance_delete = %t
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, volname, ISZoneName, attname, deleteVolume)
+}
@@ -25,7 +25,7 @@ const (
 )
 
 func resourceIBMISIPSecPolicy() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_ipsec_policy", &schema.Resource{
 		Create:   resourceIBMISIPSecPolicyCreate,
 		Read:     resourceIBMISIPSecPolicyRead,
 		Update:   resourceIBMISIPSecPolicyUpdate,
@@ -134,7 +134,7 @@ func resourceIBMISIPSecPolicy() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISIPSECValidator() *ResourceValidator {
@@ -34,7 +34,7 @@ const (
 )
 
 func resourceIBMISLB() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb", &schema.Resource{
 		Create:   resourceIBMISLBCreate,
 		Read:     resourceIBMISLBRead,
 		Update:   resourceIBMISLBUpdate,
@@ -49,7 +49,7 @@ func resourceIBMISLB() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -119,6 +119,8 @@ func resourceIBMISLB() *schema.Resource {
 				Set:      resourceIBMVPCHash,
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			isLBResourceGroup: {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -149,7 +151,7 @@ func resourceIBMISLB() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBValidator() *ResourceValidator {
@@ -259,8 +261,8 @@ func classicLBCreate(d *schema.ResourceData, meta interface{}, name, lbType, rg
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isLBTags); ok || v != "" {
-		oldList, newList := d.GetChange(isLBTags)
+	if _, ok := d.GetOk(isLBTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *lb.CRN)
 		if err != nil {
 			log.Printf(
@@ -315,8 +317,8 @@ func lbCreate(d *schema.ResourceData, meta interface{}, name, lbType, rg string,
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isLBTags); ok || v != "" {
-		oldList, newList := d.GetChange(isLBTags)
+	if _, ok := d.GetOk(isLBTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *lb.CRN)
 		if err != nil {
 			log.Printf(
@@ -407,7 +409,7 @@ func classicLBGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc Load Balancer (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isLBTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -487,7 +489,7 @@ func lbGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc Load Balancer (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isLBTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -533,7 +535,7 @@ func classicLBUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isLBTags) {
+	if d.HasChange(isLBTags) || d.HasChange(tagsAll) {
 		getLoadBalancerOptions := &vpcclassicv1.GetLoadBalancerOptions{
 			ID: &id,
 		}
@@ -541,7 +543,7 @@ func classicLBUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting Load Balancer : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isLBTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *lb.CRN)
 		if err != nil {
 			log.Printf(
@@ -575,7 +577,7 @@ func lbUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasChan
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isLBTags) {
+	if d.HasChange(isLBTags) || d.HasChange(tagsAll) {
 		getLoadBalancerOptions := &vpcv1.GetLoadBalancerOptions{
 			ID: &id,
 		}
@@ -583,7 +585,7 @@ func lbUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasChan
 		if err != nil {
 			return fmt.Errorf("Error getting Load Balancer : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isLBTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *lb.CRN)
 		if err != nil {
 			log.Printf(
@@ -28,7 +28,7 @@ const (
 )
 
 func resourceIBMISLBListener() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb_listener", &schema.Resource{
 		Create:   resourceIBMISLBListenerCreate,
 		Read:     resourceIBMISLBListenerRead,
 		Update:   resourceIBMISLBListenerUpdate,
@@ -120,7 +120,7 @@ func resourceIBMISLBListener() *schema.Resource {
 				Description: "The crn of the LB resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBListenerValidator() *ResourceValidator {
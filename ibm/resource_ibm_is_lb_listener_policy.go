@@ -41,7 +41,7 @@ const (
 )
 
 func resourceIBMISLBListenerPolicy() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb_listener_policy", &schema.Resource{
 		Create:   resourceIBMISLBListenerPolicyCreate,
 		Read:     resourceIBMISLBListenerPolicyRead,
 		Update:   resourceIBMISLBListenerPolicyUpdate,
@@ -220,7 +220,7 @@ func resourceIBMISLBListenerPolicy() *schema.Resource {
 				Description: "The crn of the LB resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBListenerPolicyValidator() *ResourceValidator {
@@ -33,7 +33,7 @@ const (
 )
 
 func resourceIBMISLBListenerPolicyRule() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb_listener_policy_rule", &schema.Resource{
 		Create:   resourceIBMISLBListenerPolicyRuleCreate,
 		Read:     resourceIBMISLBListenerPolicyRuleRead,
 		Update:   resourceIBMISLBListenerPolicyRuleUpdate,
@@ -151,7 +151,7 @@ func resourceIBMISLBListenerPolicyRule() *schema.Resource {
 				Description: "The crn of the LB resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBListenerPolicyRuleValidator() *ResourceValidator {
@@ -36,7 +36,7 @@ const (
 )
 
 func resourceIBMISLBPool() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb_pool", &schema.Resource{
 		Create:   resourceIBMISLBPoolCreate,
 		Read:     resourceIBMISLBPoolRead,
 		Update:   resourceIBMISLBPoolUpdate,
@@ -149,7 +149,7 @@ func resourceIBMISLBPool() *schema.Resource {
 				Description: "The crn of the LB resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBPoolValidator() *ResourceValidator {
@@ -28,7 +28,7 @@ const (
 )
 
 func resourceIBMISLBPoolMember() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_lb_pool_member", &schema.Resource{
 		Create:   resourceIBMISLBPoolMemberCreate,
 		Read:     resourceIBMISLBPoolMemberRead,
 		Update:   resourceIBMISLBPoolMemberUpdate,
@@ -126,7 +126,7 @@ func resourceIBMISLBPoolMember() *schema.Resource {
 				Description: "The crn of the LB resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISLBPoolMemberCreate(d *schema.ResourceData, meta interface{}) error {
@@ -0,0 +1,571 @@
+package ibm
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const (
+	isNetworkACLRuleNetworkACL = "network_acl"
+	isNetworkACLRuleRuleID     = "rule_id"
+	isNetworkACLRuleBefore     = "before"
+
+	// isNetworkACLRuleICMPAny is the ICMP type or code of a rule that matches
+	// any type or code, so that 0 remains a valid type and code
+	isNetworkACLRuleICMPAny = -1
+)
+
+func resourceIBMISNetworkACLRule() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISNetworkACLRuleCreate,
+		Read:     resourceIBMISNetworkACLRuleRead,
+		Update:   resourceIBMISNetworkACLRuleUpdate,
+		Delete:   resourceIBMISNetworkACLRuleDelete,
+		Exists:   resourceIBMISNetworkACLRuleExists,
+		Importer: &schema.ResourceImporter{},
+
+		CustomizeDiff: resourceIBMISNetworkACLRuleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			isNetworkACLRuleNetworkACL: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Network ACL id",
+			},
+
+			isNetworkACLRuleRuleID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rule id",
+			},
+
+			isNetworkACLRuleBefore: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The rule that this rule is immediately before. If unspecified, this rule is inserted after all existing rules",
+			},
+
+			isNetworkACLRuleName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleName),
+				Description:  "The user-defined name for this rule",
+			},
+
+			isNetworkACLRuleAction: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleAction),
+				Description:  "Whether to allow or deny matching traffic",
+			},
+
+			isNetworkACLRuleSource: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleSource),
+				Description:  "The source IP address or CIDR block",
+			},
+
+			isNetworkACLRuleDestination: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleDestination),
+				Description:  "The destination IP address or CIDR block",
+			},
+
+			isNetworkACLRuleDirection: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleDirection),
+				Description:  "Direction of traffic to enforce, either inbound or outbound",
+			},
+
+			isNetworkACLRuleIPVersion: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP version for this rule",
+			},
+
+			isNetworkACLRuleProtocol: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The protocol of the rule: all, icmp, tcp or udp",
+			},
+
+			isNetworkACLRuleICMP: {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Optional:      true,
+				ConflictsWith: []string{isNetworkACLRuleTCP, isNetworkACLRuleUDP},
+				Description:   "protocol=icmp",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isNetworkACLRuleICMPCode: {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  isNetworkACLRuleICMPAny,
+							ValidateFunc: validation.Any(
+								validation.IntInSlice([]int{isNetworkACLRuleICMPAny}),
+								InvokeValidator("ibm_is_network_acl", isNetworkACLRuleICMPCode)),
+						},
+						isNetworkACLRuleICMPType: {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  isNetworkACLRuleICMPAny,
+							ValidateFunc: validation.Any(
+								validation.IntInSlice([]int{isNetworkACLRuleICMPAny}),
+								InvokeValidator("ibm_is_network_acl", isNetworkACLRuleICMPType)),
+						},
+					},
+				},
+			},
+
+			isNetworkACLRuleTCP: {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Optional:      true,
+				ConflictsWith: []string{isNetworkACLRuleICMP, isNetworkACLRuleUDP},
+				Description:   "protocol=tcp",
+				Elem:          networkACLRulePortsResource(),
+			},
+
+			isNetworkACLRuleUDP: {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Optional:      true,
+				ConflictsWith: []string{isNetworkACLRuleICMP, isNetworkACLRuleTCP},
+				Description:   "protocol=udp",
+				Elem:          networkACLRulePortsResource(),
+			},
+		},
+	}
+}
+
+func networkACLRulePortsResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			isNetworkACLRulePortMax: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      65535,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRulePortMax),
+			},
+			isNetworkACLRulePortMin: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRulePortMin),
+			},
+			isNetworkACLRuleSourcePortMax: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      65535,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleSourcePortMax),
+			},
+			isNetworkACLRuleSourcePortMin: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: InvokeValidator("ibm_is_network_acl", isNetworkACLRuleSourcePortMin),
+			},
+		},
+	}
+}
+
+// resourceIBMISNetworkACLRuleCustomizeDiff replaces the rule when its protocol
+// changes, e.g. from tcp to udp, as the protocol of a rule can't be updated.
+// Ports and ICMP type and code are updated in place.
+func resourceIBMISNetworkACLRuleCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	oldProtocol, _ := diff.GetChange(isNetworkACLRuleProtocol)
+	newProtocol := "all"
+	for _, protocol := range []string{isNetworkACLRuleICMP, isNetworkACLRuleTCP, isNetworkACLRuleUDP} {
+		if len(diff.Get(protocol).([]interface{})) > 0 {
+			newProtocol = protocol
+		}
+	}
+	if oldProtocol.(string) == "" || oldProtocol.(string) == newProtocol {
+		return nil
+	}
+	// The block of the new protocol is added, or the one of the old protocol
+	// is removed when the new protocol is all
+	if newProtocol == "all" {
+		return diff.ForceNew(oldProtocol.(string))
+	}
+	return diff.ForceNew(newProtocol)
+}
+
+// networkACLRuleICMP returns the ICMP type and code of the rule, nil when they
+// are isNetworkACLRuleICMPAny and the rule matches any type or code.
+func networkACLRuleICMP(d *schema.ResourceData) (icmpType, icmpCode *int64) {
+	if v := d.Get(fmt.Sprintf("%s.0.%s", isNetworkACLRuleICMP, isNetworkACLRuleICMPType)).(int); v != isNetworkACLRuleICMPAny {
+		t := int64(v)
+		icmpType = &t
+	}
+	if v := d.Get(fmt.Sprintf("%s.0.%s", isNetworkACLRuleICMP, isNetworkACLRuleICMPCode)).(int); v != isNetworkACLRuleICMPAny {
+		c := int64(v)
+		icmpCode = &c
+	}
+	return
+}
+
+func resourceIBMISNetworkACLRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	nwaclID := d.Get(isNetworkACLRuleNetworkACL).(string)
+	action := d.Get(isNetworkACLRuleAction).(string)
+	source := d.Get(isNetworkACLRuleSource).(string)
+	destination := d.Get(isNetworkACLRuleDestination).(string)
+	direction := d.Get(isNetworkACLRuleDirection).(string)
+
+	ruleTemplate := &vpcv1.NetworkACLRulePrototype{
+		Action:      &action,
+		Destination: &destination,
+		Direction:   &direction,
+		Source:      &source,
+	}
+	if v, ok := d.GetOk(isNetworkACLRuleName); ok {
+		name := v.(string)
+		ruleTemplate.Name = &name
+	}
+	if v, ok := d.GetOk(isNetworkACLRuleBefore); ok {
+		before := v.(string)
+		ruleTemplate.Before = &vpcv1.NetworkACLRuleBeforePrototype{
+			ID: &before,
+		}
+	}
+
+	protocol := "all"
+	if _, ok := d.GetOk(isNetworkACLRuleICMP); ok {
+		protocol = "icmp"
+		ruleTemplate.Type, ruleTemplate.Code = networkACLRuleICMP(d)
+	} else if ports, ok := networkACLRulePorts(d); ok {
+		protocol = ports.protocol
+		ruleTemplate.DestinationPortMin = &ports.min
+		ruleTemplate.DestinationPortMax = &ports.max
+		ruleTemplate.SourcePortMin = &ports.sourceMin
+		ruleTemplate.SourcePortMax = &ports.sourceMax
+	}
+	ruleTemplate.Protocol = &protocol
+
+	isNetworkACLKey := "network_acl_key_" + nwaclID
+	ibmMutexKV.Lock(isNetworkACLKey)
+	defer ibmMutexKV.Unlock(isNetworkACLKey)
+
+	createNetworkACLRuleOptions := &vpcv1.CreateNetworkACLRuleOptions{
+		NetworkACLID:            &nwaclID,
+		NetworkACLRulePrototype: ruleTemplate,
+	}
+	rule, response, err := sess.CreateNetworkACLRule(createNetworkACLRuleOptions)
+	if err != nil {
+		return fmt.Errorf("Error Creating network ACL rule : %s\n%s", err, response)
+	}
+	ruleID := networkACLRuleID(rule)
+	if ruleID == "" {
+		return fmt.Errorf("Error Creating network ACL rule : unexpected rule type %s", reflect.TypeOf(rule))
+	}
+	d.SetId(fmt.Sprintf("%s/%s", nwaclID, ruleID))
+	return resourceIBMISNetworkACLRuleRead(d, meta)
+}
+
+type networkACLRulePortRange struct {
+	protocol  string
+	min       int64
+	max       int64
+	sourceMin int64
+	sourceMax int64
+}
+
+// networkACLRulePorts returns the port ranges of the tcp or udp block, if any
+func networkACLRulePorts(d *schema.ResourceData) (networkACLRulePortRange, bool) {
+	for _, protocol := range []string{isNetworkACLRuleTCP, isNetworkACLRuleUDP} {
+		if _, ok := d.GetOk(protocol); ok {
+			return networkACLRulePortRange{
+				protocol:  protocol,
+				min:       int64(d.Get(fmt.Sprintf("%s.0.%s", protocol, isNetworkACLRulePortMin)).(int)),
+				max:       int64(d.Get(fmt.Sprintf("%s.0.%s", protocol, isNetworkACLRulePortMax)).(int)),
+				sourceMin: int64(d.Get(fmt.Sprintf("%s.0.%s", protocol, isNetworkACLRuleSourcePortMin)).(int)),
+				sourceMax: int64(d.Get(fmt.Sprintf("%s.0.%s", protocol, isNetworkACLRuleSourcePortMax)).(int)),
+			}, true
+		}
+	}
+	return networkACLRulePortRange{}, false
+}
+
+func networkACLRuleID(rule vpcv1.NetworkACLRuleIntf) string {
+	switch reflect.TypeOf(rule).String() {
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolIcmp":
+		return *rule.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolIcmp).ID
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolTcpudp":
+		return *rule.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolTcpudp).ID
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolAll":
+		return *rule.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolAll).ID
+	case "*vpcv1.NetworkACLRule":
+		return *rule.(*vpcv1.NetworkACLRule).ID
+	}
+	return ""
+}
+
+func resourceIBMISNetworkACLRuleRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("Incorrect ID %s: ID should be a combination of networkACLID/ruleID", d.Id())
+	}
+	nwaclID := parts[0]
+	ruleID := parts[1]
+
+	getNetworkACLRuleOptions := &vpcv1.GetNetworkACLRuleOptions{
+		NetworkACLID: &nwaclID,
+		ID:           &ruleID,
+	}
+	rulex, response, err := sess.GetNetworkACLRule(getNetworkACLRuleOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error Getting network ACL rule (%s): %s\n%s", ruleID, err, response)
+	}
+
+	d.Set(isNetworkACLRuleNetworkACL, nwaclID)
+	d.Set(isNetworkACLRuleRuleID, ruleID)
+	var before *vpcv1.NetworkACLRuleReference
+	icmp := make([]map[string]interface{}, 0)
+	tcp := make([]map[string]interface{}, 0)
+	udp := make([]map[string]interface{}, 0)
+	switch reflect.TypeOf(rulex).String() {
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolIcmp":
+		rule := rulex.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolIcmp)
+		d.Set(isNetworkACLRuleName, *rule.Name)
+		d.Set(isNetworkACLRuleAction, *rule.Action)
+		d.Set(isNetworkACLRuleSource, *rule.Source)
+		d.Set(isNetworkACLRuleDestination, *rule.Destination)
+		d.Set(isNetworkACLRuleDirection, *rule.Direction)
+		d.Set(isNetworkACLRuleIPVersion, *rule.IPVersion)
+		d.Set(isNetworkACLRuleProtocol, *rule.Protocol)
+		before = rule.Before
+		icmpval := map[string]interface{}{
+			isNetworkACLRuleICMPType: isNetworkACLRuleICMPAny,
+			isNetworkACLRuleICMPCode: isNetworkACLRuleICMPAny,
+		}
+		if rule.Type != nil {
+			icmpval[isNetworkACLRuleICMPType] = int(*rule.Type)
+		}
+		if rule.Code != nil {
+			icmpval[isNetworkACLRuleICMPCode] = int(*rule.Code)
+		}
+		icmp = append(icmp, icmpval)
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolTcpudp":
+		rule := rulex.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolTcpudp)
+		d.Set(isNetworkACLRuleName, *rule.Name)
+		d.Set(isNetworkACLRuleAction, *rule.Action)
+		d.Set(isNetworkACLRuleSource, *rule.Source)
+		d.Set(isNetworkACLRuleDestination, *rule.Destination)
+		d.Set(isNetworkACLRuleDirection, *rule.Direction)
+		d.Set(isNetworkACLRuleIPVersion, *rule.IPVersion)
+		d.Set(isNetworkACLRuleProtocol, *rule.Protocol)
+		before = rule.Before
+		ports := map[string]interface{}{
+			isNetworkACLRulePortMax:       checkNetworkACLNil(rule.DestinationPortMax),
+			isNetworkACLRulePortMin:       checkNetworkACLNil(rule.DestinationPortMin),
+			isNetworkACLRuleSourcePortMax: checkNetworkACLNil(rule.SourcePortMax),
+			isNetworkACLRuleSourcePortMin: checkNetworkACLNil(rule.SourcePortMin),
+		}
+		if *rule.Protocol == "tcp" {
+			tcp = append(tcp, ports)
+		} else {
+			udp = append(udp, ports)
+		}
+	case "*vpcv1.NetworkACLRuleNetworkACLRuleProtocolAll":
+		rule := rulex.(*vpcv1.NetworkACLRuleNetworkACLRuleProtocolAll)
+		d.Set(isNetworkACLRuleName, *rule.Name)
+		d.Set(isNetworkACLRuleAction, *rule.Action)
+		d.Set(isNetworkACLRuleSource, *rule.Source)
+		d.Set(isNetworkACLRuleDestination, *rule.Destination)
+		d.Set(isNetworkACLRuleDirection, *rule.Direction)
+		d.Set(isNetworkACLRuleIPVersion, *rule.IPVersion)
+		d.Set(isNetworkACLRuleProtocol, *rule.Protocol)
+		before = rule.Before
+	}
+	d.Set(isNetworkACLRuleICMP, icmp)
+	d.Set(isNetworkACLRuleTCP, tcp)
+	d.Set(isNetworkACLRuleUDP, udp)
+	if before != nil && before.ID != nil {
+		d.Set(isNetworkACLRuleBefore, *before.ID)
+	} else {
+		d.Set(isNetworkACLRuleBefore, "")
+	}
+	return nil
+}
+
+func resourceIBMISNetworkACLRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	nwaclID := parts[0]
+	ruleID := parts[1]
+
+	hasChanged := false
+	rulePatchModel := &vpcv1.NetworkACLRulePatch{}
+	if d.HasChange(isNetworkACLRuleName) {
+		name := d.Get(isNetworkACLRuleName).(string)
+		rulePatchModel.Name = &name
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleAction) {
+		action := d.Get(isNetworkACLRuleAction).(string)
+		rulePatchModel.Action = &action
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleSource) {
+		source := d.Get(isNetworkACLRuleSource).(string)
+		rulePatchModel.Source = &source
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleDestination) {
+		destination := d.Get(isNetworkACLRuleDestination).(string)
+		rulePatchModel.Destination = &destination
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleDirection) {
+		direction := d.Get(isNetworkACLRuleDirection).(string)
+		rulePatchModel.Direction = &direction
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleBefore) {
+		if before := d.Get(isNetworkACLRuleBefore).(string); before != "" {
+			rulePatchModel.Before = &vpcv1.NetworkACLRuleBeforePatch{
+				ID: &before,
+			}
+			hasChanged = true
+		}
+	}
+	icmpChanged := d.HasChange(isNetworkACLRuleICMP)
+	if icmpChanged {
+		rulePatchModel.Type, rulePatchModel.Code = networkACLRuleICMP(d)
+		hasChanged = true
+	}
+	if d.HasChange(isNetworkACLRuleTCP) || d.HasChange(isNetworkACLRuleUDP) {
+		if ports, ok := networkACLRulePorts(d); ok {
+			rulePatchModel.DestinationPortMin = &ports.min
+			rulePatchModel.DestinationPortMax = &ports.max
+			rulePatchModel.SourcePortMin = &ports.sourceMin
+			rulePatchModel.SourcePortMax = &ports.sourceMax
+			hasChanged = true
+		}
+	}
+
+	if hasChanged {
+		rulePatch, err := rulePatchModel.AsPatch()
+		if err != nil {
+			return fmt.Errorf("Error calling asPatch for NetworkACLRulePatch: %s", err)
+		}
+		// The patch omits a type or code that is nil, send null to remove it
+		// from the rule
+		if icmpChanged {
+			if rulePatchModel.Type == nil {
+				rulePatch[isNetworkACLRuleICMPType] = nil
+			}
+			if rulePatchModel.Code == nil {
+				rulePatch[isNetworkACLRuleICMPCode] = nil
+			}
+		}
+
+		isNetworkACLKey := "network_acl_key_" + nwaclID
+		ibmMutexKV.Lock(isNetworkACLKey)
+		defer ibmMutexKV.Unlock(isNetworkACLKey)
+
+		updateNetworkACLRuleOptions := &vpcv1.UpdateNetworkACLRuleOptions{
+			NetworkACLID:        &nwaclID,
+			ID:                  &ruleID,
+			NetworkACLRulePatch: rulePatch,
+		}
+		_, response, err := sess.UpdateNetworkACLRule(updateNetworkACLRuleOptions)
+		if err != nil {
+			return fmt.Errorf("Error Updating network ACL rule (%s): %s\n%s", ruleID, err, response)
+		}
+	}
+	return resourceIBMISNetworkACLRuleRead(d, meta)
+}
+
+func resourceIBMISNetworkACLRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	nwaclID := parts[0]
+	ruleID := parts[1]
+
+	isNetworkACLKey := "network_acl_key_" + nwaclID
+	ibmMutexKV.Lock(isNetworkACLKey)
+	defer ibmMutexKV.Unlock(isNetworkACLKey)
+
+	deleteNetworkACLRuleOptions := &vpcv1.DeleteNetworkACLRuleOptions{
+		NetworkACLID: &nwaclID,
+		ID:           &ruleID,
+	}
+	response, err := sess.DeleteNetworkACLRule(deleteNetworkACLRuleOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error Deleting network ACL rule (%s): %s\n%s", ruleID, err, response)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISNetworkACLRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+	if len(parts) != 2 {
+		return false, fmt.Errorf("Incorrect ID %s: ID should be a combination of networkACLID/ruleID", d.Id())
+	}
+
+	getNetworkACLRuleOptions := &vpcv1.GetNetworkACLRuleOptions{
+		NetworkACLID: &parts[0],
+		ID:           &parts[1],
+	}
+	_, response, err := sess.GetNetworkACLRule(getNetworkACLRuleOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error Getting network ACL rule (%s): %s\n%s", parts[1], err, response)
+	}
+	return true, nil
+}
@@ -0,0 +1,218 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestNetworkACLRuleICMP(t *testing.T) {
+	cases := []struct {
+		icmp               map[string]interface{}
+		icmpType, icmpCode string
+	}{
+		{map[string]interface{}{}, "<nil>", "<nil>"},
+		{map[string]interface{}{"type": 8}, "8", "<nil>"},
+		{map[string]interface{}{"type": 0, "code": 0}, "0", "0"},
+		{map[string]interface{}{"type": -1, "code": -1}, "<nil>", "<nil>"},
+	}
+	format := func(v *int64) string {
+		if v == nil {
+			return "<nil>"
+		}
+		return fmt.Sprint(*v)
+	}
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			"network_acl": "acl",
+			"action":      "allow",
+			"direction":   "inbound",
+			"source":      "0.0.0.0/0",
+			"destination": "0.0.0.0/0",
+			"icmp":        []interface{}{c.icmp},
+		}
+		d := schema.TestResourceDataRaw(t, resourceIBMISNetworkACLRule().Schema, raw)
+		icmpType, icmpCode := networkACLRuleICMP(d)
+		if format(icmpType) != c.icmpType || format(icmpCode) != c.icmpCode {
+			t.Errorf("icmp %v: got type %s and code %s, want %s and %s", c.icmp, format(icmpType), format(icmpCode), c.icmpType, c.icmpCode)
+		}
+	}
+}
+
+func TestAccIBMISNetworkACLRule_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	aclname := fmt.Sprintf("tf-acl-%d", acctest.RandIntRange(10, 100))
+	var ruleID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISNetworkACLRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISNetworkACLRuleConfig(vpcname, aclname, "allow", "tcp", 22),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISNetworkACLRuleExists("ibm_is_network_acl_rule.testacc_deny"),
+					testAccCheckIBMISNetworkACLRuleExists("ibm_is_network_acl_rule.testacc_ssh"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "action", "allow"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "tcp.0.port_min", "22"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_network_acl_rule.testacc_ssh", "before",
+						"ibm_is_network_acl_rule.testacc_deny", "rule_id"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_deny", "protocol", "all"),
+					testAccCheckIBMISNetworkACLRuleID("ibm_is_network_acl_rule.testacc_ssh", &ruleID, true),
+				),
+			},
+			{
+				// The ports are updated in place
+				Config: testAccCheckIBMISNetworkACLRuleConfig(vpcname, aclname, "deny", "tcp", 2222),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISNetworkACLRuleExists("ibm_is_network_acl_rule.testacc_ssh"),
+					testAccCheckIBMISNetworkACLRuleID("ibm_is_network_acl_rule.testacc_ssh", &ruleID, true),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "action", "deny"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "tcp.0.port_min", "2222"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_network_acl_rule.testacc_ssh", "before",
+						"ibm_is_network_acl_rule.testacc_deny", "rule_id"),
+				),
+			},
+			{
+				// Switching the protocol replaces the rule
+				Config: testAccCheckIBMISNetworkACLRuleConfig(vpcname, aclname, "deny", "udp", 2222),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISNetworkACLRuleExists("ibm_is_network_acl_rule.testacc_ssh"),
+					testAccCheckIBMISNetworkACLRuleID("ibm_is_network_acl_rule.testacc_ssh", &ruleID, false),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "protocol", "udp"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_network_acl_rule.testacc_ssh", "udp.0.port_min", "2222"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_network_acl_rule.testacc_ssh",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISNetworkACLRuleDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_network_acl_rule" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		getNetworkACLRuleOptions := &vpcv1.GetNetworkACLRuleOptions{
+			NetworkACLID: &parts[0],
+			ID:           &parts[1],
+		}
+		_, _, err1 := sess.GetNetworkACLRule(getNetworkACLRuleOptions)
+		if err1 == nil {
+			return fmt.Errorf("network ACL rule still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISNetworkACLRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		getNetworkACLRuleOptions := &vpcv1.GetNetworkACLRuleOptions{
+			NetworkACLID: &parts[0],
+			ID:           &parts[1],
+		}
+		_, _, err = sess.GetNetworkACLRule(getNetworkACLRuleOptions)
+		return err
+	}
+}
+
+// testAccCheckIBMISNetworkACLRuleID checks that the rule_id of n is the same
+// as id when same is true, or a different one otherwise, and then records it
+// in id.
+func testAccCheckIBMISNetworkACLRuleID(n string, id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		ruleID := rs.Primary.Attributes[isNetworkACLRuleRuleID]
+		if *id != "" && (ruleID == *id) != same {
+			if same {
+				return fmt.Errorf("network ACL rule was replaced: %s, expected %s", ruleID, *id)
+			}
+			return fmt.Errorf("network ACL rule %s was not replaced", ruleID)
+		}
+		*id = ruleID
+		return nil
+	}
+}
+
+func testAccCheckIBMISNetworkACLRuleConfig(vpcname, aclname, action, protocol string, port int) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_network_acl" "testacc_acl" {
+		name = "%s"
+		vpc  = ibm_is_vpc.testacc_vpc.id
+	  }
+
+	  resource "ibm_is_network_acl_rule" "testacc_deny" {
+		network_acl = ibm_is_network_acl.testacc_acl.id
+		name        = "deny-all"
+		action      = "deny"
+		source      = "0.0.0.0/0"
+		destination = "0.0.0.0/0"
+		direction   = "inbound"
+	  }
+
+	  resource "ibm_is_network_acl_rule" "testacc_ssh" {
+		network_acl = ibm_is_network_acl.testacc_acl.id
+		before      = ibm_is_network_acl_rule.testacc_deny.rule_id
+		name        = "ssh"
+		action      = "%s"
+		source      = "0.0.0.0/0"
+		destination = "0.0.0.0/0"
+		direction   = "inbound"
+		%s {
+		  port_min = %d
+		  port_max = %d
+		}
+	  }`, vpcname, aclname, action, protocol, port, port)
+}
@@ -39,7 +39,7 @@ const (
 )
 
 func resourceIBMISNetworkACL() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_network_acl", &schema.Resource{
 		Create:   resourceIBMISNetworkACLCreate,
 		Read:     resourceIBMISNetworkACLRead,
 		Update:   resourceIBMISNetworkACLUpdate,
@@ -238,7 +238,7 @@ func resourceIBMISNetworkACL() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISNetworkACLValidator() *ResourceValidator {
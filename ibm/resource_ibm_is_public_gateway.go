@@ -31,7 +31,7 @@ const (
 )
 
 func resourceIBMISPublicGateway() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_public_gateway", &schema.Resource{
 		Create:   resourceIBMISPublicGatewayCreate,
 		Read:     resourceIBMISPublicGatewayRead,
 		Update:   resourceIBMISPublicGatewayUpdate,
@@ -46,7 +46,7 @@ func resourceIBMISPublicGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -103,6 +103,8 @@ func resourceIBMISPublicGateway() *schema.Resource {
 				Description: "Service tags for the public gateway instance",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -133,7 +135,7 @@ func resourceIBMISPublicGateway() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISPublicGatewayValidator() *ResourceValidator {
@@ -222,8 +224,8 @@ func classicPgwCreate(d *schema.ResourceData, meta interface{}, name, vpc, zone
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(isPublicGatewayTags)
+	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *publicgw.CRN)
 		if err != nil {
 			log.Printf(
@@ -283,8 +285,8 @@ func pgwCreate(d *schema.ResourceData, meta interface{}, name, vpc, zone string)
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(isPublicGatewayTags)
+	if _, ok := d.GetOk(isPublicGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *publicgw.CRN)
 		if err != nil {
 			log.Printf(
@@ -413,7 +415,7 @@ func classicPgwGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc public gateway (%s) tags: %s", id, err)
 	}
-	d.Set(isPublicGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -458,7 +460,7 @@ func pgwGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc public gateway (%s) tags: %s", id, err)
 	}
-	d.Set(isPublicGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -507,7 +509,7 @@ func classicPgwUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isPublicGatewayTags) {
+	if d.HasChange(isPublicGatewayTags) || d.HasChange(tagsAll) {
 		getPublicGatewayOptions := &vpcclassicv1.GetPublicGatewayOptions{
 			ID: &id,
 		}
@@ -515,7 +517,7 @@ func classicPgwUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting Public Gateway : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isPublicGatewayTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *publicgw.CRN)
 		if err != nil {
 			log.Printf(
@@ -549,7 +551,7 @@ func pgwUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isPublicGatewayTags) {
+	if d.HasChange(isPublicGatewayTags) || d.HasChange(tagsAll) {
 		getPublicGatewayOptions := &vpcv1.GetPublicGatewayOptions{
 			ID: &id,
 		}
@@ -557,7 +559,7 @@ func pgwUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		if err != nil {
 			return fmt.Errorf("Error getting Public Gateway : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isPublicGatewayTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *publicgw.CRN)
 		if err != nil {
 			log.Printf(
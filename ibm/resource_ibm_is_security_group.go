@@ -18,7 +18,7 @@ const (
 
 func resourceIBMISSecurityGroup() *schema.Resource {
 
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_security_group", &schema.Resource{
 		Create:   resourceIBMISSecurityGroupCreate,
 		Read:     resourceIBMISSecurityGroupRead,
 		Update:   resourceIBMISSecurityGroupUpdate,
@@ -83,7 +83,7 @@ func resourceIBMISSecurityGroup() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISSecurityGroupValidator() *ResourceValidator {
@@ -30,7 +30,7 @@ const (
 )
 
 func resourceIBMISSecurityGroupNetworkInterfaceAttachment() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_security_group_network_interface_attachment", &schema.Resource{
 		Create:   resourceIBMISSecurityGroupNetworkInterfaceAttachmentCreate,
 		Read:     resourceIBMISSecurityGroupNetworkInterfaceAttachmentRead,
 		Delete:   resourceIBMISSecurityGroupNetworkInterfaceAttachmentDelete,
@@ -145,7 +145,7 @@ func resourceIBMISSecurityGroupNetworkInterfaceAttachment() *schema.Resource {
 				Description: "The crn of the Security Group",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISSecurityGroupNetworkInterfaceAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
@@ -29,7 +29,7 @@ const (
 
 func resourceIBMISSecurityGroupRule() *schema.Resource {
 
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_security_group_rule", &schema.Resource{
 		Create:   resourceIBMISSecurityGroupRuleCreate,
 		Read:     resourceIBMISSecurityGroupRuleRead,
 		Update:   resourceIBMISSecurityGroupRuleUpdate,
@@ -167,7 +167,7 @@ func resourceIBMISSecurityGroupRule() *schema.Resource {
 				Description: "The Security Group Rule Protocol",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISSecurityGroupRuleValidator() *ResourceValidator {
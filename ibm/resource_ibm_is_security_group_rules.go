@@ -0,0 +1,613 @@
+package ibm
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const (
+	isSecurityGroupRuleRemoteDefault = "0.0.0.0/0"
+
+	// isSecurityGroupRuleICMPAny is the icmp type or code of a rule that
+	// matches any type or code. Set elements get a zero value for unset
+	// fields, which would make a rule match only type or code 0.
+	isSecurityGroupRuleICMPAny = -1
+)
+
+func resourceIBMISSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISSecurityGroupRulesCreate,
+		Read:     resourceIBMISSecurityGroupRulesRead,
+		Update:   resourceIBMISSecurityGroupRulesUpdate,
+		Delete:   resourceIBMISSecurityGroupRulesDelete,
+		Exists:   resourceIBMISSecurityGroupRulesExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Security group id",
+			},
+
+			isSecurityGroupRules: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Set:         resourceIBMISSecurityGroupRulesHash,
+				Description: "The complete set of rules of the security group",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isSecurityGroupRuleID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rule id",
+						},
+
+						isSecurityGroupRuleDirection: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Direction of traffic to enforce, either inbound or outbound",
+							ValidateFunc: InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleDirection),
+						},
+
+						isSecurityGroupRuleIPVersion: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      isSecurityGroupRuleIPVersionDefault,
+							Description:  "IP version: ipv4 or ipv6",
+							ValidateFunc: InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleIPVersion),
+						},
+
+						isSecurityGroupRuleRemote: {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressSecurityGroupRuleRemoteDefault,
+							Description:      "Security group id: an IP address, a CIDR block, or a single security group identifier",
+						},
+
+						isSecurityGroupRuleProtocol: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Security Group Rule Protocol",
+						},
+
+						isSecurityGroupRuleProtocolICMP: {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "protocol=icmp",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									isSecurityGroupRuleType: {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  isSecurityGroupRuleICMPAny,
+										ValidateFunc: validation.Any(
+											validation.IntInSlice([]int{isSecurityGroupRuleICMPAny}),
+											InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleType)),
+									},
+									isSecurityGroupRuleCode: {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  isSecurityGroupRuleICMPAny,
+										ValidateFunc: validation.Any(
+											validation.IntInSlice([]int{isSecurityGroupRuleICMPAny}),
+											InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleCode)),
+									},
+								},
+							},
+						},
+
+						isSecurityGroupRuleProtocolTCP: {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "protocol=tcp",
+							Elem:        securityGroupRulesPortsResource(),
+						},
+
+						isSecurityGroupRuleProtocolUDP: {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "protocol=udp",
+							Elem:        securityGroupRulesPortsResource(),
+						},
+					},
+				},
+			},
+
+			RelatedCRN: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The crn of the Security Group",
+			},
+		},
+	}
+}
+
+func securityGroupRulesPortsResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupRulePortMin: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMin),
+			},
+			isSecurityGroupRulePortMax: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      65535,
+				ValidateFunc: InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMax),
+			},
+		},
+	}
+}
+
+// The API reports a rule created without remote as a rule for 0.0.0.0/0
+func suppressSecurityGroupRuleRemoteDefault(k, old, new string, d *schema.ResourceData) bool {
+	return new == "" && old == isSecurityGroupRuleRemoteDefault
+}
+
+func resourceIBMISSecurityGroupRulesHash(v interface{}) int {
+	var buf bytes.Buffer
+	parsed, _ := expandSecurityGroupRulesRule(v.(map[string]interface{}))
+	buf.WriteString(securityGroupRulesRuleKey(parsed))
+	return String(buf.String())
+}
+
+// expandSecurityGroupRulesRule parses one element of the rules set the same way
+// parseIBMISSecurityGroupRuleDictionary parses a single rule resource
+func expandSecurityGroupRulesRule(rule map[string]interface{}) (*parsedIBMISSecurityGroupRuleDictionary, error) {
+	parsed := &parsedIBMISSecurityGroupRuleDictionary{
+		icmpType: -1,
+		icmpCode: -1,
+		portMin:  -1,
+		portMax:  -1,
+		protocol: "all",
+	}
+	if v, ok := rule[isSecurityGroupRuleID].(string); ok {
+		parsed.ruleID = v
+	}
+	parsed.direction, _ = rule[isSecurityGroupRuleDirection].(string)
+	parsed.ipversion, _ = rule[isSecurityGroupRuleIPVersion].(string)
+	if parsed.ipversion == "" {
+		parsed.ipversion = isSecurityGroupRuleIPVersionDefault
+	}
+	parsed.remote, _ = rule[isSecurityGroupRuleRemote].(string)
+	if parsed.remote == "" {
+		parsed.remote = isSecurityGroupRuleRemoteDefault
+	}
+	var err error
+	parsed.remoteAddress, parsed.remoteCIDR, parsed.remoteSecGrpID, err = inferRemoteSecurityGroup(parsed.remote)
+	if err != nil {
+		return parsed, err
+	}
+
+	icmp, _ := rule[isSecurityGroupRuleProtocolICMP].([]interface{})
+	tcp, _ := rule[isSecurityGroupRuleProtocolTCP].([]interface{})
+	udp, _ := rule[isSecurityGroupRuleProtocolUDP].([]interface{})
+	if (len(icmp) > 0 && len(tcp) > 0) || (len(icmp) > 0 && len(udp) > 0) || (len(tcp) > 0 && len(udp) > 0) {
+		return parsed, fmt.Errorf("Only one of icmp|tcp|udp can be defined per rule")
+	}
+
+	if len(icmp) > 0 {
+		parsed.protocol = isSecurityGroupRuleProtocolICMP
+		if icmpval, ok := icmp[0].(map[string]interface{}); ok {
+			if value, ok := icmpval[isSecurityGroupRuleType].(int); ok && value != isSecurityGroupRuleICMPAny {
+				parsed.icmpType = int64(value)
+			}
+			if value, ok := icmpval[isSecurityGroupRuleCode].(int); ok && value != isSecurityGroupRuleICMPAny {
+				if parsed.icmpType < 0 {
+					return parsed, fmt.Errorf("icmp code requires icmp type")
+				}
+				parsed.icmpCode = int64(value)
+			}
+		}
+	}
+	for prot, ports := range map[string][]interface{}{isSecurityGroupRuleProtocolTCP: tcp, isSecurityGroupRuleProtocolUDP: udp} {
+		if len(ports) == 0 {
+			continue
+		}
+		parsed.protocol = prot
+		parsed.portMin = 1
+		parsed.portMax = 65535
+		if portval, ok := ports[0].(map[string]interface{}); ok {
+			if value, ok := portval[isSecurityGroupRulePortMin]; ok {
+				parsed.portMin = int64(value.(int))
+			}
+			if value, ok := portval[isSecurityGroupRulePortMax]; ok {
+				parsed.portMax = int64(value.(int))
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// securityGroupRulesRuleKey identifies what a rule matches, regardless of its id.
+// An unset icmp type or code is -1, which keeps it apart from type or code 0.
+func securityGroupRulesRuleKey(parsed *parsedIBMISSecurityGroupRuleDictionary) string {
+	return fmt.Sprintf("%s-%s-%s-%s-%d-%d-%d-%d-",
+		strings.ToLower(parsed.direction), strings.ToLower(parsed.ipversion), parsed.remote,
+		parsed.protocol, parsed.icmpType, parsed.icmpCode, parsed.portMin, parsed.portMax)
+}
+
+// flattenSecurityGroupRulesRule converts a rule returned by the API into the parsed form
+func flattenSecurityGroupRulesRule(sgrule vpcv1.SecurityGroupRuleIntf) *parsedIBMISSecurityGroupRuleDictionary {
+	parsed := &parsedIBMISSecurityGroupRuleDictionary{
+		icmpType: -1,
+		icmpCode: -1,
+		portMin:  -1,
+		portMax:  -1,
+	}
+	var remoteIntf vpcv1.SecurityGroupRuleRemoteIntf
+	var ipVersion *string
+	switch reflect.TypeOf(sgrule).String() {
+	case "*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp":
+		rule := sgrule.(*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp)
+		parsed.ruleID = *rule.ID
+		parsed.direction = *rule.Direction
+		ipVersion = rule.IPVersion
+		parsed.protocol = *rule.Protocol
+		if rule.Type != nil {
+			parsed.icmpType = *rule.Type
+		}
+		if rule.Code != nil {
+			parsed.icmpCode = *rule.Code
+		}
+		remoteIntf = rule.Remote
+	case "*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll":
+		rule := sgrule.(*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll)
+		parsed.ruleID = *rule.ID
+		parsed.direction = *rule.Direction
+		ipVersion = rule.IPVersion
+		parsed.protocol = *rule.Protocol
+		remoteIntf = rule.Remote
+	case "*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp":
+		rule := sgrule.(*vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp)
+		parsed.ruleID = *rule.ID
+		parsed.direction = *rule.Direction
+		ipVersion = rule.IPVersion
+		parsed.protocol = *rule.Protocol
+		if rule.PortMin != nil {
+			parsed.portMin = *rule.PortMin
+		}
+		if rule.PortMax != nil {
+			parsed.portMax = *rule.PortMax
+		}
+		remoteIntf = rule.Remote
+	default:
+		return nil
+	}
+	parsed.ipversion = isSecurityGroupRuleIPVersionDefault
+	if ipVersion != nil {
+		parsed.ipversion = *ipVersion
+	}
+	if remote, ok := remoteIntf.(*vpcv1.SecurityGroupRuleRemote); ok && remote != nil {
+		if remote.ID != nil {
+			parsed.remote = *remote.ID
+			parsed.remoteSecGrpID = *remote.ID
+		} else if remote.Address != nil {
+			parsed.remote = *remote.Address
+			parsed.remoteAddress = *remote.Address
+		} else if remote.CIDRBlock != nil {
+			parsed.remote = *remote.CIDRBlock
+			parsed.remoteCIDR = *remote.CIDRBlock
+		}
+	}
+	return parsed
+}
+
+// planSecurityGroupRules works out the calls needed to turn the existing rules into the
+// desired ones. Rules matching exactly are kept, a changed rule is patched in place when
+// the protocol allows it, and the remaining rules are created or deleted.
+func planSecurityGroupRules(existing, desired []*parsedIBMISSecurityGroupRuleDictionary) (create, update, remove []*parsedIBMISSecurityGroupRuleDictionary) {
+	existingByKey := make(map[string][]*parsedIBMISSecurityGroupRuleDictionary)
+	for _, rule := range existing {
+		key := securityGroupRulesRuleKey(rule)
+		existingByKey[key] = append(existingByKey[key], rule)
+	}
+	kept := make(map[string]bool)
+	unmatched := make([]*parsedIBMISSecurityGroupRuleDictionary, 0)
+	for _, rule := range desired {
+		key := securityGroupRulesRuleKey(rule)
+		if matches := existingByKey[key]; len(matches) > 0 {
+			kept[matches[0].ruleID] = true
+			existingByKey[key] = matches[1:]
+			continue
+		}
+		unmatched = append(unmatched, rule)
+	}
+	for _, rule := range unmatched {
+		var target *parsedIBMISSecurityGroupRuleDictionary
+		for _, candidate := range existing {
+			if kept[candidate.ruleID] || candidate.protocol != rule.protocol {
+				continue
+			}
+			// A patch cannot unset the icmp type or code of a rule
+			if rule.protocol == isSecurityGroupRuleProtocolICMP &&
+				((rule.icmpType < 0 && candidate.icmpType >= 0) || (rule.icmpCode < 0 && candidate.icmpCode >= 0)) {
+				continue
+			}
+			target = candidate
+			break
+		}
+		if target == nil {
+			create = append(create, rule)
+			continue
+		}
+		kept[target.ruleID] = true
+		patched := *rule
+		patched.ruleID = target.ruleID
+		update = append(update, &patched)
+	}
+	for _, rule := range existing {
+		if !kept[rule.ruleID] {
+			remove = append(remove, rule)
+		}
+	}
+	return create, update, remove
+}
+
+func resourceIBMISSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	secgrpID := d.Get(isSecurityGroupID).(string)
+	err := securityGroupRulesReconcile(d, meta, secgrpID)
+	if err != nil {
+		return err
+	}
+	d.SetId(secgrpID)
+	return resourceIBMISSecurityGroupRulesRead(d, meta)
+}
+
+func securityGroupRulesList(sess *vpcv1.VpcV1, secgrpID string) ([]*parsedIBMISSecurityGroupRuleDictionary, error) {
+	listSecurityGroupRulesOptions := &vpcv1.ListSecurityGroupRulesOptions{
+		SecurityGroupID: &secgrpID,
+	}
+	rules, response, err := sess.ListSecurityGroupRules(listSecurityGroupRulesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Error Listing Security Group Rules : %s\n%s", err, response)
+	}
+	existing := make([]*parsedIBMISSecurityGroupRuleDictionary, 0, len(rules.Rules))
+	for _, rule := range rules.Rules {
+		if parsed := flattenSecurityGroupRulesRule(rule); parsed != nil {
+			existing = append(existing, parsed)
+		}
+	}
+	return existing, nil
+}
+
+// securityGroupRulesReconcile brings the rules of the security group to the
+// configured ones. Rules only allow traffic, so new rules are created and
+// changed rules updated before the stale rules are deleted: traffic allowed by
+// a replaced rule is never dropped, and a failed create leaves the old rules
+// in place.
+func securityGroupRulesReconcile(d *schema.ResourceData, meta interface{}, secgrpID string) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	desired := make([]*parsedIBMISSecurityGroupRuleDictionary, 0)
+	for _, rule := range d.Get(isSecurityGroupRules).(*schema.Set).List() {
+		parsed, err := expandSecurityGroupRulesRule(rule.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		desired = append(desired, parsed)
+	}
+
+	isSecurityGroupRuleKey := "security_group_rule_key_" + secgrpID
+	ibmMutexKV.Lock(isSecurityGroupRuleKey)
+	defer ibmMutexKV.Unlock(isSecurityGroupRuleKey)
+
+	existing, err := securityGroupRulesList(sess, secgrpID)
+	if err != nil {
+		return err
+	}
+	create, update, remove := planSecurityGroupRules(existing, desired)
+	log.Printf("[DEBUG] Security group %s rules: %d to create, %d to update, %d to delete", secgrpID, len(create), len(update), len(remove))
+
+	for _, rule := range create {
+		sgTemplate := &vpcv1.SecurityGroupRulePrototype{
+			Direction: &rule.direction,
+			IPVersion: &rule.ipversion,
+			Protocol:  &rule.protocol,
+			Remote: &vpcv1.SecurityGroupRuleRemotePrototype{
+				Address:   securityGroupRulesOptionalString(rule.remoteAddress),
+				CIDRBlock: securityGroupRulesOptionalString(rule.remoteCIDR),
+				ID:        securityGroupRulesOptionalString(rule.remoteSecGrpID),
+			},
+		}
+		securityGroupRulesSetProtocolFields(rule, &sgTemplate.Type, &sgTemplate.Code, &sgTemplate.PortMin, &sgTemplate.PortMax)
+		options := &vpcv1.CreateSecurityGroupRuleOptions{
+			SecurityGroupID:            &secgrpID,
+			SecurityGroupRulePrototype: sgTemplate,
+		}
+		_, response, err := sess.CreateSecurityGroupRule(options)
+		if err != nil {
+			return fmt.Errorf("Error while creating Security Group Rule %s\n%s", err, response)
+		}
+	}
+	for _, rule := range update {
+		securityGroupRulePatchModel := &vpcv1.SecurityGroupRulePatch{
+			Direction: &rule.direction,
+			IPVersion: &rule.ipversion,
+			Remote: &vpcv1.SecurityGroupRuleRemotePatch{
+				Address:   securityGroupRulesOptionalString(rule.remoteAddress),
+				CIDRBlock: securityGroupRulesOptionalString(rule.remoteCIDR),
+				ID:        securityGroupRulesOptionalString(rule.remoteSecGrpID),
+			},
+		}
+		securityGroupRulesSetProtocolFields(rule, &securityGroupRulePatchModel.Type, &securityGroupRulePatchModel.Code,
+			&securityGroupRulePatchModel.PortMin, &securityGroupRulePatchModel.PortMax)
+		securityGroupRulePatch, err := securityGroupRulePatchModel.AsPatch()
+		if err != nil {
+			return fmt.Errorf("Error calling asPatch for SecurityGroupRulePatch: %s", err)
+		}
+		updateSecurityGroupRuleOptions := &vpcv1.UpdateSecurityGroupRuleOptions{
+			SecurityGroupID:        &secgrpID,
+			ID:                     &rule.ruleID,
+			SecurityGroupRulePatch: securityGroupRulePatch,
+		}
+		_, response, err := sess.UpdateSecurityGroupRule(updateSecurityGroupRuleOptions)
+		if err != nil {
+			return fmt.Errorf("Error Updating Security Group Rule %s : %s\n%s", rule.ruleID, err, response)
+		}
+	}
+	for _, rule := range remove {
+		deleteSecurityGroupRuleOptions := &vpcv1.DeleteSecurityGroupRuleOptions{
+			SecurityGroupID: &secgrpID,
+			ID:              &rule.ruleID,
+		}
+		response, err := sess.DeleteSecurityGroupRule(deleteSecurityGroupRuleOptions)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			return fmt.Errorf("Error Deleting Security Group Rule %s : %s\n%s", rule.ruleID, err, response)
+		}
+	}
+	return nil
+}
+
+func securityGroupRulesOptionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func securityGroupRulesSetProtocolFields(rule *parsedIBMISSecurityGroupRuleDictionary, icmpType, icmpCode, portMin, portMax **int64) {
+	switch rule.protocol {
+	case isSecurityGroupRuleProtocolICMP:
+		if rule.icmpType >= 0 {
+			*icmpType = &rule.icmpType
+		}
+		if rule.icmpCode >= 0 {
+			*icmpCode = &rule.icmpCode
+		}
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		*portMin = &rule.portMin
+		*portMax = &rule.portMax
+	}
+}
+
+func resourceIBMISSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	secgrpID := d.Id()
+	getSecurityGroupOptions := &vpcv1.GetSecurityGroupOptions{
+		ID: &secgrpID,
+	}
+	sg, response, err := sess.GetSecurityGroup(getSecurityGroupOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error Getting Security Group : %s\n%s", err, response)
+	}
+	existing, err := securityGroupRulesList(sess, secgrpID)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]interface{}, 0, len(existing))
+	for _, parsed := range existing {
+		rule := map[string]interface{}{
+			isSecurityGroupRuleID:        parsed.ruleID,
+			isSecurityGroupRuleDirection: parsed.direction,
+			isSecurityGroupRuleIPVersion: parsed.ipversion,
+			isSecurityGroupRuleRemote:    parsed.remote,
+			isSecurityGroupRuleProtocol:  parsed.protocol,
+		}
+		switch parsed.protocol {
+		case isSecurityGroupRuleProtocolICMP:
+			icmp := map[string]interface{}{
+				isSecurityGroupRuleType: isSecurityGroupRuleICMPAny,
+				isSecurityGroupRuleCode: isSecurityGroupRuleICMPAny,
+			}
+			if parsed.icmpType >= 0 {
+				icmp[isSecurityGroupRuleType] = int(parsed.icmpType)
+			}
+			if parsed.icmpCode >= 0 {
+				icmp[isSecurityGroupRuleCode] = int(parsed.icmpCode)
+			}
+			rule[isSecurityGroupRuleProtocolICMP] = []interface{}{icmp}
+		case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+			rule[parsed.protocol] = []interface{}{map[string]interface{}{
+				isSecurityGroupRulePortMin: int(parsed.portMin),
+				isSecurityGroupRulePortMax: int(parsed.portMax),
+			}}
+		}
+		rules = append(rules, rule)
+	}
+	d.Set(isSecurityGroupID, secgrpID)
+	d.Set(isSecurityGroupRules, schema.NewSet(resourceIBMISSecurityGroupRulesHash, rules))
+	d.Set(RelatedCRN, *sg.CRN)
+	return nil
+}
+
+func resourceIBMISSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange(isSecurityGroupRules) {
+		err := securityGroupRulesReconcile(d, meta, d.Id())
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISSecurityGroupRulesRead(d, meta)
+}
+
+func resourceIBMISSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	secgrpID := d.Id()
+	isSecurityGroupRuleKey := "security_group_rule_key_" + secgrpID
+	ibmMutexKV.Lock(isSecurityGroupRuleKey)
+	defer ibmMutexKV.Unlock(isSecurityGroupRuleKey)
+
+	for _, rule := range d.Get(isSecurityGroupRules).(*schema.Set).List() {
+		ruleID := rule.(map[string]interface{})[isSecurityGroupRuleID].(string)
+		if ruleID == "" {
+			continue
+		}
+		deleteSecurityGroupRuleOptions := &vpcv1.DeleteSecurityGroupRuleOptions{
+			SecurityGroupID: &secgrpID,
+			ID:              &ruleID,
+		}
+		response, err := sess.DeleteSecurityGroupRule(deleteSecurityGroupRuleOptions)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			return fmt.Errorf("Error Deleting Security Group Rule %s : %s\n%s", ruleID, err, response)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISSecurityGroupRulesExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	secgrpID := d.Id()
+	getSecurityGroupOptions := &vpcv1.GetSecurityGroupOptions{
+		ID: &secgrpID,
+	}
+	_, response, err := sess.GetSecurityGroup(getSecurityGroupOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error Getting Security Group : %s\n%s", err, response)
+	}
+	return true, nil
+}
@@ -0,0 +1,226 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMISSecurityGroupRules_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	sgname := fmt.Sprintf("tf-sg-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISSecurityGroupRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISSecurityGroupRulesConfig(vpcname, sgname, 22),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSecurityGroupRulesExists("ibm_is_security_group_rules.testacc_rules", 3),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_rules.testacc_rules", "rules.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISSecurityGroupRulesConfig(vpcname, sgname, 2222),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSecurityGroupRulesExists("ibm_is_security_group_rules.testacc_rules", 3),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_rules.testacc_rules", "rules.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_security_group_rules.testacc_rules",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestPlanSecurityGroupRules(t *testing.T) {
+	ssh := &parsedIBMISSecurityGroupRuleDictionary{ruleID: "r1", direction: "inbound", ipversion: "ipv4", remote: "0.0.0.0/0", protocol: "tcp", icmpType: -1, icmpCode: -1, portMin: 22, portMax: 22}
+	ping := &parsedIBMISSecurityGroupRuleDictionary{ruleID: "r2", direction: "inbound", ipversion: "ipv4", remote: "0.0.0.0/0", protocol: "icmp", icmpType: 8, icmpCode: 0, portMin: -1, portMax: -1}
+	egress := &parsedIBMISSecurityGroupRuleDictionary{ruleID: "r3", direction: "outbound", ipversion: "ipv4", remote: "0.0.0.0/0", protocol: "all", icmpType: -1, icmpCode: -1, portMin: -1, portMax: -1}
+	existing := []*parsedIBMISSecurityGroupRuleDictionary{ssh, ping, egress}
+
+	// Same rules, without ids: nothing to do
+	desired := make([]*parsedIBMISSecurityGroupRuleDictionary, 0)
+	for _, rule := range existing {
+		r := *rule
+		r.ruleID = ""
+		desired = append(desired, &r)
+	}
+	create, update, remove := planSecurityGroupRules(existing, desired)
+	if len(create) != 0 || len(update) != 0 || len(remove) != 0 {
+		t.Fatalf("expected no changes, got %d creates, %d updates, %d deletes", len(create), len(update), len(remove))
+	}
+
+	// Port change is patched in place, a new udp rule is created and the ping rule is deleted
+	https := *desired[0]
+	https.portMin = 443
+	https.portMax = 443
+	dns := https
+	dns.protocol = "udp"
+	dns.portMin = 53
+	dns.portMax = 53
+	create, update, remove = planSecurityGroupRules(existing, []*parsedIBMISSecurityGroupRuleDictionary{&https, &dns, desired[2]})
+	if len(update) != 1 || update[0].ruleID != "r1" || update[0].portMin != 443 {
+		t.Fatalf("expected rule r1 to be updated to port 443, got %+v", update)
+	}
+	if len(create) != 1 || create[0].protocol != "udp" {
+		t.Fatalf("expected one udp rule to be created, got %+v", create)
+	}
+	if len(remove) != 1 || remove[0].ruleID != "r2" {
+		t.Fatalf("expected rule r2 to be deleted, got %+v", remove)
+	}
+}
+
+func TestSecurityGroupRulesHashRemoteDefault(t *testing.T) {
+	rule := map[string]interface{}{
+		isSecurityGroupRuleDirection:   "inbound",
+		isSecurityGroupRuleIPVersion:   "ipv4",
+		isSecurityGroupRuleRemote:      "",
+		isSecurityGroupRuleProtocolTCP: []interface{}{map[string]interface{}{isSecurityGroupRulePortMin: 22, isSecurityGroupRulePortMax: 22}},
+	}
+	read := map[string]interface{}{
+		isSecurityGroupRuleID:          "r1",
+		isSecurityGroupRuleDirection:   "inbound",
+		isSecurityGroupRuleIPVersion:   "ipv4",
+		isSecurityGroupRuleRemote:      isSecurityGroupRuleRemoteDefault,
+		isSecurityGroupRuleProtocol:    "tcp",
+		isSecurityGroupRuleProtocolTCP: []interface{}{map[string]interface{}{isSecurityGroupRulePortMin: 22, isSecurityGroupRulePortMax: 22}},
+	}
+	if resourceIBMISSecurityGroupRulesHash(rule) != resourceIBMISSecurityGroupRulesHash(read) {
+		t.Fatalf("a rule without remote should hash like the rule read back with remote %s", isSecurityGroupRuleRemoteDefault)
+	}
+
+	rule[isSecurityGroupRuleProtocolUDP] = []interface{}{map[string]interface{}{isSecurityGroupRulePortMin: 53, isSecurityGroupRulePortMax: 53}}
+	if _, err := expandSecurityGroupRulesRule(rule); err == nil {
+		t.Fatalf("expected an error for a rule with both tcp and udp")
+	}
+}
+
+func TestExpandSecurityGroupRulesRuleICMP(t *testing.T) {
+	cases := []struct {
+		icmp               map[string]interface{}
+		icmpType, icmpCode int64
+	}{
+		{map[string]interface{}{}, -1, -1},
+		{map[string]interface{}{isSecurityGroupRuleType: 8}, 8, -1},
+		{map[string]interface{}{isSecurityGroupRuleType: 0, isSecurityGroupRuleCode: 0}, 0, 0},
+	}
+	keys := make(map[string]bool)
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			isSecurityGroupID: "sg",
+			isSecurityGroupRules: []interface{}{map[string]interface{}{
+				isSecurityGroupRuleDirection:    "inbound",
+				isSecurityGroupRuleProtocolICMP: []interface{}{c.icmp},
+			}},
+		}
+		d := schema.TestResourceDataRaw(t, resourceIBMISSecurityGroupRules().Schema, raw)
+		rules := d.Get(isSecurityGroupRules).(*schema.Set).List()
+		parsed, err := expandSecurityGroupRulesRule(rules[0].(map[string]interface{}))
+		if err != nil {
+			t.Fatalf("icmp %v: %s", c.icmp, err)
+		}
+		if parsed.icmpType != c.icmpType || parsed.icmpCode != c.icmpCode {
+			t.Errorf("icmp %v: got type %d and code %d, want %d and %d", c.icmp, parsed.icmpType, parsed.icmpCode, c.icmpType, c.icmpCode)
+		}
+		keys[securityGroupRulesRuleKey(parsed)] = true
+	}
+	if len(keys) != len(cases) {
+		t.Errorf("expected a distinct key per icmp block, got %v", keys)
+	}
+}
+
+func testAccCheckIBMISSecurityGroupRulesDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_security_group_rules" {
+			continue
+		}
+
+		listSecurityGroupRulesOptions := &vpcv1.ListSecurityGroupRulesOptions{
+			SecurityGroupID: &rs.Primary.ID,
+		}
+		rules, _, err := sess.ListSecurityGroupRules(listSecurityGroupRulesOptions)
+		if err == nil && len(rules.Rules) > 0 {
+			return fmt.Errorf("security group %s still has %d rules", rs.Primary.ID, len(rules.Rules))
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISSecurityGroupRulesExists(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		listSecurityGroupRulesOptions := &vpcv1.ListSecurityGroupRulesOptions{
+			SecurityGroupID: &rs.Primary.ID,
+		}
+		rules, _, err := sess.ListSecurityGroupRules(listSecurityGroupRulesOptions)
+		if err != nil {
+			return err
+		}
+		if len(rules.Rules) != count {
+			return fmt.Errorf("security group %s has %d rules, expected %d", rs.Primary.ID, len(rules.Rules), count)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMISSecurityGroupRulesConfig(vpcname, sgname string, port int) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_security_group" "testacc_security_group" {
+		name = "%s"
+		vpc  = ibm_is_vpc.testacc_vpc.id
+	  }
+
+	  resource "ibm_is_security_group_rules" "testacc_rules" {
+		group = ibm_is_security_group.testacc_security_group.id
+
+		rules {
+		  direction = "inbound"
+		  remote    = "127.0.0.1"
+		  tcp {
+			port_min = %d
+			port_max = %d
+		  }
+		}
+
+		rules {
+		  direction = "inbound"
+		  icmp {
+			type = 8
+		  }
+		}
+
+		rules {
+		  direction = "outbound"
+		}
+	  }`, vpcname, sgname, port, port)
+}
@@ -0,0 +1,183 @@
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	isSecurityGroupTargetSecurityGroup = "security_group"
+	isSecurityGroupTargetID            = "target"
+	isSecurityGroupTargetName          = "name"
+	isSecurityGroupTargetCRN           = "crn"
+	isSecurityGroupTargetResourceType  = "resource_type"
+)
+
+func resourceIBMISSecurityGroupTarget() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISSecurityGroupTargetCreate,
+		Read:     resourceIBMISSecurityGroupTargetRead,
+		Delete:   resourceIBMISSecurityGroupTargetDelete,
+		Exists:   resourceIBMISSecurityGroupTargetExists,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupTargetSecurityGroup: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Security group identifier",
+			},
+			isSecurityGroupTargetID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifier of the network interface, load balancer or endpoint gateway to attach the security group to",
+			},
+			isSecurityGroupTargetName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the security group target",
+			},
+			isSecurityGroupTargetCRN: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CRN of the security group target",
+			},
+			isSecurityGroupTargetResourceType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource type of the security group target",
+			},
+		},
+	}
+}
+
+func resourceIBMISSecurityGroupTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	sgID := d.Get(isSecurityGroupTargetSecurityGroup).(string)
+	targetID := d.Get(isSecurityGroupTargetID).(string)
+
+	target, response, err := createSecurityGroupTargetBinding(sess, sgID, targetID)
+	if err != nil {
+		return fmt.Errorf("Error while creating Security Group Target Binding %s\n%s", err, response)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", sgID, targetID))
+
+	if target.ResourceType != nil && *target.ResourceType == securityGroupTargetTypeLoadBalancer {
+		_, err = isWaitForLBAvailable(sess, targetID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISSecurityGroupTargetRead(d, meta)
+}
+
+func resourceIBMISSecurityGroupTargetRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	sgID := parts[0]
+	targetID := parts[1]
+
+	target, response, err := getSecurityGroupTarget(sess, sgID, targetID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting Target (%s) for the Security Group (%s) : %s\n%s", targetID, sgID, err, response)
+	}
+	d.Set(isSecurityGroupTargetSecurityGroup, sgID)
+	d.Set(isSecurityGroupTargetID, targetID)
+	if target.Name != nil {
+		d.Set(isSecurityGroupTargetName, *target.Name)
+	}
+	if target.CRN != nil {
+		d.Set(isSecurityGroupTargetCRN, *target.CRN)
+	}
+	if target.ResourceType != nil {
+		d.Set(isSecurityGroupTargetResourceType, *target.ResourceType)
+	}
+	return nil
+}
+
+func resourceIBMISSecurityGroupTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	sgID := parts[0]
+	targetID := parts[1]
+
+	target, response, err := getSecurityGroupTarget(sess, sgID, targetID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting Target (%s) for the Security Group (%s) : %s\n%s", targetID, sgID, err, response)
+	}
+
+	response, err = deleteSecurityGroupTargetBinding(sess, sgID, targetID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error Deleting Target (%s) for the Security Group (%s) : %s\n%s", targetID, sgID, err, response)
+	}
+
+	if target.ResourceType != nil && *target.ResourceType == securityGroupTargetTypeLoadBalancer {
+		_, err = isWaitForLBAvailable(sess, targetID, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISSecurityGroupTargetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+	if len(parts) != 2 {
+		return false, fmt.Errorf("Incorrect ID %s: ID should be a combination of securityGroupID/targetID", d.Id())
+	}
+	sgID := parts[0]
+	targetID := parts[1]
+
+	_, response, err := getSecurityGroupTarget(sess, sgID, targetID)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting Target (%s) for the Security Group (%s) : %s\n%s", targetID, sgID, err, response)
+	}
+	return true, nil
+}
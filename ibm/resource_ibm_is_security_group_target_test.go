@@ -0,0 +1,112 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMISSecurityGroupTarget_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tfsgt-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tfsgt-subnet-%d", acctest.RandIntRange(10, 100))
+	lbname := fmt.Sprintf("tfsgt-lb-%d", acctest.RandIntRange(10, 100))
+	sgname := fmt.Sprintf("tfsgt-sg-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISSecurityGroupTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISSecurityGroupTargetConfig(vpcname, subnetname, ISZoneName, ISCIDR, lbname, sgname),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSecurityGroupTargetExists("ibm_is_security_group_target.testacc_target"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_target.testacc_target", "name", lbname),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_target.testacc_target", "resource_type", "load_balancer"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_security_group_target.testacc_target",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISSecurityGroupTargetDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_security_group_target" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, _, err = getSecurityGroupTarget(sess, parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("security group target still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISSecurityGroupTargetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, _, err = getSecurityGroupTarget(sess, parts[0], parts[1])
+		return err
+	}
+}
+
+func testAccCheckIBMISSecurityGroupTargetConfig(vpcname, subnetname, zone, cidr, lbname, sgname string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	}
+
+	resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	}
+
+	resource "ibm_is_lb" "testacc_lb" {
+		name    = "%s"
+		subnets = [ibm_is_subnet.testacc_subnet.id]
+	}
+
+	resource "ibm_is_security_group" "testacc_security_group" {
+		name = "%s"
+		vpc  = ibm_is_vpc.testacc_vpc.id
+	}
+
+	resource "ibm_is_security_group_target" "testacc_target" {
+		security_group = ibm_is_security_group.testacc_security_group.id
+		target         = ibm_is_lb.testacc_lb.id
+	}`, vpcname, subnetname, zone, cidr, lbname, sgname)
+}
@@ -22,7 +22,7 @@ const (
 )
 
 func resourceIBMISSSHKey() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_ssh_key", &schema.Resource{
 		Create:   resourceIBMISSSHKeyCreate,
 		Read:     resourceIBMISSSHKeyRead,
 		Update:   resourceIBMISSSHKeyUpdate,
@@ -32,7 +32,7 @@ func resourceIBMISSSHKey() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -78,6 +78,8 @@ func resourceIBMISSSHKey() *schema.Resource {
 				Description: "List of tags for SSH key",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			isKeyResourceGroup: {
 				Type:        schema.TypeString,
 				ForceNew:    true,
@@ -110,7 +112,7 @@ func resourceIBMISSSHKey() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISSHKeyValidator() *ResourceValidator {
@@ -178,8 +180,8 @@ func classicKeyCreate(d *schema.ResourceData, meta interface{}, name, publickey
 	log.Printf("[INFO] Key : %s", *key.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isKeyTags); ok || v != "" {
-		oldList, newList := d.GetChange(isKeyTags)
+	if _, ok := d.GetOk(isKeyTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *key.CRN)
 		if err != nil {
 			log.Printf(
@@ -214,8 +216,8 @@ func keyCreate(d *schema.ResourceData, meta interface{}, name, publickey string)
 	log.Printf("[INFO] Key : %s", *key.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isKeyTags); ok || v != "" {
-		oldList, newList := d.GetChange(isKeyTags)
+	if _, ok := d.GetOk(isKeyTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *key.CRN)
 		if err != nil {
 			log.Printf(
@@ -272,7 +274,7 @@ func classicKeyGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc SSH Key (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isKeyTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -313,7 +315,7 @@ func keyGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of vpc SSH Key (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isKeyTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -362,7 +364,7 @@ func classicKeyUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isKeyTags) {
+	if d.HasChange(isKeyTags) || d.HasChange(tagsAll) {
 		options := &vpcclassicv1.GetKeyOptions{
 			ID: &id,
 		}
@@ -370,7 +372,7 @@ func classicKeyUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting SSH Key : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isKeyTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *key.CRN)
 		if err != nil {
 			log.Printf(
@@ -402,7 +404,7 @@ func keyUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isKeyTags) {
+	if d.HasChange(isKeyTags) || d.HasChange(tagsAll) {
 		options := &vpcv1.GetKeyOptions{
 			ID: &id,
 		}
@@ -410,7 +412,7 @@ func keyUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		if err != nil {
 			return fmt.Errorf("Error getting SSH Key : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isKeyTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *key.CRN)
 		if err != nil {
 			log.Printf(
@@ -34,7 +34,7 @@ const (
 )
 
 func resourceIBMISSubnet() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_subnet", &schema.Resource{
 		Create:   resourceIBMISSubnetCreate,
 		Read:     resourceIBMISSubnetRead,
 		Update:   resourceIBMISSubnetUpdate,
@@ -174,7 +174,7 @@ func resourceIBMISSubnet() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISSubnetValidator() *ResourceValidator {
@@ -0,0 +1,205 @@
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceIBMISSubnetRoutingTableAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISSubnetRoutingTableAttachmentCreate,
+		Read:     resourceIBMISSubnetRoutingTableAttachmentRead,
+		Update:   resourceIBMISSubnetRoutingTableAttachmentUpdate,
+		Delete:   resourceIBMISSubnetRoutingTableAttachmentDelete,
+		Exists:   resourceIBMISSubnetRoutingTableAttachmentExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			isSubnetID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The subnet identifier",
+			},
+
+			rtID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The routing table identifier",
+			},
+
+			rtName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the routing table",
+			},
+
+			rtLifecycleState: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The lifecycle state of the routing table",
+			},
+
+			rtIsDefault: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether this is the default routing table for the VPC",
+			},
+
+			rtResourceType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource type of the routing table",
+			},
+
+			rtRouteDirectLinkIngress: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the routing table is used to route traffic that originates from Direct Link to the VPC",
+			},
+
+			rtRouteTransitGatewayIngress: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the routing table is used to route traffic that originates from Transit Gateway to the VPC",
+			},
+
+			rtRouteVPCZoneIngress: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the routing table is used to route traffic that originates from subnets in other zones of the VPC",
+			},
+		},
+	}
+}
+
+func resourceIBMISSubnetRoutingTableAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	subnetID := d.Get(isSubnetID).(string)
+	routingTableID := d.Get(rtID).(string)
+
+	err = isReplaceSubnetRoutingTable(sess, subnetID, routingTableID)
+	if err != nil {
+		return err
+	}
+	d.SetId(subnetID)
+	return resourceIBMISSubnetRoutingTableAttachmentRead(d, meta)
+}
+
+func isReplaceSubnetRoutingTable(sess *vpcv1.VpcV1, subnetID, routingTableID string) error {
+	replaceSubnetRoutingTableOptions := &vpcv1.ReplaceSubnetRoutingTableOptions{
+		ID: &subnetID,
+		RoutingTableIdentity: &vpcv1.RoutingTableIdentityByID{
+			ID: &routingTableID,
+		},
+	}
+	_, response, err := sess.ReplaceSubnetRoutingTable(replaceSubnetRoutingTableOptions)
+	if err != nil {
+		return fmt.Errorf("Error attaching routing table %s to subnet %s: %s\n%s", routingTableID, subnetID, err, response)
+	}
+	return nil
+}
+
+func resourceIBMISSubnetRoutingTableAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	subnetID := d.Id()
+	getSubnetRoutingTableOptions := &vpcv1.GetSubnetRoutingTableOptions{
+		ID: &subnetID,
+	}
+	routeTable, response, err := sess.GetSubnetRoutingTable(getSubnetRoutingTableOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting routing table of subnet %s: %s\n%s", subnetID, err, response)
+	}
+
+	d.Set(isSubnetID, subnetID)
+	d.Set(rtID, *routeTable.ID)
+	d.Set(rtName, *routeTable.Name)
+	d.Set(rtLifecycleState, *routeTable.LifecycleState)
+	d.Set(rtIsDefault, *routeTable.IsDefault)
+	d.Set(rtResourceType, *routeTable.ResourceType)
+	d.Set(rtRouteDirectLinkIngress, *routeTable.RouteDirectLinkIngress)
+	d.Set(rtRouteTransitGatewayIngress, *routeTable.RouteTransitGatewayIngress)
+	d.Set(rtRouteVPCZoneIngress, *routeTable.RouteVPCZoneIngress)
+	return nil
+}
+
+func resourceIBMISSubnetRoutingTableAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	if d.HasChange(rtID) {
+		err = isReplaceSubnetRoutingTable(sess, d.Id(), d.Get(rtID).(string))
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISSubnetRoutingTableAttachmentRead(d, meta)
+}
+
+func resourceIBMISSubnetRoutingTableAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	subnetID := d.Id()
+	getSubnetOptions := &vpcv1.GetSubnetOptions{
+		ID: &subnetID,
+	}
+	subnet, response, err := sess.GetSubnet(getSubnetOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error getting subnet %s: %s\n%s", subnetID, err, response)
+	}
+
+	// A subnet always has a routing table, detaching means going back to the VPC default one
+	getVPCDefaultRoutingTableOptions := &vpcv1.GetVPCDefaultRoutingTableOptions{
+		ID: subnet.VPC.ID,
+	}
+	defaultRoutingTable, response, err := sess.GetVPCDefaultRoutingTable(getVPCDefaultRoutingTableOptions)
+	if err != nil {
+		return fmt.Errorf("Error getting default routing table of VPC %s: %s\n%s", *subnet.VPC.ID, err, response)
+	}
+	if subnet.RoutingTable == nil || *subnet.RoutingTable.ID != *defaultRoutingTable.ID {
+		err = isReplaceSubnetRoutingTable(sess, subnetID, *defaultRoutingTable.ID)
+		if err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMISSubnetRoutingTableAttachmentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return false, err
+	}
+	subnetID := d.Id()
+	getSubnetRoutingTableOptions := &vpcv1.GetSubnetRoutingTableOptions{
+		ID: &subnetID,
+	}
+	_, response, err := sess.GetSubnetRoutingTable(getSubnetRoutingTableOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error getting routing table of subnet %s: %s\n%s", subnetID, err, response)
+	}
+	return true, nil
+}
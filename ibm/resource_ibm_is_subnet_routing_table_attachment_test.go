@@ -0,0 +1,131 @@
+package ibm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccIBMISSubnetRoutingTableAttachment_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	rtname := fmt.Sprintf("tf-rt-%d", acctest.RandIntRange(10, 100))
+	rtname2 := fmt.Sprintf("tf-rt-two-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISSubnetRoutingTableAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISSubnetRoutingTableAttachmentConfig(vpcname, subnetname, rtname, rtname2, "testacc_rt"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSubnetRoutingTableAttachmentExists("ibm_is_subnet_routing_table_attachment.testacc_attachment"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_subnet_routing_table_attachment.testacc_attachment", "routing_table",
+						"ibm_is_vpc_routing_table.testacc_rt", "routing_table"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_subnet_routing_table_attachment.testacc_attachment", "name", rtname),
+					resource.TestCheckResourceAttr(
+						"ibm_is_subnet_routing_table_attachment.testacc_attachment", "is_default", "false"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISSubnetRoutingTableAttachmentConfig(vpcname, subnetname, rtname, rtname2, "testacc_rt2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSubnetRoutingTableAttachmentExists("ibm_is_subnet_routing_table_attachment.testacc_attachment"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_subnet_routing_table_attachment.testacc_attachment", "routing_table",
+						"ibm_is_vpc_routing_table.testacc_rt2", "routing_table"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_subnet_routing_table_attachment.testacc_attachment", "name", rtname2),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_subnet_routing_table_attachment.testacc_attachment",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISSubnetRoutingTableAttachmentDestroy(s *terraform.State) error {
+	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_subnet_routing_table_attachment" {
+			continue
+		}
+
+		getSubnetRoutingTableOptions := &vpcv1.GetSubnetRoutingTableOptions{
+			ID: &rs.Primary.ID,
+		}
+		rt, _, err := sess.GetSubnetRoutingTable(getSubnetRoutingTableOptions)
+		if err == nil && rt.ID != nil && *rt.ID == rs.Primary.Attributes["routing_table"] {
+			return fmt.Errorf("subnet routing table attachment still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISSubnetRoutingTableAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		getSubnetRoutingTableOptions := &vpcv1.GetSubnetRoutingTableOptions{
+			ID: &rs.Primary.ID,
+		}
+		rt, _, err := sess.GetSubnetRoutingTable(getSubnetRoutingTableOptions)
+		if err != nil {
+			return err
+		}
+		if *rt.ID != rs.Primary.Attributes["routing_table"] {
+			return fmt.Errorf("subnet %s is attached to routing table %s", rs.Primary.ID, *rt.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMISSubnetRoutingTableAttachmentConfig(vpcname, subnetname, rtname, rtname2, attached string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+
+	  resource "ibm_is_vpc_routing_table" "testacc_rt" {
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		name = "%s"
+	  }
+
+	  resource "ibm_is_vpc_routing_table" "testacc_rt2" {
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet_routing_table_attachment" "testacc_attachment" {
+		subnet        = ibm_is_subnet.testacc_subnet.id
+		routing_table = ibm_is_vpc_routing_table.%s.routing_table
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, rtname, rtname2, attached)
+}
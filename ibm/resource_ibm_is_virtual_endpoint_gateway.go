@@ -46,7 +46,7 @@ func resourceIBMISEndpointGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -175,6 +175,8 @@ func resourceIBMISEndpointGateway() *schema.Resource {
 				Set:         resourceIBMVPCHash,
 				Description: "List of tags for VPE",
 			},
+
+			tagsAll: tagsAllSchema(),
 		},
 	}
 }
@@ -235,8 +237,8 @@ func resourceIBMisVirtualEndpointGatewayCreate(d *schema.ResourceData, meta inte
 
 	d.SetId(*result.ID)
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVirtualEndpointGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVirtualEndpointGatewayTags)
+	if _, ok := d.GetOk(isVirtualEndpointGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *result.CRN)
 		if err != nil {
 			log.Printf(
@@ -267,13 +269,13 @@ func resourceIBMisVirtualEndpointGatewayUpdate(d *schema.ResourceData, meta inte
 		}
 
 	}
-	if d.HasChange(isVirtualEndpointGatewayTags) {
+	if d.HasChange(isVirtualEndpointGatewayTags) || d.HasChange(tagsAll) {
 		opt := sess.NewGetEndpointGatewayOptions(d.Id())
 		result, response, err := sess.GetEndpointGateway(opt)
 		if err != nil {
 			return fmt.Errorf("Error getting VPE: %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isVirtualEndpointGatewayTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *result.CRN)
 		if err != nil {
 			log.Printf(
@@ -311,7 +313,7 @@ func resourceIBMisVirtualEndpointGatewayRead(d *schema.ResourceData, meta interf
 		log.Printf(
 			"Error on get of VPE (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVirtualEndpointGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 	return nil
 }
 
@@ -31,7 +31,7 @@ const (
 )
 
 func resourceIBMISVolume() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_volume", &schema.Resource{
 		Create:   resourceIBMISVolumeCreate,
 		Read:     resourceIBMISVolumeRead,
 		Update:   resourceIBMISVolumeUpdate,
@@ -46,7 +46,7 @@ func resourceIBMISVolume() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -122,6 +122,8 @@ func resourceIBMISVolume() *schema.Resource {
 				Description: "Tags for the volume instance",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -152,7 +154,7 @@ func resourceIBMISVolume() *schema.Resource {
 				Description: "The resource group name in which resource is provisioned",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISVolumeValidator() *ResourceValidator {
@@ -250,8 +252,8 @@ func classicVolCreate(d *schema.ResourceData, meta interface{}, volName, profile
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVolumeTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVolumeTags)
+	if _, ok := d.GetOk(isVolumeTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
 		if err != nil {
 			log.Printf(
@@ -310,8 +312,8 @@ func volCreate(d *schema.ResourceData, meta interface{}, volName, profile, zone
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVolumeTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVolumeTags)
+	if _, ok := d.GetOk(isVolumeTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
 		if err != nil {
 			log.Printf(
@@ -374,7 +376,7 @@ func classicVolGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc volume (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVolumeTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -422,7 +424,7 @@ func volGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc volume (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVolumeTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -472,7 +474,7 @@ func classicVolUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVolumeTags) {
+	if d.HasChange(isVolumeTags) || d.HasChange(tagsAll) {
 		options := &vpcclassicv1.GetVolumeOptions{
 			ID: &id,
 		}
@@ -480,7 +482,7 @@ func classicVolUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting Volume : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isVolumeTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
 		if err != nil {
 			log.Printf(
@@ -512,7 +514,7 @@ func volUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVolumeTags) {
+	if d.HasChange(isVolumeTags) || d.HasChange(tagsAll) {
 		options := &vpcv1.GetVolumeOptions{
 			ID: &id,
 		}
@@ -520,7 +522,7 @@ func volUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		if err != nil {
 			return fmt.Errorf("Error getting Volume : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isVolumeTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vol.CRN)
 		if err != nil {
 			log.Printf(
@@ -51,7 +51,7 @@ const (
 )
 
 func resourceIBMISVPC() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_vpc", &schema.Resource{
 		Create:   resourceIBMISVPCCreate,
 		Read:     resourceIBMISVPCRead,
 		Update:   resourceIBMISVPCUpdate,
@@ -66,7 +66,7 @@ func resourceIBMISVPC() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -133,6 +133,8 @@ func resourceIBMISVPC() *schema.Resource {
 				Description: "List of tags",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			isVPCCRN: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -312,7 +314,7 @@ func resourceIBMISVPC() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISVPCValidator() *ResourceValidator {
@@ -407,8 +409,8 @@ func classicVpcCreate(d *schema.ResourceData, meta interface{}, name, apm, rg st
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPCTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVPCTags)
+	if _, ok := d.GetOk(isVPCTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
 		if err != nil {
 			log.Printf(
@@ -480,8 +482,8 @@ func vpcCreate(d *schema.ResourceData, meta interface{}, name, apm, rg string, i
 		return err
 	}
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPCTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVPCTags)
+	if _, ok := d.GetOk(isVPCTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
 		if err != nil {
 			log.Printf(
@@ -581,7 +583,7 @@ func classicVpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVPCTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -801,7 +803,7 @@ func vpcGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVPCTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -1015,7 +1017,7 @@ func classicVpcUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVPCTags) {
+	if d.HasChange(isVPCTags) || d.HasChange(tagsAll) {
 		getvpcOptions := &vpcclassicv1.GetVPCOptions{
 			ID: &id,
 		}
@@ -1023,7 +1025,7 @@ func classicVpcUpdate(d *schema.ResourceData, meta interface{}, id, name string,
 		if err != nil {
 			return fmt.Errorf("Error getting VPC : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isVPCTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
 		if err != nil {
 			log.Printf(
@@ -1055,7 +1057,7 @@ func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVPCTags) {
+	if d.HasChange(isVPCTags) || d.HasChange(tagsAll) {
 		getvpcOptions := &vpcv1.GetVPCOptions{
 			ID: &id,
 		}
@@ -1063,7 +1065,7 @@ func vpcUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasCha
 		if err != nil {
 			return fmt.Errorf("Error getting VPC : %s\n%s", err, response)
 		}
-		oldList, newList := d.GetChange(isVPCTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpc.CRN)
 		if err != nil {
 			log.Printf(
@@ -17,7 +17,7 @@ const (
 )
 
 func resourceIBMISVpcAddressPrefix() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_vpc_address_prefix", &schema.Resource{
 		Create:   resourceIBMISVpcAddressPrefixCreate,
 		Read:     resourceIBMISVpcAddressPrefixRead,
 		Update:   resourceIBMISVpcAddressPrefixUpdate,
@@ -67,7 +67,7 @@ func resourceIBMISVpcAddressPrefix() *schema.Resource {
 				Description: "The crn of the VPC resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISAddressPrefixValidator() *ResourceValidator {
@@ -29,7 +29,7 @@ const (
 )
 
 func resourceIBMISVpcRoute() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_vpc_route", &schema.Resource{
 		Create:   resourceIBMISVpcRouteCreate,
 		Read:     resourceIBMISVpcRouteRead,
 		Update:   resourceIBMISVpcRouteUpdate,
@@ -90,7 +90,7 @@ func resourceIBMISVpcRoute() *schema.Resource {
 				Description: "The crn of the VPC resource",
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISRouteValidator() *ResourceValidator {
@@ -40,6 +40,40 @@ func TestAccIBMISVPCRoutingTable_basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMISVPCRoutingTable_ingress(t *testing.T) {
+	var vpcRouteTables string
+	name1 := fmt.Sprintf("tfvpc-ingress-%d", acctest.RandIntRange(10, 100))
+	routeTableName := fmt.Sprintf("tfvpcrt-ingress-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPCRouteTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCRouteTableIngressConfig(routeTableName, name1, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCRouteTableExists("ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", vpcRouteTables),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", "route_direct_link_ingress", "true"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", "route_transit_gateway_ingress", "false"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISVPCRouteTableIngressConfig(routeTableName, name1, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCRouteTableExists("ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", vpcRouteTables),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", "route_direct_link_ingress", "false"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table", "route_transit_gateway_ingress", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMISVPCRouteTableDestroy(s *terraform.State) error {
 	//userDetails, _ := testAccProvider.Meta().(ClientSession).BluemixUserDetails()
 
@@ -112,3 +146,16 @@ resource "ibm_is_vpc_routing_table" "test_ibm_is_vpc_routing_table" {
 	name = "%s"
 }`, name, rtName)
 }
+
+func testAccCheckIBMISVPCRouteTableIngressConfig(rtName, name string, directLink, transitGateway bool) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+	name = "%s"
+}
+resource "ibm_is_vpc_routing_table" "test_ibm_is_vpc_routing_table" {
+	vpc                           = ibm_is_vpc.testacc_vpc.id
+	name                          = "%s"
+	route_direct_link_ingress     = %t
+	route_transit_gateway_ingress = %t
+}`, name, rtName, directLink, transitGateway)
+}
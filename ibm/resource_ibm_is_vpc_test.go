@@ -3,6 +3,8 @@ package ibm
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/IBM/vpc-go-sdk/vpcclassicv1"
@@ -59,6 +61,80 @@ func TestAccIBMISVPC_basic(t *testing.T) {
 	})
 }
 
+func TestMockIBMISVPC_basic(t *testing.T) {
+	var vpc string
+	name := fmt.Sprintf("terraformvpcuat-%d", acctest.RandIntRange(10, 100))
+	mock := newMockBackend(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPCDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCExists("ibm_is_vpc.testacc_vpc", vpc),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_vpc.testacc_vpc", "default_security_group"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISVPCConfigUpdate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCExists("ibm_is_vpc.testacc_vpc", vpc),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags.#", "1"),
+				),
+			},
+		},
+	})
+	if n := mock.Count("vpcs"); n != 0 {
+		t.Fatalf("expected all mock VPCs to be deleted, found %d", n)
+	}
+}
+
+func TestMockIBMISVPC_defaultTags(t *testing.T) {
+	var vpc string
+	name := fmt.Sprintf("terraformvpcuat-%d", acctest.RandIntRange(10, 100))
+	newMockBackend(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPCDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCDefaultTagsConfig(name, `"env:dev", "costcenter:42"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCExists("ibm_is_vpc.testacc_vpc", vpc),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags_all.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", fmt.Sprintf("tags_all.%d", resourceIBMVPCHash("env:prod")), "env:prod"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", fmt.Sprintf("tags_all.%d", resourceIBMVPCHash("costcenter:42")), "costcenter:42"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISVPCDefaultTagsConfig(name, `"costcenter:43"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", "tags_all.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc.testacc_vpc", fmt.Sprintf("tags_all.%d", resourceIBMVPCHash("costcenter:43")), "costcenter:43"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccIBMISVPC_securityGroups(t *testing.T) {
 	var vpc string
 	vpcname := fmt.Sprintf("terraformvpcuat-%d", acctest.RandIntRange(10, 100))
@@ -179,6 +255,19 @@ resource "ibm_is_vpc" "testacc_vpc" {
 
 }
 
+func testAccCheckIBMISVPCDefaultTagsConfig(name, defaultTags string) string {
+	return fmt.Sprintf(`
+provider "ibm" {
+	default_tags = [%s]
+}
+
+resource "ibm_is_vpc" "testacc_vpc" {
+	name = "%s"
+	tags = ["env:prod"]
+}`, defaultTags, name)
+
+}
+
 func testAccCheckIBMISVPCConfig1(name string, apm string) string {
 	return fmt.Sprintf(`
 resource "ibm_is_vpc" "testacc_vpc1" {
@@ -212,3 +301,82 @@ func testAccCheckIBMISVPCSgConfig(vpcname string, sgname string) string {
 `, vpcname, sgname)
 
 }
+
+func TestIsGen1ResourceID(t *testing.T) {
+	gen1 := []string{
+		"4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1",
+		"4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1/9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+		"4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1.9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+	}
+	for _, id := range gen1 {
+		if !isGen1ResourceID(id) {
+			t.Errorf("expected %s to be a Generation 1 id", id)
+		}
+	}
+	gen2 := []string{
+		"r006-4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1",
+		"0717-4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1/r006-9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+		"r006-4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1.r006-9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+		"",
+	}
+	for _, id := range gen2 {
+		if isGen1ResourceID(id) {
+			t.Errorf("expected %s not to be a Generation 1 id", id)
+		}
+	}
+}
+
+func TestCheckGen1State(t *testing.T) {
+	id := "4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1"
+	attributes := map[string]string{
+		"id":                                 id,
+		"name":                               "web",
+		"crn":                                "crn:v1:bluemix:public:is:us-south:a/123::vpc:4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1",
+		"vpc":                                "9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+		"primary_network_interface.#":        "1",
+		"primary_network_interface.0.subnet": "0c8e6a2c-7a3a-4b8f-9f1e-5b2c1d0e9f8a",
+	}
+
+	// A Generation 1 provider keeps its state
+	if err := checkGen1State("ibm_is_instance", id, attributes, 1); err != nil {
+		t.Fatalf("expected no error for a generation 1 provider, got %v", err)
+	}
+
+	// Generation 2 state is kept
+	if err := checkGen1State("ibm_is_instance", "r006-4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1", map[string]string{}, 2); err != nil {
+		t.Fatalf("expected no error for generation 2 state, got %v", err)
+	}
+
+	// Generation 1 state is reported with the Generation 1 resources it refers to
+	err := checkGen1State("ibm_is_vpc", id, attributes, 2)
+	gen1Err, ok := err.(*gen1StateError)
+	if !ok {
+		t.Fatalf("expected a gen1StateError, got %v", err)
+	}
+	expected := []string{
+		"primary_network_interface.0.subnet = 0c8e6a2c-7a3a-4b8f-9f1e-5b2c1d0e9f8a",
+		"vpc = 9bc1c7a8-07d4-4a14-8a2c-0f55e5b3c0a1",
+	}
+	if !reflect.DeepEqual(gen1Err.References, expected) {
+		t.Fatalf("expected references %v, got %v", expected, gen1Err.References)
+	}
+	for _, s := range append(expected, `ibm_is_vpc "web" (4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1)`, "generation = 1") {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected the error to contain %q:\n%s", s, err)
+		}
+	}
+}
+
+func TestResourceIBMISVPCGen1StateCheck(t *testing.T) {
+	r := resourceIBMISVPC()
+	if r.SchemaVersion != 1 || len(r.StateUpgraders) != 1 {
+		t.Fatalf("expected ibm_is_vpc to keep schema version 1 and one state upgrader")
+	}
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+	rawState := map[string]interface{}{"id": "4cd4e4a1-1bb4-4dd4-b1c1-3fd8d5a0cfb1"}
+	if state, err := r.StateUpgraders[0].Upgrade(rawState, nil); err != nil || !reflect.DeepEqual(state, rawState) {
+		t.Fatalf("expected the state upgrader to keep the state, got %v, %v", state, err)
+	}
+}
@@ -31,7 +31,7 @@ const (
 )
 
 func resourceIBMISVPNGateway() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_vpn_gateway", &schema.Resource{
 		Create:   resourceIBMISVPNGatewayCreate,
 		Read:     resourceIBMISVPNGatewayRead,
 		Update:   resourceIBMISVPNGatewayUpdate,
@@ -46,7 +46,7 @@ func resourceIBMISVPNGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -100,6 +100,8 @@ func resourceIBMISVPNGateway() *schema.Resource {
 				Description: "VPN Gateway tags list",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -166,7 +168,7 @@ func resourceIBMISVPNGateway() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISVPNGatewayValidator() *ResourceValidator {
@@ -254,8 +256,8 @@ func classicVpngwCreate(d *schema.ResourceData, meta interface{}, name, subnetID
 	log.Printf("[INFO] VPNGateway : %s", *vpnGateway.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVPNGatewayTags)
+	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpnGateway.CRN)
 		if err != nil {
 			log.Printf(
@@ -303,8 +305,8 @@ func vpngwCreate(d *schema.ResourceData, meta interface{}, name, subnetID, mode
 	log.Printf("[INFO] VPNGateway : %s", *vpnGateway.ID)
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(isVPNGatewayTags)
+	if _, ok := d.GetOk(isVPNGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpnGateway.CRN)
 		if err != nil {
 			log.Printf(
@@ -438,7 +440,7 @@ func classicVpngwGet(d *schema.ResourceData, meta interface{}, id string) error
 		log.Printf(
 			"Error on get of resource vpc VPN Gateway (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVPNGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -488,7 +490,7 @@ func vpngwGet(d *schema.ResourceData, meta interface{}, id string) error {
 		log.Printf(
 			"Error on get of resource vpc VPN Gateway (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(isVPNGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 	controller, err := getBaseController(meta)
 	if err != nil {
 		return err
@@ -555,7 +557,7 @@ func classicVpngwUpdate(d *schema.ResourceData, meta interface{}, id, name strin
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVPNGatewayTags) {
+	if d.HasChange(isVPNGatewayTags) || d.HasChange(tagsAll) {
 		getVpnGatewayOptions := &vpcclassicv1.GetVPNGatewayOptions{
 			ID: &id,
 		}
@@ -565,7 +567,7 @@ func classicVpngwUpdate(d *schema.ResourceData, meta interface{}, id, name strin
 		}
 		vpnGateway := vpnGatewayIntf.(*vpcclassicv1.VPNGateway)
 
-		oldList, newList := d.GetChange(isVPNGatewayTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpnGateway.CRN)
 		if err != nil {
 			log.Printf(
@@ -597,7 +599,7 @@ func vpngwUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasC
 	if err != nil {
 		return err
 	}
-	if d.HasChange(isVPNGatewayTags) {
+	if d.HasChange(isVPNGatewayTags) || d.HasChange(tagsAll) {
 		getVpnGatewayOptions := &vpcv1.GetVPNGatewayOptions{
 			ID: &id,
 		}
@@ -607,7 +609,7 @@ func vpngwUpdate(d *schema.ResourceData, meta interface{}, id, name string, hasC
 		}
 		vpnGateway := vpnGatewayIntf.(*vpcv1.VPNGateway)
 
-		oldList, newList := d.GetChange(isVPNGatewayTags)
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *vpnGateway.CRN)
 		if err != nil {
 			log.Printf(
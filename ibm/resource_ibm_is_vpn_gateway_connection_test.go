@@ -76,6 +76,10 @@ func TestAccIBMISVPNGatewayConnection_route(t *testing.T) {
 						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection1", "name", name1),
 					resource.TestCheckResourceAttr(
 						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection1", "mode", "route"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection1", "tunnels.0.public_ip"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection1", "tunnels.0.status"),
 				),
 			},
 			resource.TestStep{
@@ -92,6 +96,82 @@ func TestAccIBMISVPNGatewayConnection_route(t *testing.T) {
 	})
 }
 
+func TestAccIBMISVPNGatewayConnection_cidrs(t *testing.T) {
+	var connectionID string
+	vpcname := fmt.Sprintf("tfvpngc-vpc-%d", acctest.RandIntRange(200, 300))
+	subnetname := fmt.Sprintf("tfvpngc-subnet-%d", acctest.RandIntRange(200, 300))
+	vpnname := fmt.Sprintf("tfvpngc-vpn-%d", acctest.RandIntRange(200, 300))
+	name := fmt.Sprintf("tfvpngc-createname-%d", acctest.RandIntRange(200, 300))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPNGatewayConnectionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMISVPNGatewayConnectionCIDRsConfig(vpcname, subnetname, vpnname, name, `"10.245.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPNGatewayConnectionSameID("ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", &connectionID),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", "peer_cidrs.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", "tunnels.0.public_ip"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", "tunnels.0.member_status"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMISVPNGatewayConnectionCIDRsConfig(vpcname, subnetname, vpnname, name, `"10.246.0.0/24", "10.247.0.0/24"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPNGatewayConnectionSameID("ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", &connectionID),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpn_gateway_connection.testacc_VPNGatewayConnection", "peer_cidrs.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestVpngwconCIDRParts(t *testing.T) {
+	prefix, length, err := vpngwconCIDRParts("10.245.0.0/24")
+	if err != nil || prefix != "10.245.0.0" || length != "24" {
+		t.Fatalf("expected 10.245.0.0 and 24, got %q, %q, %v", prefix, length, err)
+	}
+	for _, cidr := range []string{"10.245.0.0", "10.245.0.0/", "/24", "10.245.0.0/24/1"} {
+		if _, _, err := vpngwconCIDRParts(cidr); err == nil {
+			t.Fatalf("expected an error for CIDR %q", cidr)
+		}
+	}
+}
+
+func TestFlattenVPNGatewayConnectionTunnels(t *testing.T) {
+	ip1, ip2 := "169.61.161.150", "169.61.161.151"
+	active, standby := "active", "standby"
+	available := "available"
+	up := "up"
+	members := []vpcv1.VPNGatewayMember{
+		{PublicIP: &vpcv1.IP{Address: &ip1}, Role: &active, Status: &available},
+		{PublicIP: &vpcv1.IP{Address: &ip2}, Role: &standby, Status: &available},
+	}
+
+	// Policy mode: no tunnels reported, the members are
+	tunnels := flattenVPNGatewayConnectionTunnels(members, nil)
+	if len(tunnels) != 2 || tunnels[1][isVPNGatewayConnectionTunnelPublicIP] != ip2 || tunnels[1][isVPNGatewayConnectionTunnelMemberStatus] != available {
+		t.Fatalf("expected a tunnel per member, got %v", tunnels)
+	}
+	if _, ok := tunnels[0]["status"]; ok {
+		t.Fatalf("expected no tunnel status in policy mode, got %v", tunnels[0])
+	}
+
+	// Route mode: the status of the tunnel goes to its member
+	tunnels = flattenVPNGatewayConnectionTunnels(members, []vpcv1.VPNGatewayConnectionStaticRouteModeTunnel{
+		{PublicIP: &vpcv1.IP{Address: &ip2}, Status: &up},
+	})
+	if _, ok := tunnels[0]["status"]; ok || tunnels[1]["status"] != up || tunnels[1][isVPNGatewayConnectionTunnelRole] != standby {
+		t.Fatalf("expected the standby member to have tunnel status up, got %v", tunnels)
+	}
+}
+
 func testAccCheckIBMISVPNGatewayConnectionDestroy(s *terraform.State) error {
 	userDetails, _ := testAccProvider.Meta().(ClientSession).BluemixUserDetails()
 
@@ -404,3 +484,52 @@ func testAccCheckIBMISVPNGatewayConnectionRouteUpdate(vpc1, subnet1, vpnname1, n
 	`, vpc1, subnet1, ISZoneName, ISCIDR, vpnname1, name1, vpc2, subnet2, ISZoneName, ISCIDR, vpnname2, name2)
 
 }
+
+// testAccCheckIBMISVPNGatewayConnectionSameID records the ID of the connection
+// on the first call and fails if a later step has replaced the connection.
+func testAccCheckIBMISVPNGatewayConnectionSameID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+		} else if *id != rs.Primary.ID {
+			return fmt.Errorf("VPN gateway connection was replaced: %s is now %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMISVPNGatewayConnectionCIDRsConfig(vpc, subnet, vpnname, name, peerCIDRs string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	}
+
+	resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	}
+
+	resource "ibm_is_vpn_gateway" "testacc_VPNGateway" {
+		name   = "%s"
+		subnet = ibm_is_subnet.testacc_subnet.id
+		mode   = "policy"
+	}
+
+	resource "ibm_is_vpn_gateway_connection" "testacc_VPNGatewayConnection" {
+		name          = "%s"
+		vpn_gateway   = ibm_is_vpn_gateway.testacc_VPNGateway.id
+		peer_address  = "1.2.3.4"
+		preshared_key = "VPNDemoPassword"
+		local_cidrs   = [ibm_is_subnet.testacc_subnet.ipv4_cidr_block]
+		peer_cidrs    = [%s]
+	}`, vpc, subnet, ISZoneName, ISCIDR, vpnname, name, peerCIDRs)
+}
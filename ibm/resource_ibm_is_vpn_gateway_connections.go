@@ -3,8 +3,10 @@ package ibm
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/IBM/go-sdk-core/v4/core"
 	"github.com/IBM/vpc-go-sdk/vpcclassicv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -32,12 +34,15 @@ const (
 	isVPNGatewayConnectionProvisioningDone          = "done"
 	isVPNGatewayConnectionMode                      = "mode"
 	isVPNGatewayConnectionTunnels                   = "tunnels"
+	isVPNGatewayConnectionTunnelPublicIP            = "public_ip"
+	isVPNGatewayConnectionTunnelRole                = "role"
+	isVPNGatewayConnectionTunnelMemberStatus        = "member_status"
 	isVPNGatewayConnectionResourcetype              = "resource_type"
 	isVPNGatewayConnectionCreatedat                 = "created_at"
 )
 
 func resourceIBMISVPNGatewayConnection() *schema.Resource {
-	return &schema.Resource{
+	return resourceIBMISGen1StateCheck("ibm_is_vpn_gateway_connection", &schema.Resource{
 		Create:   resourceIBMISVPNGatewayConnectionCreate,
 		Read:     resourceIBMISVPNGatewayConnectionRead,
 		Update:   resourceIBMISVPNGatewayConnectionUpdate,
@@ -87,7 +92,6 @@ func resourceIBMISVPNGatewayConnection() *schema.Resource {
 			isVPNGatewayConnectionLocalCIDRS: {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				ForceNew:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
 				Description: "VPN gateway connection local CIDRs",
@@ -96,7 +100,6 @@ func resourceIBMISVPNGatewayConnection() *schema.Resource {
 			isVPNGatewayConnectionPeerCIDRS: {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				ForceNew:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
 				Description: "VPN gateway connection peer CIDRs",
@@ -176,25 +179,44 @@ func resourceIBMISVPNGatewayConnection() *schema.Resource {
 				Type:        schema.TypeList,
 				Computed:    true,
 				MinItems:    0,
-				Description: "The VPN tunnel configuration for this VPN gateway connection (in static route mode)",
+				Description: "The VPN tunnel configuration for this VPN gateway connection, one per VPN gateway member",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"address": {
 							Type:        schema.TypeString,
 							Computed:    true,
+							Deprecated:  "use public_ip instead",
 							Description: "The IP address of the VPN gateway member in which the tunnel resides",
 						},
 
+						isVPNGatewayConnectionTunnelPublicIP: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IP address of the VPN gateway member in which the tunnel resides",
+						},
+
+						isVPNGatewayConnectionTunnelRole: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The high availability role of the VPN gateway member",
+						},
+
+						isVPNGatewayConnectionTunnelMemberStatus: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the VPN gateway member",
+						},
+
 						"status": {
 							Type:        schema.TypeString,
 							Computed:    true,
-							Description: "The status of the VPN Tunnel",
+							Description: "The status of the VPN Tunnel (in static route mode)",
 						},
 					},
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceIBMISVPNGatewayConnectionValidator() *ResourceValidator {
@@ -529,21 +551,6 @@ func vpngwconGet(d *schema.ResourceData, meta interface{}, gID, gConnID string)
 		d.Set(isVPNGatewayConnectionMode, *vpnGatewayConnection.Mode)
 	}
 
-	if vpnGatewayConnection.Tunnels != nil {
-		vpcTunnelsList := make([]map[string]interface{}, 0)
-		for _, vpcTunnel := range vpnGatewayConnection.Tunnels {
-			currentTunnel := map[string]interface{}{}
-			if vpcTunnel.PublicIP != nil {
-				currentTunnel["address"] = *vpcTunnel.PublicIP.Address
-			}
-			if vpcTunnel.Status != nil {
-				currentTunnel["status"] = *vpcTunnel.Status
-			}
-			vpcTunnelsList = append(vpcTunnelsList, currentTunnel)
-		}
-		d.Set(isVPNGatewayConnectionTunnels, vpcTunnelsList)
-	}
-
 	d.Set(isVPNGatewayConnectionDeadPeerDetectionAction, *vpnGatewayConnection.DeadPeerDetection.Action)
 	d.Set(isVPNGatewayConnectionDeadPeerDetectionInterval, *vpnGatewayConnection.DeadPeerDetection.Interval)
 	d.Set(isVPNGatewayConnectionDeadPeerDetectionTimeout, *vpnGatewayConnection.DeadPeerDetection.Timeout)
@@ -555,10 +562,42 @@ func vpngwconGet(d *schema.ResourceData, meta interface{}, gID, gConnID string)
 		return fmt.Errorf("Error Getting VPN Gateway : %s\n%s", err, response)
 	}
 	vpngateway := vpngatewayIntf.(*vpcv1.VPNGateway)
+	d.Set(isVPNGatewayConnectionTunnels, flattenVPNGatewayConnectionTunnels(vpngateway.Members, vpnGatewayConnection.Tunnels))
 	d.Set(RelatedCRN, *vpngateway.CRN)
 	return nil
 }
 
+// flattenVPNGatewayConnectionTunnels returns a tunnel per member of the VPN
+// gateway. The status of the tunnel is only reported by connections in static
+// route mode, a connection in policy mode has the status of the members.
+func flattenVPNGatewayConnectionTunnels(members []vpcv1.VPNGatewayMember, tunnels []vpcv1.VPNGatewayConnectionStaticRouteModeTunnel) []map[string]interface{} {
+	tunnelStatus := make(map[string]string)
+	for _, tunnel := range tunnels {
+		if tunnel.PublicIP != nil && tunnel.PublicIP.Address != nil && tunnel.Status != nil {
+			tunnelStatus[*tunnel.PublicIP.Address] = *tunnel.Status
+		}
+	}
+	vpcTunnelsList := make([]map[string]interface{}, 0, len(members))
+	for _, member := range members {
+		currentTunnel := map[string]interface{}{}
+		if member.PublicIP != nil && member.PublicIP.Address != nil {
+			currentTunnel["address"] = *member.PublicIP.Address
+			currentTunnel[isVPNGatewayConnectionTunnelPublicIP] = *member.PublicIP.Address
+			if status, ok := tunnelStatus[*member.PublicIP.Address]; ok {
+				currentTunnel["status"] = status
+			}
+		}
+		if member.Role != nil {
+			currentTunnel[isVPNGatewayConnectionTunnelRole] = *member.Role
+		}
+		if member.Status != nil {
+			currentTunnel[isVPNGatewayConnectionTunnelMemberStatus] = *member.Status
+		}
+		vpcTunnelsList = append(vpcTunnelsList, currentTunnel)
+	}
+	return vpcTunnelsList
+}
+
 func resourceIBMISVPNGatewayConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
 	userDetails, err := meta.(ClientSession).BluemixUserDetails()
 	if err != nil {
@@ -664,7 +703,44 @@ func classicVpngwconUpdate(d *schema.ResourceData, meta interface{}, gID, gConnI
 			return fmt.Errorf("Error updating Vpn Gateway Connection: %s\n%s", err, response)
 		}
 	}
-	return nil
+
+	err = vpngwconUpdateCIDRs(d, isVPNGatewayConnectionLocalCIDRS,
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.AddVPNGatewayConnectionLocalCIDR(&vpcclassicv1.AddVPNGatewayConnectionLocalCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		},
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.RemoveVPNGatewayConnectionLocalCIDR(&vpcclassicv1.RemoveVPNGatewayConnectionLocalCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		})
+	if err != nil {
+		return err
+	}
+	return vpngwconUpdateCIDRs(d, isVPNGatewayConnectionPeerCIDRS,
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.AddVPNGatewayConnectionPeerCIDR(&vpcclassicv1.AddVPNGatewayConnectionPeerCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		},
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.RemoveVPNGatewayConnectionPeerCIDR(&vpcclassicv1.RemoveVPNGatewayConnectionPeerCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		})
 }
 
 func vpngwconUpdate(d *schema.ResourceData, meta interface{}, gID, gConnID string, hasChanged bool) error {
@@ -747,9 +823,89 @@ func vpngwconUpdate(d *schema.ResourceData, meta interface{}, gID, gConnID strin
 			return fmt.Errorf("Error updating Vpn Gateway Connection: %s\n%s", err, response)
 		}
 	}
+
+	err = vpngwconUpdateCIDRs(d, isVPNGatewayConnectionLocalCIDRS,
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.AddVPNGatewayConnectionLocalCIDR(&vpcv1.AddVPNGatewayConnectionLocalCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		},
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.RemoveVPNGatewayConnectionLocalCIDR(&vpcv1.RemoveVPNGatewayConnectionLocalCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		})
+	if err != nil {
+		return err
+	}
+	return vpngwconUpdateCIDRs(d, isVPNGatewayConnectionPeerCIDRS,
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.AddVPNGatewayConnectionPeerCIDR(&vpcv1.AddVPNGatewayConnectionPeerCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		},
+		func(prefix, length string) (*core.DetailedResponse, error) {
+			return sess.RemoveVPNGatewayConnectionPeerCIDR(&vpcv1.RemoveVPNGatewayConnectionPeerCIDROptions{
+				VPNGatewayID: &gID,
+				ID:           &gConnID,
+				CIDRPrefix:   &prefix,
+				PrefixLength: &length,
+			})
+		})
+}
+
+// vpngwconUpdateCIDRs adds the CIDRs added to the given local or peer CIDR set
+// and then removes the CIDRs removed from it, so that the connection keeps at
+// least one CIDR while they are replaced.
+func vpngwconUpdateCIDRs(d *schema.ResourceData, key string, add, remove func(prefix, length string) (*core.DetailedResponse, error)) error {
+	if !d.HasChange(key) {
+		return nil
+	}
+	o, n := d.GetChange(key)
+	oldSet := o.(*schema.Set)
+	newSet := n.(*schema.Set)
+	for _, cidr := range newSet.Difference(oldSet).List() {
+		prefix, length, err := vpngwconCIDRParts(cidr.(string))
+		if err != nil {
+			return err
+		}
+		response, err := add(prefix, length)
+		if err != nil {
+			return fmt.Errorf("Error adding CIDR %s to Vpn Gateway Connection %s: %s\n%s", cidr, key, err, response)
+		}
+	}
+	for _, cidr := range oldSet.Difference(newSet).List() {
+		prefix, length, err := vpngwconCIDRParts(cidr.(string))
+		if err != nil {
+			return err
+		}
+		response, err := remove(prefix, length)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			return fmt.Errorf("Error removing CIDR %s from Vpn Gateway Connection %s: %s\n%s", cidr, key, err, response)
+		}
+	}
 	return nil
 }
 
+// vpngwconCIDRParts splits a CIDR into the prefix and the prefix length used
+// by the VPN gateway connection CIDR APIs.
+func vpngwconCIDRParts(cidr string) (string, string, error) {
+	parts := strings.Split(cidr, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Incorrect CIDR %s: CIDR should be of the form prefix/length", cidr)
+	}
+	return parts[0], parts[1], nil
+}
+
 func resourceIBMISVPNGatewayConnectionDelete(d *schema.ResourceData, meta interface{}) error {
 	userDetails, err := meta.(ClientSession).BluemixUserDetails()
 	if err != nil {
@@ -43,7 +43,7 @@ func resourceIBMResourceInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -96,6 +96,8 @@ func resourceIBMResourceInstance() *schema.Resource {
 				Set:      resourceIBMVPCHash,
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -277,8 +279,8 @@ func resourceIBMResourceInstanceCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk("tags"); ok || v != "" {
-		oldList, newList := d.GetChange("tags")
+	if _, ok := d.GetOk("tags"); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, instance.Crn.String())
 		if err != nil {
 			log.Printf(
@@ -307,7 +309,7 @@ func resourceIBMResourceInstanceRead(d *schema.ResourceData, meta interface{}) e
 		log.Printf(
 			"Error on get of resource instance tags (%s) tags: %s", d.Id(), err)
 	}
-	d.Set("tags", tags)
+	setResourceTags(d, meta, tags)
 	d.Set("name", instance.Name)
 	d.Set("status", instance.State)
 	d.Set("resource_group_id", instance.ResourceGroupID)
@@ -447,8 +449,8 @@ func resourceIBMResourceInstanceUpdate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Error Getting resource instance: %s", err)
 	}
 
-	if d.HasChange("tags") {
-		oldList, newList := d.GetChange(isVPCTags)
+	if d.HasChange("tags") || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, instance.Crn.String())
 		if err != nil {
 			log.Printf(
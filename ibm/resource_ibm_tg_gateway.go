@@ -48,7 +48,7 @@ func resourceIBMTransitGateway() *schema.Resource {
 
 		CustomizeDiff: customdiff.Sequence(
 			func(diff *schema.ResourceDiff, v interface{}) error {
-				return resourceTagsCustomizeDiff(diff)
+				return resourceDefaultTagsCustomizeDiff(diff, v)
 			},
 		),
 
@@ -85,6 +85,8 @@ func resourceIBMTransitGateway() *schema.Resource {
 				Description: "Tags for the transit gateway instance",
 			},
 
+			tagsAll: tagsAllSchema(),
+
 			tgResourceGroup: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -204,8 +206,8 @@ func resourceIBMTransitGatewayCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	v := os.Getenv("IC_ENV_TAGS")
-	if _, ok := d.GetOk(tgGatewayTags); ok || v != "" {
-		oldList, newList := d.GetChange(tgGatewayTags)
+	if _, ok := d.GetOk(tgGatewayTags); ok || v != "" || len(meta.(ClientSession).DefaultTags()) > 0 {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *tgw.Crn)
 		if err != nil {
 			log.Printf(
@@ -285,7 +287,7 @@ func resourceIBMTransitGatewayRead(d *schema.ResourceData, meta interface{}) err
 		log.Printf(
 			"Error on get of transit gateway (%s) tags: %s", d.Id(), err)
 	}
-	d.Set(tgGatewayTags, tags)
+	setResourceTags(d, meta, tags)
 
 	controller, err := getBaseController(meta)
 	if err != nil {
@@ -336,8 +338,8 @@ func resourceIBMTransitGatewayUpdate(d *schema.ResourceData, meta interface{}) e
 			updateTransitGatewayOptions.Global = &global
 		}
 	}
-	if d.HasChange(tgGatewayTags) {
-		oldList, newList := d.GetChange(tgGatewayTags)
+	if d.HasChange(tgGatewayTags) || d.HasChange(tagsAll) {
+		oldList, newList := resourceTagsAllChange(d, meta)
 		err = UpdateTagsUsingCRN(oldList, newList, meta, *tgw.Crn)
 		if err != nil {
 			log.Printf(
@@ -43,6 +43,39 @@ func TestAccIBMTransitGateway_basic(t *testing.T) {
 	})
 }
 
+func TestMockIBMTransitGateway_basic(t *testing.T) {
+	var instance string
+	gatewayname := fmt.Sprintf("tg-gateway-name-%d", acctest.RandIntRange(10, 100))
+	newgatewayname := fmt.Sprintf("newgateway-name-%d", acctest.RandIntRange(10, 100))
+	location := "us-south"
+	mock := newMockBackend(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMTransitGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMTransitGatewayConfig(gatewayname, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMTransitGatewayExists("ibm_tg_gateway.test_tg_gateway", instance),
+					resource.TestCheckResourceAttr("ibm_tg_gateway.test_tg_gateway", "name", gatewayname),
+					resource.TestCheckResourceAttr("ibm_tg_gateway.test_tg_gateway", "global", "true"),
+				),
+			},
+			{
+				Config: testAccCheckIBMTransitGatewayConfig(newgatewayname, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMTransitGatewayExists("ibm_tg_gateway.test_tg_gateway", instance),
+					resource.TestCheckResourceAttr("ibm_tg_gateway.test_tg_gateway", "name", newgatewayname),
+				),
+			},
+		},
+	})
+	if n := mock.Count("transit_gateways"); n != 0 {
+		t.Fatalf("expected all mock transit gateways to be deleted, found %d", n)
+	}
+}
+
 func testAccCheckIBMTransitGatewayConfig(gatewayname, location string) string {
 	return fmt.Sprintf(`
 	  
@@ -1726,6 +1726,98 @@ func resourceTagsCustomizeDiff(diff *schema.ResourceDiff) error {
 	return nil
 }
 
+// tagsAll is the computed attribute of a taggable resource that holds its tags
+// merged with the provider default_tags.
+const tagsAll = "tags_all"
+
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         resourceIBMVPCHash,
+		Description: "List of tags of the resource, including the provider default tags",
+	}
+}
+
+// resourceDefaultTagsCustomizeDiff is the CustomizeDiff of every taggable
+// resource. It plans tags_all as the tags of the resource merged with the
+// provider default_tags, where a resource tag replaces the default tag with
+// the same key, e.g. "env:prod" replaces "env:dev".
+func resourceDefaultTagsCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if err := resourceTagsCustomizeDiff(diff); err != nil {
+		return err
+	}
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed(tagsAll)
+	}
+	all := mergeDefaultTags(defaultTags(meta), diff.Get("tags").(*schema.Set))
+	if old, _ := diff.GetChange(tagsAll); old.(*schema.Set).Equal(all) {
+		return nil
+	}
+	return diff.SetNew(tagsAll, all)
+}
+
+// resourceTagsAllChange returns the old and new tags_all of a resource being
+// created or updated. The new tags_all is merged again as the tags may have
+// been unknown at plan time.
+func resourceTagsAllChange(d *schema.ResourceData, meta interface{}) (interface{}, interface{}) {
+	old, _ := d.GetChange(tagsAll)
+	return old, mergeDefaultTags(defaultTags(meta), d.Get("tags").(*schema.Set))
+}
+
+// defaultTags returns the provider default_tags and the tags of IC_ENV_TAGS,
+// which are attached to every taggable resource.
+func defaultTags(meta interface{}) []string {
+	var tags []string
+	if sess, ok := meta.(ClientSession); ok {
+		tags = append(tags, sess.DefaultTags()...)
+	}
+	if v := os.Getenv("IC_ENV_TAGS"); v != "" {
+		tags = append(tags, strings.Split(v, ",")...)
+	}
+	return tags
+}
+
+func mergeDefaultTags(defaults []string, tags *schema.Set) *schema.Set {
+	all := newStringSet(resourceIBMVPCHash, nil)
+	keys := map[string]bool{}
+	for _, tag := range tags.List() {
+		all.Add(tag)
+		keys[tagKey(tag.(string))] = true
+	}
+	for _, tag := range defaults {
+		if !keys[tagKey(tag)] {
+			all.Add(tag)
+		}
+	}
+	return all
+}
+
+// tagKey returns the key of a "key:value" tag, or the tag itself.
+func tagKey(tag string) string {
+	return strings.SplitN(strings.TrimSpace(tag), ":", 2)[0]
+}
+
+// setResourceTags sets tags_all to the tags attached to a resource and tags to
+// the attached tags minus the default tags the resource does not configure
+// itself, so that default tags never show up as a diff of tags.
+func setResourceTags(d *schema.ResourceData, meta interface{}, attached *schema.Set) {
+	defaults := map[string]bool{}
+	for _, tag := range defaultTags(meta) {
+		defaults[tag] = true
+	}
+	configured := d.Get("tags").(*schema.Set)
+	tags := newStringSet(resourceIBMVPCHash, nil)
+	for _, tag := range attached.List() {
+		if !defaults[tag.(string)] || configured.Contains(tag) {
+			tags.Add(tag)
+		}
+	}
+	d.Set("tags", tags)
+	d.Set(tagsAll, attached)
+}
+
 func flattenRoleData(object []iampapv2.Role, roleType string) []map[string]string {
 	var roles []map[string]string
 
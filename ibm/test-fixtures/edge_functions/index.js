@@ -0,0 +1,3 @@
+addEventListener('fetch', (event) => {
+	event.respondWith(handleRequest(event.request))
+})
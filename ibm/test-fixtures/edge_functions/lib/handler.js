@@ -0,0 +1,10 @@
+/**
+ * Adds a greeting header to the response of the origin
+ * @param {Request} request
+ */
+async function handleRequest(request) {
+	const response = await fetch(request)
+	const result = new Response(response.body, response)
+	result.headers.set('X-Greeting', GREETING)
+	return result
+}
@@ -325,6 +325,13 @@ func validateURLPath(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+func validateServiceEndpoint(v interface{}, k string) (ws []string, errors []error) {
+	if err := validateEndpointURL(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
+
 func validateSecurityRuleDirection(v interface{}, k string) (ws []string, errors []error) {
 	validDirections := map[string]bool{
 		"ingress": true,
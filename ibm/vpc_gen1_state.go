@@ -0,0 +1,160 @@
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// VPC Generation 1 resources are identified by a plain UUID, while the
+// identifiers of VPC Generation 2 resources carry a four character prefix,
+// e.g. r006-0f2fbc47-4a71-4d7a-9c1a-0fcd0d5f8d36.
+var gen1ResourceIDRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// isGen1ResourceID reports whether the Terraform id of a VPC resource, or one
+// of the parts of a composite id such as vpnGatewayID/connectionID or
+// securityGroupID.ruleID, is the id of a VPC Generation 1 resource.
+func isGen1ResourceID(id string) bool {
+	parts := strings.FieldsFunc(id, func(r rune) bool {
+		return r == '/' || r == '.'
+	})
+	for _, part := range parts {
+		if gen1ResourceIDRegexp.MatchString(part) {
+			return true
+		}
+	}
+	return false
+}
+
+// gen1StateReferences returns the attributes of a raw resource state that hold
+// the id of a VPC Generation 1 resource, as "attribute = id", sorted by
+// attribute. Nested attributes use the flatmap notation, e.g.
+// primary_network_interface.0.subnet.
+func gen1StateReferences(rawState map[string]interface{}) []string {
+	references := []string{}
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		switch value := v.(type) {
+		case string:
+			if prefix != "id" && gen1ResourceIDRegexp.MatchString(value) {
+				references = append(references, fmt.Sprintf("%s = %s", prefix, value))
+			}
+		case []interface{}:
+			for i, elem := range value {
+				walk(fmt.Sprintf("%s.%d", prefix, i), elem)
+			}
+		case map[string]interface{}:
+			for k, elem := range value {
+				if prefix != "" {
+					k = prefix + "." + k
+				}
+				walk(k, elem)
+			}
+		}
+	}
+	walk("", rawState)
+	sort.Strings(references)
+	return references
+}
+
+// gen1StateError is returned when the state of a resource holds a VPC
+// Generation 1 resource while the provider is configured for Generation 2.
+type gen1StateError struct {
+	ResourceType string
+	ID           string
+	Name         string
+	References   []string
+}
+
+func (e *gen1StateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s", e.ResourceType)
+	if e.Name != "" {
+		fmt.Fprintf(&b, " %q", e.Name)
+	}
+	fmt.Fprintf(&b, " (%s) is a VPC Generation 1 resource, but the provider is configured for generation 2.\n", e.ID)
+	if len(e.References) > 0 {
+		b.WriteString("It also refers to these VPC Generation 1 resources:\n")
+		for _, reference := range e.References {
+			fmt.Fprintf(&b, "  %s\n", reference)
+		}
+	}
+	b.WriteString("Generation 1 resources can't be managed by a generation 2 provider: set generation = 1 in the provider block " +
+		"to keep managing them, or recreate them in Generation 2 and remove the Generation 1 resources from the state with terraform state rm.")
+	return b.String()
+}
+
+// checkGen1State checks the state of the VPC resource resourceType, given by
+// its id and flatmap attributes, for VPC Generation 1 resources. A
+// gen1StateError is returned when a Generation 2 provider finds a Generation 1
+// id.
+func checkGen1State(resourceType, id string, attributes map[string]string, generation int) error {
+	if generation == 1 || !isGen1ResourceID(id) {
+		return nil
+	}
+	rawState := make(map[string]interface{}, len(attributes))
+	for k, v := range attributes {
+		rawState[k] = v
+	}
+	return &gen1StateError{
+		ResourceType: resourceType,
+		ID:           id,
+		Name:         attributes["name"],
+		References:   gen1StateReferences(rawState),
+	}
+}
+
+// resourceIBMISGen1StateCheck makes Exists and Read of the VPC resource r
+// check its state for VPC Generation 1 resources before calling the API, see
+// checkGen1State. The check runs on every refresh, so it also catches state
+// that was written by a Generation 1 provider after the schema version was
+// bumped.
+func resourceIBMISGen1StateCheck(resourceType string, r *schema.Resource) *schema.Resource {
+	check := func(d *schema.ResourceData, meta interface{}) error {
+		session, ok := meta.(ClientSession)
+		if !ok || d.Id() == "" {
+			return nil
+		}
+		userDetails, err := session.BluemixUserDetails()
+		if err != nil {
+			return err
+		}
+		attributes := map[string]string{}
+		if state := d.State(); state != nil {
+			attributes = state.Attributes
+		}
+		return checkGen1State(resourceType, d.Id(), attributes, userDetails.generation)
+	}
+	if read := r.Read; read != nil {
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(d, meta); err != nil {
+				return err
+			}
+			return read(d, meta)
+		}
+	}
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			if err := check(d, meta); err != nil {
+				return false, err
+			}
+			return exists(d, meta)
+		}
+	}
+	// Schema version 1 was written by an earlier release that checked the
+	// state in a state upgrader, keep it so that such state stays readable
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+				return rawState, nil
+			},
+		},
+	}
+	return r
+}
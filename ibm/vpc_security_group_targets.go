@@ -0,0 +1,104 @@
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v4/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+// The security group targets API (/security_groups/{security_group_id}/targets) is not
+// part of vpc-go-sdk v0.4.0, which only knows about network interface attachments.
+// These calls go through the VPC client's BaseService the same way the generated SDK
+// methods do, and should be replaced by the SDK methods once the dependency is upgraded.
+
+const (
+	securityGroupTargetTypeNetworkInterface = "network_interface"
+	securityGroupTargetTypeLoadBalancer     = "load_balancer"
+	securityGroupTargetTypeEndpointGateway  = "endpoint_gateway"
+)
+
+// securityGroupTargetReference is a network interface, load balancer or endpoint gateway
+// a security group is attached to
+type securityGroupTargetReference struct {
+	CRN          *string `json:"crn,omitempty"`
+	Href         *string `json:"href,omitempty"`
+	ID           *string `json:"id"`
+	Name         *string `json:"name,omitempty"`
+	ResourceType *string `json:"resource_type,omitempty"`
+}
+
+type securityGroupTargetCollection struct {
+	Next *struct {
+		Href *string `json:"href"`
+	} `json:"next,omitempty"`
+	Targets []securityGroupTargetReference `json:"targets"`
+}
+
+func securityGroupTargetRequest(sess *vpcv1.VpcV1, method, path string, pathParamsMap map[string]string, query map[string]string, result interface{}) (*core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	_, err := builder.ResolveRequestURL(sess.Service.Options.URL, path, pathParamsMap)
+	if err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddQuery("version", fmt.Sprint(*sess.Version))
+	builder.AddQuery("generation", "2")
+	for name, value := range query {
+		builder.AddQuery(name, value)
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return sess.Service.Request(request, result)
+}
+
+func listSecurityGroupTargets(sess *vpcv1.VpcV1, securityGroupID string) ([]securityGroupTargetReference, *core.DetailedResponse, error) {
+	start := ""
+	allrecs := []securityGroupTargetReference{}
+	for {
+		query := map[string]string{}
+		if start != "" {
+			query["start"] = start
+		}
+		targets := &securityGroupTargetCollection{}
+		response, err := securityGroupTargetRequest(sess, core.GET, `/security_groups/{security_group_id}/targets`,
+			map[string]string{"security_group_id": securityGroupID}, query, targets)
+		if err != nil {
+			return nil, response, err
+		}
+		allrecs = append(allrecs, targets.Targets...)
+		start = GetNext(targets.Next)
+		if start == "" {
+			break
+		}
+	}
+	return allrecs, nil, nil
+}
+
+func getSecurityGroupTarget(sess *vpcv1.VpcV1, securityGroupID, targetID string) (*securityGroupTargetReference, *core.DetailedResponse, error) {
+	target := &securityGroupTargetReference{}
+	response, err := securityGroupTargetRequest(sess, core.GET, `/security_groups/{security_group_id}/targets/{id}`,
+		map[string]string{"security_group_id": securityGroupID, "id": targetID}, nil, target)
+	if err != nil {
+		return nil, response, err
+	}
+	return target, response, nil
+}
+
+func createSecurityGroupTargetBinding(sess *vpcv1.VpcV1, securityGroupID, targetID string) (*securityGroupTargetReference, *core.DetailedResponse, error) {
+	target := &securityGroupTargetReference{}
+	response, err := securityGroupTargetRequest(sess, core.PUT, `/security_groups/{security_group_id}/targets/{id}`,
+		map[string]string{"security_group_id": securityGroupID, "id": targetID}, nil, target)
+	if err != nil {
+		return nil, response, err
+	}
+	return target, response, nil
+}
+
+func deleteSecurityGroupTargetBinding(sess *vpcv1.VpcV1, securityGroupID, targetID string) (*core.DetailedResponse, error) {
+	return securityGroupTargetRequest(sess, core.DELETE, `/security_groups/{security_group_id}/targets/{id}`,
+		map[string]string{"security_group_id": securityGroupID, "id": targetID}, nil, nil)
+}